@@ -1,9 +1,12 @@
 package api
 
+import "go-clean-architecture/internal/util/query"
+
 type ApiResponse struct {
 	Data    *interface{} `json:"Data"`
 	Code    byte         `json:"Code"`
 	Message string       `json:"Message"`
+	Page    *query.Page  `json:"Page,omitempty"`
 }
 
 func Ok(data interface{}) *ApiResponse {
@@ -16,6 +19,15 @@ func Ok(data interface{}) *ApiResponse {
 	return &apiResponse
 }
 
+// OkPage
+// Returns a successful response of a list endpoint with its page metadata.
+func OkPage(data interface{}, page query.Page) *ApiResponse {
+	apiResponse := Ok(data)
+	apiResponse.Page = &page
+
+	return apiResponse
+}
+
 func Error(message string) *ApiResponse {
 	apiResponse := ApiResponse{
 		Data:    nil,
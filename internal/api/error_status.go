@@ -0,0 +1,25 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"go-clean-architecture/internal/util/customerror"
+)
+
+// errorStatus
+// Maps sentinel errors to HTTP status codes. Returns fallback if error has no specific status.
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, customerror.ErrLockedOut), errors.Is(err, customerror.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, customerror.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, customerror.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, customerror.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-clean-architecture/internal/util/customerror"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidIfMatch = customerror.New(fmt.Errorf("If-Match must be a single entity tag, %w", customerror.ErrPreconditionFailed), customerror.LogLevelInfo)
+
+// ETag
+// Returns the entity tag of given version of a resource.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetETag
+// Sets the ETag header to the entity tag of given version of the resource in response.
+func SetETag(context *gin.Context, version int) {
+	context.Header("ETag", ETag(version))
+}
+
+// IfMatchVersion
+// Returns the version in the If-Match header, zero if there is none or it is *, so updates are unconditional.
+// Fails with customerror.ErrPreconditionFailed if the header is not a single strong entity tag of a version.
+func IfMatchVersion(context *gin.Context) (int, error) {
+	header := strings.TrimSpace(context.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	version, ok := parseETag(header)
+	if !ok {
+		return 0, errInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+// IsNotModified
+// Returns true if the If-None-Match header has the entity tag of given version, weak or strong, or is *.
+// The request should be answered with 304 Not Modified.
+func IsNotModified(context *gin.Context, version int) bool {
+	header := strings.TrimSpace(context.GetHeader("If-None-Match"))
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tagVersion, ok := parseETag(strings.TrimPrefix(strings.TrimSpace(tag), "W/"))
+		if ok && tagVersion == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseETag
+// Returns the version of a strong entity tag written by ETag.
+func parseETag(tag string) (int, bool) {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-clean-architecture/internal/util/customerror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ETagTestSuite struct {
+	suite.Suite
+}
+
+// Run suite.
+func TestETag(t *testing.T) {
+	suite.Run(t, new(ETagTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *ETagTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+// context
+// Returns a context of a request with given header.
+func (s *ETagTestSuite) context(name string, value string) *gin.Context {
+	context, _ := gin.CreateTestContext(httptest.NewRecorder())
+	context.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		context.Request.Header.Set(name, value)
+	}
+
+	return context
+}
+
+func (s *ETagTestSuite) TestIfMatchVersion_ValidHeaders_ReturnsVersion() {
+	headers := map[string]int{
+		"":      0,
+		"*":     0,
+		`"3"`:   3,
+		` "7" `: 7,
+	}
+
+	for header, expected := range headers {
+		// When
+		version, err := IfMatchVersion(s.context("If-Match", header))
+
+		// Then
+		s.NoError(err, header)
+		s.Equal(expected, version, header)
+	}
+}
+
+func (s *ETagTestSuite) TestIfMatchVersion_InvalidHeaders_ReturnsPreconditionFailed() {
+	for _, header := range []string{`3`, `W/"3"`, `"3", "4"`, `"abc"`, `"0"`, `"`} {
+		// When
+		_, err := IfMatchVersion(s.context("If-Match", header))
+
+		// Then
+		s.ErrorIs(err, customerror.ErrPreconditionFailed, header)
+		s.Equal(http.StatusPreconditionFailed, errorStatus(err, http.StatusOK), header)
+	}
+}
+
+func (s *ETagTestSuite) TestIsNotModified_Headers_ComparesWithVersion() {
+	headers := map[string]bool{
+		"":             false,
+		"*":            true,
+		`"2"`:          true,
+		`W/"2"`:        true,
+		`"1", W/"2"`:   true,
+		`"1"`:          false,
+		`"1", "3"`:     false,
+		`not-an-etag`:  false,
+		`"2"-modified`: false,
+	}
+
+	for header, expected := range headers {
+		// When
+		isNotModified := IsNotModified(s.context("If-None-Match", header), 2)
+
+		// Then
+		s.Equal(expected, isNotModified, header)
+	}
+}
+
+func (s *ETagTestSuite) TestSetETag_Version_SetsQuotedHeader() {
+	// Given
+	recorder := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(recorder)
+
+	// When
+	SetETag(context, 5)
+
+	// Then
+	s.Equal(`"5"`, recorder.Header().Get("ETag"))
+}
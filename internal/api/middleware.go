@@ -1,60 +1,212 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"go-clean-architecture/internal/service/apikey"
+	"go-clean-architecture/internal/service/revocation"
 	"go-clean-architecture/internal/util/customerror"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"go-clean-architecture/internal/util"
+	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/keyring"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/requestcontext"
+	"go-clean-architecture/internal/util/validator"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// defaultRequestTimeout
+// Used when APP_REQUEST_TIMEOUT is not set.
+const defaultRequestTimeout = 30 * time.Second
+
+const requestIdHeader = "X-Request-Id"
+
+// requestIdRegexp
+// Matches request ids accepted from clients, so arbitrary values are not written to logs.
+var requestIdRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
 // AuthenticationMiddleware
-// Checks JWT token if it's valid or not.
-func AuthenticationMiddleware(environment env.IEnvironment) gin.HandlerFunc {
+// Checks JWT token if it's valid or not, or the api key in X-Api-Key header if it is given instead.
+// Revoked tokens are rejected, revocations are cached in process for a few seconds.
+func AuthenticationMiddleware(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) gin.HandlerFunc {
+	keyrng := keyring.New(environment)
+	apiKeyService := apikey.NewApiKeyService(environment, loggr, validatr, cachr, nil, nil)
+	revocationService := revocation.NewRevocationService(environment, loggr, validatr, cachr, nil)
+
 	return func(c *gin.Context) {
+		if apiKey := c.GetHeader("X-Api-Key"); apiKey != "" {
+			authenticateApiKey(c, apiKeyService, apiKey)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		tokenString := strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(authHeader, "Bearer", ""), "bearer", ""))
 
-		token, err := jwt.ParseWithClaims(
-			tokenString, &util.CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-				return []byte(environment.Get(env.JwtSecret)), nil
-			},
-		)
-
-		if err != nil || !token.Valid {
+		claims, err := util.ParseClaims(tokenString, keyrng)
+		if err != nil {
 			c.Header("Content-Type", "application/json; charset=utf-8")
 			c.AbortWithError(http.StatusUnauthorized, errors.New("JWT token is invalid"))
 			return
 		}
 
-		claims, ok := token.Claims.(*util.CustomClaims)
-		if !ok {
+		userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+		if err != nil || claims.IssuedAt == nil {
 			c.Header("Content-Type", "application/json; charset=utf-8")
 			c.AbortWithError(http.StatusUnauthorized, errors.New("JWT token is invalid"))
 			return
 		}
 
+		ch := make(chan *revocation.IsRevokedServiceResponse)
+		defer close(ch)
+
+		go revocationService.IsRevoked(c.Request.Context(), ch, &revocation.IsRevokedServiceModel{
+			Jti:      claims.ID,
+			UserId:   userId,
+			IssuedAt: claims.IssuedAt.Time,
+		})
+
+		isRevokedResponse := <-ch
+		if isRevokedResponse.Error != nil {
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			c.AbortWithError(http.StatusInternalServerError, isRevokedResponse.Error)
+			return
+		}
+
+		if isRevokedResponse.IsRevoked {
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			c.AbortWithError(http.StatusUnauthorized, customerror.New(errors.New("JWT token is revoked"), customerror.LogLevelInfo))
+			return
+		}
+
 		c.Set(helper.UserId, claims.Subject)
 		c.Set(helper.UserName, claims.Username)
 		c.Set(helper.UserEmail, claims.Email)
+		c.Set(helper.UserRoles, claims.Roles)
+		c.Set(helper.UserPermissions, claims.Permissions)
+		c.Set(helper.TokenId, claims.ID)
+		c.Set(helper.TokenIssuedAt, claims.IssuedAt.Time)
+		if claims.ExpiresAt != nil {
+			c.Set(helper.TokenExpiresAt, claims.ExpiresAt.Time)
+		}
+		c.Request = c.Request.WithContext(requestcontext.WithUser(c.Request.Context(), requestcontext.User{
+			Id:       userId,
+			UserName: claims.Username,
+		}))
+
+		c.Next()
+	}
+}
+
+// authenticateApiKey
+// Sets user of the api key to the context. Api keys carry no roles, only the permissions they are scoped to.
+func authenticateApiKey(c *gin.Context, apiKeyService apikey.IApiKeyService, apiKey string) {
+	ch := make(chan *apikey.AuthenticateServiceResponse)
+	defer close(ch)
+
+	go apiKeyService.Authenticate(c.Request.Context(), ch, &apikey.AuthenticateServiceModel{ApiKey: apiKey})
 
-		/* Later, if we implement permissions feature;
-		We can get claims and check if token has required claims to access any given endpoint. */
+	response := <-ch
+	if response.Error != nil {
+		statusCode := http.StatusUnauthorized
+		var customerr *customerror.Error
+		if !errors.As(response.Error, &customerr) {
+			statusCode = http.StatusInternalServerError
+		}
+
+		c.Header("Content-Type", "application/json; charset=utf-8")
+		c.AbortWithError(statusCode, response.Error)
+		return
+	}
+
+	c.Set(helper.UserId, strconv.FormatInt(response.UserId, 10))
+	c.Set(helper.UserName, response.UserName)
+	c.Set(helper.UserEmail, response.Email)
+	c.Set(helper.UserRoles, []string{})
+	c.Set(helper.UserPermissions, response.Permissions)
+	c.Set(helper.ApiKeyId, response.ApiKeyId)
+	c.Request = c.Request.WithContext(requestcontext.WithUser(c.Request.Context(), requestcontext.User{
+		Id:       response.UserId,
+		UserName: response.UserName,
+		ApiKeyId: response.ApiKeyId,
+	}))
+
+	c.Next()
+}
+
+// RequirePermission
+// Checks if authenticated user has all of the given permissions.
+// Must be used after AuthenticationMiddleware.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, permission := range permissions {
+			if !helper.HasPermission(c, permission) {
+				c.Header("Content-Type", "application/json; charset=utf-8")
+				c.AbortWithError(http.StatusForbidden, customerror.New(errors.New("permission is required: "+permission), customerror.LogLevelWarn))
+				return
+			}
+		}
 
 		c.Next()
 	}
 }
 
+// RequireRole
+// Checks if authenticated user has at least one of the given roles.
+// Must be used after AuthenticationMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, role := range roles {
+			if helper.HasRole(c, role) {
+				c.Next()
+				return
+			}
+		}
+
+		c.Header("Content-Type", "application/json; charset=utf-8")
+		c.AbortWithError(http.StatusForbidden, customerror.New(errors.New("one of the roles is required: "+strings.Join(roles, ", ")), customerror.LogLevelWarn))
+	}
+}
+
+// RequestContextMiddleware
+// Sets a deadline and a request id to the request context, so services and data types called with it
+// are cancelled when the client disconnects or the deadline passes. Request id is taken from X-Request-Id header
+// if it is valid, otherwise a new one is generated, and it is returned in the same header.
+func RequestContextMiddleware(environment env.IEnvironment) gin.HandlerFunc {
+	timeout := defaultRequestTimeout
+	if value := environment.Get(env.AppRequestTimeout); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 1 {
+			panic("Couldn't convert " + env.AppRequestTimeout + " environment variable to a positive int !")
+		}
+		timeout = time.Second * time.Duration(seconds)
+	}
+
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(requestIdHeader)
+		if !requestIdRegexp.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+		c.Header(requestIdHeader, requestId)
+
+		ctx, cancel := context.WithTimeout(requestcontext.WithRequestId(c.Request.Context(), requestId), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 // LoggingMiddleware
 // Logs HTTP requests with a predefined structure.
 func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
@@ -63,6 +215,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 
 		c.Next()
 
+		requestId := requestcontext.GetRequestId(c.Request.Context())
 		host := c.Request.Host
 		route := c.FullPath()
 		remoteAddr := c.Request.RemoteAddr
@@ -75,6 +228,10 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 		statusCode := c.Writer.Status()
 		hasError := len(c.Errors.Errors()) > 0
 
+		if hasError && !c.Writer.Written() {
+			statusCode = errorStatus(c.Errors.Last().Err, statusCode)
+		}
+
 		var customerrors []*customerror.Error
 		for _, err := range c.Errors {
 			var customerr *customerror.Error
@@ -112,6 +269,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 				if len(errError) > 0 {
 					loggr.Error(
 						logMessage,
+						zap.String("requestId", requestId),
 						zap.String("host", host),
 						zap.String("route", route),
 						zap.String("protocol", protocol),
@@ -129,6 +287,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 				if len(errWarn) > 0 {
 					loggr.Warn(
 						logMessage,
+						zap.String("requestId", requestId),
 						zap.String("host", host),
 						zap.String("route", route),
 						zap.String("protocol", protocol),
@@ -146,6 +305,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 				if len(errInfo) > 0 {
 					loggr.Info(
 						logMessage,
+						zap.String("requestId", requestId),
 						zap.String("host", host),
 						zap.String("route", route),
 						zap.String("protocol", protocol),
@@ -162,6 +322,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 			} else {
 				loggr.Info(
 					logMessage,
+					zap.String("requestId", requestId),
 					zap.String("host", host),
 					zap.String("route", route),
 					zap.String("protocol", protocol),
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/requestcontext"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+}
+
+// Run suite.
+func TestMiddleware(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *MiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve
+// Serves a request through a router which sets given claims before the middleware under test.
+func (s *MiddlewareTestSuite) serve(middleware gin.HandlerFunc, roles []string, permissions []string) int {
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		c.Set(helper.UserRoles, roles)
+		c.Set(helper.UserPermissions, permissions)
+	}, middleware, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	return recorder.Code
+}
+
+func (s *MiddlewareTestSuite) TestRequirePermission_HasAllPermissions_Passes() {
+	// When
+	code := s.serve(RequirePermission("sample:publish", "sample:read"), nil, []string{"sample:read", "sample:publish"})
+
+	// Then
+	s.Equal(http.StatusOK, code)
+}
+
+func (s *MiddlewareTestSuite) TestRequirePermission_MissingPermission_ReturnsForbidden() {
+	// When
+	code := s.serve(RequirePermission("sample:publish", "sample:read"), nil, []string{"sample:read"})
+
+	// Then
+	s.Equal(http.StatusForbidden, code)
+}
+
+func (s *MiddlewareTestSuite) TestRequireRole_HasOneOfRoles_Passes() {
+	// When
+	code := s.serve(RequireRole("admin", "sample-publisher"), []string{"sample-publisher"}, nil)
+
+	// Then
+	s.Equal(http.StatusOK, code)
+}
+
+func (s *MiddlewareTestSuite) TestRequireRole_HasNoneOfRoles_ReturnsForbidden() {
+	// When
+	code := s.serve(RequireRole("admin"), []string{"sample-publisher"}, nil)
+
+	// Then
+	s.Equal(http.StatusForbidden, code)
+}
+
+// serveWithRequestContext
+// Serves a request through RequestContextMiddleware and returns the response and the context seen by the handler.
+func (s *MiddlewareTestSuite) serveWithRequestContext(requestId string) (*httptest.ResponseRecorder, context.Context) {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	mockEnvironment := env.NewMockIEnvironment(ctrl)
+	mockEnvironment.EXPECT().Get(env.AppRequestTimeout).Return("5")
+
+	var ctx context.Context
+	router := gin.New()
+	router.GET("/", RequestContextMiddleware(mockEnvironment), func(c *gin.Context) {
+		ctx = c.Request.Context()
+		c.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	if requestId != "" {
+		request.Header.Set("X-Request-Id", requestId)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder, ctx
+}
+
+func (s *MiddlewareTestSuite) TestRequestContextMiddleware_RequestIdGiven_KeepsRequestId() {
+	// When
+	recorder, ctx := s.serveWithRequestContext("client-request-1")
+
+	// Then
+	s.Equal("client-request-1", recorder.Header().Get("X-Request-Id"))
+	s.Equal("client-request-1", requestcontext.GetRequestId(ctx))
+}
+
+func (s *MiddlewareTestSuite) TestRequestContextMiddleware_InvalidRequestId_GeneratesRequestId() {
+	// When
+	recorder, ctx := s.serveWithRequestContext("bad id\n")
+
+	// Then
+	requestId := requestcontext.GetRequestId(ctx)
+	s.Len(requestId, 36)
+	s.Equal(requestId, recorder.Header().Get("X-Request-Id"))
+}
+
+func (s *MiddlewareTestSuite) TestRequestContextMiddleware_SetsDeadline_CancelsAfterRequest() {
+	// When
+	_, ctx := s.serveWithRequestContext("")
+
+	// Then
+	deadline, ok := ctx.Deadline()
+	s.True(ok)
+	s.WithinDuration(time.Now().Add(time.Second*5), deadline, time.Second)
+	s.ErrorIs(ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,193 @@
+package apikey
+
+import (
+	"go-clean-architecture/internal/service/apikey"
+	"net/http"
+	"strconv"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IApiKeyController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	AddApiKey(context *gin.Context)
+	GetApiKeys(context *gin.Context)
+	RevokeApiKey(context *gin.Context)
+}
+
+type ApiKeyController struct {
+	path          string
+	environment   env.IEnvironment
+	loggr         logger.ILogger
+	validatr      validator.IValidator
+	cachr         cacher.ICacher
+	apiKeyService apikey.IApiKeyService
+}
+
+// NewApiKeyController
+// Returns a new ApiKeyController.
+func NewApiKeyController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, apiKeyService apikey.IApiKeyService) IApiKeyController {
+	controller := ApiKeyController{
+		path:        "api-keys",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if apiKeyService != nil {
+		controller.apiKeyService = apiKeyService
+	} else {
+		controller.apiKeyService = apikey.NewApiKeyService(environment, loggr, validatr, cachr, nil, nil)
+	}
+
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *ApiKeyController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr), api.RequirePermission(permission.ApiKeysManage))
+	routes.POST("", c.AddApiKey)
+	routes.GET("", c.GetApiKeys)
+	routes.DELETE(":id", c.RevokeApiKey)
+}
+
+// AddApiKey
+// @basePath     /api
+// @router       /v1/api-keys [post]
+// @tags         ApiKey
+// @summary      Issues an api key for a programmatic user.
+// @description  Returns the api key only once, it cannot be retrieved later. Scopes must be permissions granted to the user.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      201         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      AddApiKeyModel  true  "UserId, Name, Scopes and optional ExpiryDate"
+func (c *ApiKeyController) AddApiKey(context *gin.Context) {
+	var model AddApiKeyModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *apikey.AddApiKeyServiceResponse)
+	defer close(ch)
+
+	go c.apiKeyService.AddApiKey(context.Request.Context(), ch, &apikey.AddApiKeyServiceModel{
+		UserId:     model.UserId,
+		Name:       model.Name,
+		Scopes:     model.Scopes,
+		ExpiryDate: model.ExpiryDate,
+		CreatedBy:  helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusCreated, api.Ok(serviceResponse))
+}
+
+// GetApiKeys
+// @basePath     /api
+// @router       /v1/api-keys [get]
+// @tags         ApiKey
+// @summary      Gets api keys.
+// @description  Lists api keys without their secrets, newest first.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true   "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true   "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        userId      query     int     false  "User filter."
+func (c *ApiKeyController) GetApiKeys(context *gin.Context) {
+	var model GetApiKeysModel
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *apikey.GetApiKeysServiceResponse)
+	defer close(ch)
+
+	go c.apiKeyService.GetApiKeys(context.Request.Context(), ch, &apikey.GetApiKeysServiceModel{
+		UserId: model.UserId,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// RevokeApiKey
+// @basePath     /api
+// @router       /v1/api-keys/{id} [delete]
+// @tags         ApiKey
+// @summary      Revokes an api key.
+// @description  Requests with the api key are rejected immediately.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "Api key id"
+func (c *ApiKeyController) RevokeApiKey(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *apikey.RevokeApiKeyServiceResponse)
+	defer close(ch)
+
+	go c.apiKeyService.RevokeApiKey(context.Request.Context(), ch, &apikey.RevokeApiKeyServiceModel{
+		Id:        id,
+		RevokedBy: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/apikey/apikey_controller.go
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIApiKeyController is a mock of IApiKeyController interface.
+type MockIApiKeyController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIApiKeyControllerMockRecorder
+}
+
+// MockIApiKeyControllerMockRecorder is the mock recorder for MockIApiKeyController.
+type MockIApiKeyControllerMockRecorder struct {
+	mock *MockIApiKeyController
+}
+
+// NewMockIApiKeyController creates a new mock instance.
+func NewMockIApiKeyController(ctrl *gomock.Controller) *MockIApiKeyController {
+	mock := &MockIApiKeyController{ctrl: ctrl}
+	mock.recorder = &MockIApiKeyControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIApiKeyController) EXPECT() *MockIApiKeyControllerMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockIApiKeyController) AddApiKey(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddApiKey", context)
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockIApiKeyControllerMockRecorder) AddApiKey(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockIApiKeyController)(nil).AddApiKey), context)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIApiKeyController) GetApiKeys(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetApiKeys", context)
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIApiKeyControllerMockRecorder) GetApiKeys(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIApiKeyController)(nil).GetApiKeys), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIApiKeyController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIApiKeyControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIApiKeyController)(nil).RegisterRoutes), routerGroup)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockIApiKeyController) RevokeApiKey(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeApiKey", context)
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIApiKeyControllerMockRecorder) RevokeApiKey(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIApiKeyController)(nil).RevokeApiKey), context)
+}
@@ -0,0 +1,14 @@
+package apikey
+
+import "time"
+
+type AddApiKeyModel struct {
+	UserId     int64      `json:"UserId"`
+	Name       string     `json:"Name"`
+	Scopes     []string   `json:"Scopes"`
+	ExpiryDate *time.Time `json:"ExpiryDate"`
+}
+
+type GetApiKeysModel struct {
+	UserId int64 `form:"userId"`
+}
@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"net/http"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/service/audit"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IAuditController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	GetAuditEvents(context *gin.Context)
+}
+
+type AuditController struct {
+	path         string
+	environment  env.IEnvironment
+	loggr        logger.ILogger
+	validatr     validator.IValidator
+	cachr        cacher.ICacher
+	auditService audit.IAuditService
+}
+
+// NewAuditController
+// Returns a new AuditController.
+func NewAuditController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, auditService audit.IAuditService) IAuditController {
+	controller := AuditController{
+		path:        "auth/audit-events",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if auditService != nil {
+		controller.auditService = auditService
+	} else {
+		controller.auditService = audit.NewAuditService(environment, loggr, validatr, cachr, nil)
+	}
+
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *AuditController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr), api.RequirePermission(permission.AuthAuditRead))
+	routes.GET("", c.GetAuditEvents)
+}
+
+// GetAuditEvents
+// @basePath     /api
+// @router       /v1/auth/audit-events [get]
+// @tags         Auth
+// @summary      Gets authentication audit events.
+// @description  Lists logins, failed logins, lockouts, token refreshes, programmatic token issuances and logouts, newest first. Events are written asynchronously, so the latest ones may appear after a second.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        userId      query     int     false  "User filter."
+// @Param        eventType   query     string  false  "Event type filter."  Enums(login.succeeded, login.failed, login.locked_out, login.mfa_challenged, token.refreshed, token.refresh_failed, token.reused, token.programmatic_issued, logout, logout.all)
+// @Param        from        query     string  false  "Events at or after this time, RFC 3339."
+// @Param        to          query     string  false  "Events before this time, RFC 3339."
+// @Param        limit       query     int     false  "Maximum number of events."  default(50)
+func (c *AuditController) GetAuditEvents(context *gin.Context) {
+	model := GetAuditEventsModel{Limit: 50}
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *audit.GetEventsServiceResponse)
+	defer close(ch)
+
+	go c.auditService.GetEvents(context.Request.Context(), ch, &audit.GetEventsServiceModel{
+		UserId:    model.UserId,
+		EventType: model.EventType,
+		From:      model.From,
+		To:        model.To,
+		Limit:     model.Limit,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
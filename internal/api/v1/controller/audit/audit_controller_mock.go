@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/audit/audit_controller.go
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAuditController is a mock of IAuditController interface.
+type MockIAuditController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditControllerMockRecorder
+}
+
+// MockIAuditControllerMockRecorder is the mock recorder for MockIAuditController.
+type MockIAuditControllerMockRecorder struct {
+	mock *MockIAuditController
+}
+
+// NewMockIAuditController creates a new mock instance.
+func NewMockIAuditController(ctrl *gomock.Controller) *MockIAuditController {
+	mock := &MockIAuditController{ctrl: ctrl}
+	mock.recorder = &MockIAuditControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditController) EXPECT() *MockIAuditControllerMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockIAuditController) GetAuditEvents(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuditEvents", context)
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockIAuditControllerMockRecorder) GetAuditEvents(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockIAuditController)(nil).GetAuditEvents), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIAuditController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIAuditControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIAuditController)(nil).RegisterRoutes), routerGroup)
+}
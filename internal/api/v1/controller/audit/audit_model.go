@@ -0,0 +1,11 @@
+package audit
+
+import "time"
+
+type GetAuditEventsModel struct {
+	UserId    int64      `form:"userId"`
+	EventType string     `form:"eventType"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit     int        `form:"limit"`
+}
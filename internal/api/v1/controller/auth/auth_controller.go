@@ -1,51 +1,73 @@
 package auth
 
 import (
-	"fmt"
+	"errors"
 	"go-clean-architecture/internal/service/auth"
 	"net/http"
+	"strings"
 
 	"go-clean-architecture/internal/api"
 	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/helper"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
 	"go-clean-architecture/internal/util/validator"
 
 	"github.com/gin-gonic/gin"
 )
 
+// oidcStateCookie
+// Binds an OIDC login to the browser which started it.
+const oidcStateCookie = "oidc_state"
+
 type IAuthController interface {
 	RegisterRoutes(routerGroup *gin.RouterGroup)
 	Login(context *gin.Context)
 	GetAccessToken(context *gin.Context)
 	GetProgrammaticAccessToken(context *gin.Context)
+	Register(context *gin.Context)
+	Activate(context *gin.Context)
+	Logout(context *gin.Context)
+	LogoutAll(context *gin.Context)
+	VerifyMfa(context *gin.Context)
+	OidcLogin(context *gin.Context)
+	OidcCallback(context *gin.Context)
+	ForgotPassword(context *gin.Context)
+	ResetPassword(context *gin.Context)
+	ChangePassword(context *gin.Context)
+	Introspect(context *gin.Context)
+	GetSessions(context *gin.Context)
+	RevokeSession(context *gin.Context)
 }
 
 type AuthController struct {
-	path        string
-	environment env.IEnvironment
-	loggr       logger.ILogger
-	validatr    validator.IValidator
-	cachr       cacher.ICacher
-	authService auth.IAuthService
+	path         string
+	environment  env.IEnvironment
+	loggr        logger.ILogger
+	validatr     validator.IValidator
+	cachr        cacher.ICacher
+	authService  auth.IAuthService
+	secureCookie bool
 }
 
 // NewAuthController
 // Returns a new AuthController.
 func NewAuthController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, authService auth.IAuthService) IAuthController {
 	controller := AuthController{
-		path:        "auth",
-		environment: environment,
-		loggr:       loggr,
-		validatr:    validatr,
-		cachr:       cachr,
+		path:         "auth",
+		environment:  environment,
+		loggr:        loggr,
+		validatr:     validatr,
+		cachr:        cachr,
+		secureCookie: isSecureCookie(environment),
 	}
 
 	if authService != nil {
 		controller.authService = authService
 	} else {
-		controller.authService = auth.NewAuthService(environment, loggr, validatr, cachr, nil)
+		controller.authService = auth.NewAuthService(environment, loggr, validatr, cachr, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	}
 
 	return &controller
@@ -58,8 +80,21 @@ func (c *AuthController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	routes.POST("login", c.Login)
 	routes.POST("access-token", c.GetAccessToken)
 	routes.POST("access-token/programmatic", c.GetProgrammaticAccessToken)
-	routes.Use(api.AuthenticationMiddleware(c.environment))
+	routes.POST("register", c.Register)
+	routes.POST("activate", c.Activate)
+	routes.POST("mfa/verify", c.VerifyMfa)
+	routes.GET("oidc/login", c.OidcLogin)
+	routes.GET("oidc/callback", c.OidcCallback)
+	routes.POST("password/forgot", c.ForgotPassword)
+	routes.POST("password/reset", c.ResetPassword)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr))
 	routes.GET("", c.Get)
+	routes.POST("logout", c.Logout)
+	routes.POST("logout/all", c.LogoutAll)
+	routes.PUT("password", c.ChangePassword)
+	routes.GET("sessions", c.GetSessions)
+	routes.DELETE("sessions/:id", c.RevokeSession)
+	routes.POST("introspect", api.RequirePermission(permission.AuthTokensIntrospect), c.Introspect)
 }
 
 // Login
@@ -67,7 +102,7 @@ func (c *AuthController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 // @router       /v1/auth/login [post]
 // @tags         Auth
 // @summary      Log in and get JWT token via username and password.
-// @description  Generates JWT token if user can log in successfully.
+// @description  Generates JWT token if user can log in successfully. Returns MfaChallengeToken instead if user has MFA enabled.
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -75,6 +110,7 @@ func (c *AuthController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 // @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
 // @Param        model       body      LoginModel  true  "Username and password"
 func (c *AuthController) Login(context *gin.Context) {
@@ -88,9 +124,11 @@ func (c *AuthController) Login(context *gin.Context) {
 	ch := make(chan *auth.LoginServiceResponse)
 	defer close(ch)
 
-	go c.authService.Login(ch, &auth.LoginServiceModel{
-		UserName: model.UserName,
-		Password: model.Password,
+	go c.authService.Login(context.Request.Context(), ch, &auth.LoginServiceModel{
+		UserName:  model.UserName,
+		Password:  model.Password,
+		ClientIp:  context.ClientIP(),
+		UserAgent: context.Request.UserAgent(),
 	})
 
 	serviceResponse := <-ch
@@ -129,8 +167,10 @@ func (c *AuthController) GetAccessToken(context *gin.Context) {
 	ch := make(chan *auth.LoginServiceResponse)
 	defer close(ch)
 
-	go c.authService.GetAccessToken(ch, &auth.GetAccessTokenServiceModel{
+	go c.authService.GetAccessToken(context.Request.Context(), ch, &auth.GetAccessTokenServiceModel{
 		RefreshToken: model.RefreshToken,
+		ClientIp:     context.ClientIP(),
+		UserAgent:    context.Request.UserAgent(),
 	})
 
 	serviceResponse := <-ch
@@ -148,7 +188,8 @@ func (c *AuthController) GetAccessToken(context *gin.Context) {
 // @router       /v1/auth/access-token/programmatic [post]
 // @tags         Auth
 // @summary      Gets access token with expiry days for programmatic users.
-// @description  Generates JWT token if user can log in successfully.
+// @description  Generates JWT token if user can log in successfully. Deprecated in favour of api keys sent in X-Api-Key header.
+// @deprecated
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -156,6 +197,7 @@ func (c *AuthController) GetAccessToken(context *gin.Context) {
 // @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
 // @Param        model       body      GetProgrammaticAccessTokenModel  true  "Username, password and expiryDays"
 func (c *AuthController) GetProgrammaticAccessToken(context *gin.Context) {
@@ -169,10 +211,12 @@ func (c *AuthController) GetProgrammaticAccessToken(context *gin.Context) {
 	ch := make(chan *auth.GetProgrammaticAccessTokenServiceResponse)
 	defer close(ch)
 
-	go c.authService.GetProgrammaticAccessToken(ch, &auth.GetProgrammaticAccessTokenServiceModel{
+	go c.authService.GetProgrammaticAccessToken(context.Request.Context(), ch, &auth.GetProgrammaticAccessTokenServiceModel{
 		UserName:   model.UserName,
 		Password:   model.Password,
 		ExpiryDays: model.ExpiryDays,
+		ClientIp:   context.ClientIP(),
+		UserAgent:  context.Request.UserAgent(),
 	})
 
 	serviceResponse := <-ch
@@ -190,27 +234,568 @@ func (c *AuthController) GetProgrammaticAccessToken(context *gin.Context) {
 	context.JSON(http.StatusOK, api.Ok(serviceResponse))
 }
 
+// Register
+// @basePath     /api
+// @router       /v1/auth/register [post]
+// @tags         Auth
+// @summary      Registers a new user.
+// @description  Creates an inactive user and sends an activation link to given email.
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      201         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      RegisterModel  true  "Username, email and password"
+func (c *AuthController) Register(context *gin.Context) {
+	var model RegisterModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.RegisterServiceResponse)
+	defer close(ch)
+
+	go c.authService.Register(context.Request.Context(), ch, &auth.RegisterServiceModel{
+		UserName: model.UserName,
+		Email:    model.Email,
+		Password: model.Password,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusCreated, api.Ok(serviceResponse))
+}
+
+// Activate
+// @basePath     /api
+// @router       /v1/auth/activate [post]
+// @tags         Auth
+// @summary      Activates a registered user.
+// @description  Activates the user of given single use activation token.
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      ActivateModel  true  "Activation token"
+func (c *AuthController) Activate(context *gin.Context) {
+	var model ActivateModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.ActivateServiceResponse)
+	defer close(ch)
+
+	go c.authService.Activate(context.Request.Context(), ch, &auth.ActivateServiceModel{
+		ActivationToken: model.ActivationToken,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
 // Get
 // @basePath     /api
 // @router       /v1/auth [get]
 // @tags         Auth
-// @summary      Gets user info.
-// @description  Gets user info.
+// @summary      Gets current user info.
+// @description  Gets claims of the current user with roles, permissions and expiry of the token used.
 // @security     Bearer
 // @accept       json
 // @produce      json
 // @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
 // @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
-// @success      200         {object}  api.ApiResponse
+// @success      200         {object}  api.ApiResponse{Data=WhoAmIResponse}
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
 func (c *AuthController) Get(context *gin.Context) {
-	id := helper.GetUserId(context)
-	username := helper.GetUserName(context)
-	email := helper.GetUserEmail(context)
+	response := WhoAmIResponse{
+		UserId:      helper.GetUserId(context),
+		UserName:    helper.GetUserName(context),
+		Email:       helper.GetUserEmail(context),
+		Roles:       helper.GetUserRoles(context),
+		Permissions: helper.GetUserPermissions(context),
+		TokenId:     helper.GetTokenId(context),
+		IssuedAt:    helper.GetTokenIssuedAt(context),
+		ExpiresAt:   helper.GetTokenExpiresAt(context),
+		ApiKeyId:    helper.GetApiKeyId(context),
+	}
+
+	context.JSON(http.StatusOK, api.Ok(response))
+}
+
+// Introspect
+// @basePath     /api
+// @router       /v1/auth/introspect [post]
+// @tags         Auth
+// @summary      Introspects an access token.
+// @description  Returns whether the token is active and its claims as described in RFC 7662. Response is not wrapped for compatibility with introspection clients.
+// @security     Bearer
+// @accept       x-www-form-urlencoded
+// @produce      json
+// @success      200              {object}  auth.IntrospectServiceResponse
+// @failure      400              {object}  api.ApiResponse
+// @failure      401              {object}  api.ApiResponse
+// @failure      403              {object}  api.ApiResponse
+// @failure      500              {object}  api.ApiResponse
+// @Param        token            formData  string  true   "Access token"
+// @Param        token_type_hint  formData  string  false  "Token type hint"  Enums(access_token)
+func (c *AuthController) Introspect(context *gin.Context) {
+	var model IntrospectModel
+	err := context.ShouldBind(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.IntrospectServiceResponse)
+	defer close(ch)
+
+	go c.authService.Introspect(context.Request.Context(), ch, &auth.IntrospectServiceModel{
+		Token: model.Token,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, serviceResponse)
+}
+
+// GetSessions
+// @basePath     /api
+// @router       /v1/auth/sessions [get]
+// @tags         Auth
+// @summary      Gets active sessions.
+// @description  Lists active sessions of the current user with device, ip, created and last used dates.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+func (c *AuthController) GetSessions(context *gin.Context) {
+	ch := make(chan *auth.GetSessionsServiceResponse)
+	defer close(ch)
+
+	go c.authService.GetSessions(context.Request.Context(), ch, &auth.GetSessionsServiceModel{
+		UserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// RevokeSession
+// @basePath     /api
+// @router       /v1/auth/sessions/{id} [delete]
+// @tags         Auth
+// @summary      Revokes a session.
+// @description  Revokes refresh tokens of a session of the current user.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      string  true  "Session id"
+func (c *AuthController) RevokeSession(context *gin.Context) {
+	ch := make(chan *auth.RevokeSessionServiceResponse)
+	defer close(ch)
+
+	go c.authService.RevokeSession(context.Request.Context(), ch, &auth.RevokeSessionServiceModel{
+		UserId:    helper.GetUserId(context),
+		SessionId: context.Param("id"),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
 
-	message := fmt.Sprintf("id:%d user:%s email:%s", id, username, email)
+// VerifyMfa
+// @basePath     /api
+// @router       /v1/auth/mfa/verify [post]
+// @tags         Auth
+// @summary      Completes login of a user with MFA.
+// @description  Exchanges the challenge token returned by login and a TOTP or recovery code for JWT and refresh tokens.
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      VerifyMfaModel  true  "ChallengeToken and Code or RecoveryCode"
+func (c *AuthController) VerifyMfa(context *gin.Context) {
+	var model VerifyMfaModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.LoginServiceResponse)
+	defer close(ch)
+
+	go c.authService.VerifyMfa(context.Request.Context(), ch, &auth.VerifyMfaServiceModel{
+		ChallengeToken: model.ChallengeToken,
+		Code:           model.Code,
+		RecoveryCode:   model.RecoveryCode,
+		ClientIp:       context.ClientIP(),
+		UserAgent:      context.Request.UserAgent(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// OidcLogin
+// @basePath     /api
+// @router       /v1/auth/oidc/login [get]
+// @tags         Auth
+// @summary      Redirects to the identity provider to log in.
+// @description  Starts an OpenID Connect authorization code flow with PKCE. State is also kept in a cookie to bind the callback to this browser.
+// @produce      json
+// @success      302
+// @failure      400  {object}  api.ApiResponse
+// @failure      500  {object}  api.ApiResponse
+func (c *AuthController) OidcLogin(context *gin.Context) {
+	ch := make(chan *auth.GetOidcLoginUrlServiceResponse)
+	defer close(ch)
+
+	go c.authService.GetOidcLoginUrl(context.Request.Context(), ch, &auth.GetOidcLoginUrlServiceModel{})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.SetSameSite(http.SameSiteLaxMode)
+	context.SetCookie(oidcStateCookie, serviceResponse.State, 600, "/api/v1/auth/oidc", "", c.secureCookie, true)
+	context.Redirect(http.StatusFound, serviceResponse.Url)
+}
+
+// OidcCallback
+// @basePath     /api
+// @router       /v1/auth/oidc/callback [get]
+// @tags         Auth
+// @summary      Completes login via the identity provider.
+// @description  Exchanges the authorization code for JWT and refresh tokens. User is provisioned on first login.
+// @produce      json
+// @success      200    {object}  api.ApiResponse
+// @failure      400    {object}  api.ApiResponse
+// @failure      500    {object}  api.ApiResponse
+// @Param        code   query     string  false  "Authorization code"
+// @Param        state  query     string  false  "State"
+// @Param        error  query     string  false  "Error returned by the identity provider"
+func (c *AuthController) OidcCallback(context *gin.Context) {
+	var model OidcCallbackModel
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	if model.Error != "" {
+		context.Error(customerror.New(errors.New("identity provider returned an error: "+model.Error+" "+model.ErrorDescription), customerror.LogLevelInfo))
+		return
+	}
+
+	state, err := context.Cookie(oidcStateCookie)
+	if err != nil || state != model.State {
+		context.Error(customerror.New(errors.New("oidc state does not match"), customerror.LogLevelWarn))
+		return
+	}
+	context.SetCookie(oidcStateCookie, "", -1, "/api/v1/auth/oidc", "", c.secureCookie, true)
+
+	ch := make(chan *auth.LoginServiceResponse)
+	defer close(ch)
+
+	go c.authService.LoginWithOidc(context.Request.Context(), ch, &auth.LoginWithOidcServiceModel{
+		Code:      model.Code,
+		State:     model.State,
+		ClientIp:  context.ClientIP(),
+		UserAgent: context.Request.UserAgent(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// ForgotPassword
+// @basePath     /api
+// @router       /v1/auth/password/forgot [post]
+// @tags         Auth
+// @summary      Requests a password reset link.
+// @description  Sends a single use password reset link to the email if it belongs to an active user. Response does not reveal whether the email is registered.
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      ForgotPasswordModel  true  "Email"
+func (c *AuthController) ForgotPassword(context *gin.Context) {
+	var model ForgotPasswordModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.ForgotPasswordServiceResponse)
+	defer close(ch)
+
+	go c.authService.ForgotPassword(context.Request.Context(), ch, &auth.ForgotPasswordServiceModel{
+		Email:    model.Email,
+		ClientIp: context.ClientIP(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok("If the email belongs to an account, a password reset link is sent."))
+}
+
+// ResetPassword
+// @basePath     /api
+// @router       /v1/auth/password/reset [post]
+// @tags         Auth
+// @summary      Resets password via reset token.
+// @description  Sets a new password with the token sent by email and logs out from all sessions.
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      ResetPasswordModel  true  "ResetToken and NewPassword"
+func (c *AuthController) ResetPassword(context *gin.Context) {
+	var model ResetPasswordModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.PasswordServiceResponse)
+	defer close(ch)
+
+	go c.authService.ResetPassword(context.Request.Context(), ch, &auth.ResetPasswordServiceModel{
+		ResetToken:  model.ResetToken,
+		NewPassword: model.NewPassword,
+		ClientIp:    context.ClientIP(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// ChangePassword
+// @basePath     /api
+// @router       /v1/auth/password [put]
+// @tags         Auth
+// @summary      Changes password of the current user.
+// @description  Replaces password after verifying the current one and logs out from all sessions.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      429         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      ChangePasswordModel  true  "CurrentPassword and NewPassword"
+func (c *AuthController) ChangePassword(context *gin.Context) {
+	var model ChangePasswordModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.PasswordServiceResponse)
+	defer close(ch)
+
+	go c.authService.ChangePassword(context.Request.Context(), ch, &auth.ChangePasswordServiceModel{
+		UserId:          helper.GetUserId(context),
+		UserName:        helper.GetUserName(context),
+		CurrentPassword: model.CurrentPassword,
+		NewPassword:     model.NewPassword,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// Logout
+// @basePath     /api
+// @router       /v1/auth/logout [post]
+// @tags         Auth
+// @summary      Logs out from the current session.
+// @description  Revokes given refresh token and every token rotated from the same login.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      LogoutModel  true  "RefreshToken"
+func (c *AuthController) Logout(context *gin.Context) {
+	var model LogoutModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *auth.LogoutServiceResponse)
+	defer close(ch)
+
+	go c.authService.Logout(context.Request.Context(), ch, &auth.LogoutServiceModel{
+		UserId:       helper.GetUserId(context),
+		UserName:     helper.GetUserName(context),
+		RefreshToken: model.RefreshToken,
+		ClientIp:     context.ClientIP(),
+		UserAgent:    context.Request.UserAgent(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// LogoutAll
+// @basePath     /api
+// @router       /v1/auth/logout/all [post]
+// @tags         Auth
+// @summary      Logs out from all sessions.
+// @description  Revokes every refresh token of the current user.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+func (c *AuthController) LogoutAll(context *gin.Context) {
+	ch := make(chan *auth.LogoutServiceResponse)
+	defer close(ch)
+
+	go c.authService.LogoutAll(context.Request.Context(), ch, &auth.LogoutAllServiceModel{
+		UserId:    helper.GetUserId(context),
+		UserName:  helper.GetUserName(context),
+		ClientIp:  context.ClientIP(),
+		UserAgent: context.Request.UserAgent(),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// isSecureCookie
+// Returns whether cookies are sent only over https, given by OIDC_SECURE_COOKIE and true outside development by default.
+// TLS is usually terminated by a proxy, so the request itself cannot tell whether the client uses https.
+func isSecureCookie(environment env.IEnvironment) bool {
+	value := environment.Get(env.OidcSecureCookie)
+	if value == "" {
+		return !strings.EqualFold(environment.Get(env.AppEnvironment), "development")
+	}
 
-	context.JSON(http.StatusOK, api.Ok(message))
+	return value == "true"
 }
@@ -34,6 +34,42 @@ func (m *MockIAuthController) EXPECT() *MockIAuthControllerMockRecorder {
 	return m.recorder
 }
 
+// Activate mocks base method.
+func (m *MockIAuthController) Activate(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Activate", context)
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockIAuthControllerMockRecorder) Activate(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockIAuthController)(nil).Activate), context)
+}
+
+// ChangePassword mocks base method.
+func (m *MockIAuthController) ChangePassword(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ChangePassword", context)
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIAuthControllerMockRecorder) ChangePassword(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIAuthController)(nil).ChangePassword), context)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockIAuthController) ForgotPassword(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgotPassword", context)
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockIAuthControllerMockRecorder) ForgotPassword(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockIAuthController)(nil).ForgotPassword), context)
+}
+
 // GetAccessToken mocks base method.
 func (m *MockIAuthController) GetAccessToken(context *gin.Context) {
 	m.ctrl.T.Helper()
@@ -58,6 +94,30 @@ func (mr *MockIAuthControllerMockRecorder) GetProgrammaticAccessToken(context in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgrammaticAccessToken", reflect.TypeOf((*MockIAuthController)(nil).GetProgrammaticAccessToken), context)
 }
 
+// GetSessions mocks base method.
+func (m *MockIAuthController) GetSessions(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSessions", context)
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockIAuthControllerMockRecorder) GetSessions(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockIAuthController)(nil).GetSessions), context)
+}
+
+// Introspect mocks base method.
+func (m *MockIAuthController) Introspect(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Introspect", context)
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockIAuthControllerMockRecorder) Introspect(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockIAuthController)(nil).Introspect), context)
+}
+
 // Login mocks base method.
 func (m *MockIAuthController) Login(context *gin.Context) {
 	m.ctrl.T.Helper()
@@ -70,6 +130,66 @@ func (mr *MockIAuthControllerMockRecorder) Login(context interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAuthController)(nil).Login), context)
 }
 
+// Logout mocks base method.
+func (m *MockIAuthController) Logout(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Logout", context)
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIAuthControllerMockRecorder) Logout(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIAuthController)(nil).Logout), context)
+}
+
+// LogoutAll mocks base method.
+func (m *MockIAuthController) LogoutAll(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogoutAll", context)
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockIAuthControllerMockRecorder) LogoutAll(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockIAuthController)(nil).LogoutAll), context)
+}
+
+// OidcCallback mocks base method.
+func (m *MockIAuthController) OidcCallback(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OidcCallback", context)
+}
+
+// OidcCallback indicates an expected call of OidcCallback.
+func (mr *MockIAuthControllerMockRecorder) OidcCallback(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OidcCallback", reflect.TypeOf((*MockIAuthController)(nil).OidcCallback), context)
+}
+
+// OidcLogin mocks base method.
+func (m *MockIAuthController) OidcLogin(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OidcLogin", context)
+}
+
+// OidcLogin indicates an expected call of OidcLogin.
+func (mr *MockIAuthControllerMockRecorder) OidcLogin(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OidcLogin", reflect.TypeOf((*MockIAuthController)(nil).OidcLogin), context)
+}
+
+// Register mocks base method.
+func (m *MockIAuthController) Register(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", context)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockIAuthControllerMockRecorder) Register(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthController)(nil).Register), context)
+}
+
 // RegisterRoutes mocks base method.
 func (m *MockIAuthController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	m.ctrl.T.Helper()
@@ -81,3 +201,39 @@ func (mr *MockIAuthControllerMockRecorder) RegisterRoutes(routerGroup interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIAuthController)(nil).RegisterRoutes), routerGroup)
 }
+
+// ResetPassword mocks base method.
+func (m *MockIAuthController) ResetPassword(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetPassword", context)
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIAuthControllerMockRecorder) ResetPassword(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIAuthController)(nil).ResetPassword), context)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIAuthController) RevokeSession(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeSession", context)
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIAuthControllerMockRecorder) RevokeSession(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIAuthController)(nil).RevokeSession), context)
+}
+
+// VerifyMfa mocks base method.
+func (m *MockIAuthController) VerifyMfa(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "VerifyMfa", context)
+}
+
+// VerifyMfa indicates an expected call of VerifyMfa.
+func (mr *MockIAuthControllerMockRecorder) VerifyMfa(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMfa", reflect.TypeOf((*MockIAuthController)(nil).VerifyMfa), context)
+}
@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type LoginModel struct {
 	UserName string `json:"UserName"`
 	Password string `json:"Password"`
@@ -14,3 +16,63 @@ type GetProgrammaticAccessTokenModel struct {
 	Password   string `json:"Password"`
 	ExpiryDays int32  `json:"ExpiryDays"`
 }
+
+type RegisterModel struct {
+	UserName string `json:"UserName"`
+	Email    string `json:"Email"`
+	Password string `json:"Password"`
+}
+
+type ActivateModel struct {
+	ActivationToken string `json:"ActivationToken"`
+}
+
+type LogoutModel struct {
+	RefreshToken string `json:"RefreshToken"`
+}
+
+type VerifyMfaModel struct {
+	ChallengeToken string `json:"ChallengeToken"`
+	Code           string `json:"Code"`
+	RecoveryCode   string `json:"RecoveryCode"`
+}
+
+type ForgotPasswordModel struct {
+	Email string `json:"Email"`
+}
+
+type ResetPasswordModel struct {
+	ResetToken  string `json:"ResetToken"`
+	NewPassword string `json:"NewPassword"`
+}
+
+type ChangePasswordModel struct {
+	CurrentPassword string `json:"CurrentPassword"`
+	NewPassword     string `json:"NewPassword"`
+}
+
+type OidcCallbackModel struct {
+	Code             string `form:"code"`
+	State            string `form:"state"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+type IntrospectModel struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
+
+// WhoAmIResponse
+// Claims of the current user. Token fields are empty when authenticated with an api key.
+type WhoAmIResponse struct {
+	UserId      int64
+	UserName    string
+	Email       string
+	Roles       []string
+	Permissions []string
+	TokenId     string     `json:",omitempty"`
+	IssuedAt    *time.Time `json:",omitempty"`
+	ExpiresAt   *time.Time `json:",omitempty"`
+	ApiKeyId    int64      `json:",omitempty"`
+}
@@ -46,7 +46,7 @@ func NewHealthController(environment env.IEnvironment,
 	if healthService != nil {
 		controller.healthService = healthService
 	} else {
-		controller.healthService = health.NewHealthService(environment, loggr, validatr, cachr, nil)
+		controller.healthService = health.NewHealthService(environment, loggr, validatr, cachr, nil, nil)
 	}
 	return &controller
 }
@@ -76,7 +76,7 @@ func (c *HealthController) Ping(context *gin.Context) {
 // @router       /service [get]
 // @tags         Health
 // @summary      Send a service check request.
-// @description  Send a service check request.
+// @description  Checks redis and db connections and returns db connection pool statistics.
 // @accept       json
 // @produce      json
 // @success      200  {object}  api.ApiResponse
@@ -87,9 +87,9 @@ func (c *HealthController) Service(context *gin.Context) {
 	healthCheckCh := make(chan *health.HealthCheckServiceResponse)
 	defer close(healthCheckCh)
 
-	go c.healthService.HealthCheck(healthCheckCh)
+	go c.healthService.HealthCheck(context.Request.Context(), healthCheckCh)
 
 	res := <-healthCheckCh
 
-	context.JSON(http.StatusOK, api.Ok(res.HealthMessage))
+	context.JSON(http.StatusOK, api.Ok(res))
 }
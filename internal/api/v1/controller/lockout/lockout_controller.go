@@ -0,0 +1,145 @@
+package lockout
+
+import (
+	"go-clean-architecture/internal/service/lockout"
+	"net/http"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ILockoutController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	GetLockouts(context *gin.Context)
+	ClearLockout(context *gin.Context)
+}
+
+type LockoutController struct {
+	path           string
+	environment    env.IEnvironment
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	cachr          cacher.ICacher
+	lockoutService lockout.ILockoutService
+}
+
+// NewLockoutController
+// Returns a new LockoutController.
+func NewLockoutController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, lockoutService lockout.ILockoutService) ILockoutController {
+	controller := LockoutController{
+		path:        "auth/lockouts",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if lockoutService != nil {
+		controller.lockoutService = lockoutService
+	} else {
+		controller.lockoutService = lockout.NewLockoutService(environment, loggr, validatr, cachr, nil)
+	}
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *LockoutController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr), api.RequirePermission(permission.AuthLockoutsManage))
+	routes.GET("", c.GetLockouts)
+	routes.DELETE(":scope/:key", c.ClearLockout)
+}
+
+// GetLockouts
+// @basePath     /api
+// @router       /v1/auth/lockouts [get]
+// @tags         Auth
+// @summary      Gets lockout events.
+// @description  Lists lockout events caused by failed login attempts, newest first.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true   "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true   "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        userName    query     string  false  "Username filter."
+// @Param        clientIp    query     string  false  "Client ip filter."
+// @Param        onlyActive  query     bool    false  "Only lockouts which are still in effect."
+// @Param        limit       query     int     false  "Maximum number of events."  default(50)
+func (c *LockoutController) GetLockouts(context *gin.Context) {
+	model := GetLockoutsModel{Limit: 50}
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *lockout.GetLockoutsServiceResponse)
+	defer close(ch)
+
+	go c.lockoutService.GetLockouts(context.Request.Context(), ch, &lockout.GetLockoutsServiceModel{
+		UserName:   model.UserName,
+		ClientIp:   model.ClientIp,
+		OnlyActive: model.OnlyActive,
+		Limit:      model.Limit,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// ClearLockout
+// @basePath     /api
+// @router       /v1/auth/lockouts/{scope}/{key} [delete]
+// @tags         Auth
+// @summary      Clears a lockout.
+// @description  Lifts the lockout of a username or client ip and resets its failed attempts.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        scope       path      string  true  "Lockout scope"  Enums(user, ip)
+// @Param        key         path      string  true  "Username or client ip"
+func (c *LockoutController) ClearLockout(context *gin.Context) {
+	ch := make(chan *lockout.ClearLockoutServiceResponse)
+	defer close(ch)
+
+	go c.lockoutService.ClearLockout(context.Request.Context(), ch, &lockout.ClearLockoutServiceModel{
+		Scope:     context.Param("scope"),
+		Key:       context.Param("key"),
+		ClearedBy: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
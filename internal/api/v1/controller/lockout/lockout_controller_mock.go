@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/lockout/lockout_controller.go
+
+// Package lockout is a generated GoMock package.
+package lockout
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILockoutController is a mock of ILockoutController interface.
+type MockILockoutController struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockoutControllerMockRecorder
+}
+
+// MockILockoutControllerMockRecorder is the mock recorder for MockILockoutController.
+type MockILockoutControllerMockRecorder struct {
+	mock *MockILockoutController
+}
+
+// NewMockILockoutController creates a new mock instance.
+func NewMockILockoutController(ctrl *gomock.Controller) *MockILockoutController {
+	mock := &MockILockoutController{ctrl: ctrl}
+	mock.recorder = &MockILockoutControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILockoutController) EXPECT() *MockILockoutControllerMockRecorder {
+	return m.recorder
+}
+
+// ClearLockout mocks base method.
+func (m *MockILockoutController) ClearLockout(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearLockout", context)
+}
+
+// ClearLockout indicates an expected call of ClearLockout.
+func (mr *MockILockoutControllerMockRecorder) ClearLockout(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLockout", reflect.TypeOf((*MockILockoutController)(nil).ClearLockout), context)
+}
+
+// GetLockouts mocks base method.
+func (m *MockILockoutController) GetLockouts(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLockouts", context)
+}
+
+// GetLockouts indicates an expected call of GetLockouts.
+func (mr *MockILockoutControllerMockRecorder) GetLockouts(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockouts", reflect.TypeOf((*MockILockoutController)(nil).GetLockouts), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockILockoutController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockILockoutControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockILockoutController)(nil).RegisterRoutes), routerGroup)
+}
@@ -0,0 +1,8 @@
+package lockout
+
+type GetLockoutsModel struct {
+	UserName   string `form:"userName"`
+	ClientIp   string `form:"clientIp"`
+	OnlyActive bool   `form:"onlyActive"`
+	Limit      int    `form:"limit"`
+}
@@ -0,0 +1,135 @@
+package mfa
+
+import (
+	"go-clean-architecture/internal/service/mfa"
+	"net/http"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IMfaController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	Enroll(context *gin.Context)
+	Confirm(context *gin.Context)
+}
+
+type MfaController struct {
+	path        string
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	mfaService  mfa.IMfaService
+}
+
+// NewMfaController
+// Returns a new MfaController.
+func NewMfaController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, mfaService mfa.IMfaService) IMfaController {
+	controller := MfaController{
+		path:        "auth/mfa",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if mfaService != nil {
+		controller.mfaService = mfaService
+	} else {
+		controller.mfaService = mfa.NewMfaService(environment, loggr, validatr, cachr, nil, nil)
+	}
+
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *MfaController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr))
+	routes.POST("enroll", c.Enroll)
+	routes.POST("confirm", c.Confirm)
+}
+
+// Enroll
+// @basePath     /api
+// @router       /v1/auth/mfa/enroll [post]
+// @tags         Auth
+// @summary      Starts MFA enrollment.
+// @description  Generates a TOTP secret, its otpauth URI and recovery codes. Recovery codes are only shown once.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+func (c *MfaController) Enroll(context *gin.Context) {
+	ch := make(chan *mfa.EnrollServiceResponse)
+	defer close(ch)
+
+	go c.mfaService.Enroll(context.Request.Context(), ch, &mfa.EnrollServiceModel{
+		UserId:   helper.GetUserId(context),
+		UserName: helper.GetUserName(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// Confirm
+// @basePath     /api
+// @router       /v1/auth/mfa/confirm [post]
+// @tags         Auth
+// @summary      Enables MFA.
+// @description  Enables MFA once a code generated from the enrolled secret is confirmed.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      ConfirmModel  true  "Code"
+func (c *MfaController) Confirm(context *gin.Context) {
+	var model ConfirmModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *mfa.ConfirmServiceResponse)
+	defer close(ch)
+
+	go c.mfaService.Confirm(context.Request.Context(), ch, &mfa.ConfirmServiceModel{
+		UserId: helper.GetUserId(context),
+		Code:   model.Code,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
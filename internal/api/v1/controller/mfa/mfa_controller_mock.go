@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/mfa/mfa_controller.go
+
+// Package mfa is a generated GoMock package.
+package mfa
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMfaController is a mock of IMfaController interface.
+type MockIMfaController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMfaControllerMockRecorder
+}
+
+// MockIMfaControllerMockRecorder is the mock recorder for MockIMfaController.
+type MockIMfaControllerMockRecorder struct {
+	mock *MockIMfaController
+}
+
+// NewMockIMfaController creates a new mock instance.
+func NewMockIMfaController(ctrl *gomock.Controller) *MockIMfaController {
+	mock := &MockIMfaController{ctrl: ctrl}
+	mock.recorder = &MockIMfaControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMfaController) EXPECT() *MockIMfaControllerMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockIMfaController) Confirm(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Confirm", context)
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockIMfaControllerMockRecorder) Confirm(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockIMfaController)(nil).Confirm), context)
+}
+
+// Enroll mocks base method.
+func (m *MockIMfaController) Enroll(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enroll", context)
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockIMfaControllerMockRecorder) Enroll(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockIMfaController)(nil).Enroll), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIMfaController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIMfaControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIMfaController)(nil).RegisterRoutes), routerGroup)
+}
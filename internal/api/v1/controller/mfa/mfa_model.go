@@ -0,0 +1,5 @@
+package mfa
+
+type ConfirmModel struct {
+	Code string `json:"Code"`
+}
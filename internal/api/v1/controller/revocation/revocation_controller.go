@@ -0,0 +1,146 @@
+package revocation
+
+import (
+	"go-clean-architecture/internal/service/revocation"
+	"net/http"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IRevocationController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	RevokeToken(context *gin.Context)
+	RevokeUser(context *gin.Context)
+}
+
+type RevocationController struct {
+	path              string
+	environment       env.IEnvironment
+	loggr             logger.ILogger
+	validatr          validator.IValidator
+	cachr             cacher.ICacher
+	revocationService revocation.IRevocationService
+}
+
+// NewRevocationController
+// Returns a new RevocationController.
+func NewRevocationController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, revocationService revocation.IRevocationService) IRevocationController {
+	controller := RevocationController{
+		path:        "auth/revocations",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if revocationService != nil {
+		controller.revocationService = revocationService
+	} else {
+		controller.revocationService = revocation.NewRevocationService(environment, loggr, validatr, cachr, nil)
+	}
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *RevocationController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr), api.RequirePermission(permission.AuthTokensRevoke))
+	routes.POST("tokens", c.RevokeToken)
+	routes.POST("users", c.RevokeUser)
+}
+
+// RevokeToken
+// @basePath     /api
+// @router       /v1/auth/revocations/tokens [post]
+// @tags         Auth
+// @summary      Revokes an access token by its jti.
+// @description  Token is rejected until it expires. ExpiresAt is the exp claim of the token, tokens without it are revoked for the longest token lifetime.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      RevokeTokenModel  true  "Jti and optional ExpiresAt"
+func (c *RevocationController) RevokeToken(context *gin.Context) {
+	var model RevokeTokenModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *revocation.RevokeTokenServiceResponse)
+	defer close(ch)
+
+	go c.revocationService.RevokeToken(context.Request.Context(), ch, &revocation.RevokeTokenServiceModel{
+		Jti:       model.Jti,
+		ExpiresAt: model.ExpiresAt,
+		RevokedBy: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// RevokeUser
+// @basePath     /api
+// @router       /v1/auth/revocations/users [post]
+// @tags         Auth
+// @summary      Revokes every token of a user.
+// @description  Access tokens issued until now are rejected and refresh tokens are revoked, user has to log in again.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      RevokeUserModel  true  "UserId"
+func (c *RevocationController) RevokeUser(context *gin.Context) {
+	var model RevokeUserModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *revocation.RevokeUserServiceResponse)
+	defer close(ch)
+
+	go c.revocationService.RevokeUser(context.Request.Context(), ch, &revocation.RevokeUserServiceModel{
+		UserId:    model.UserId,
+		RevokedBy: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
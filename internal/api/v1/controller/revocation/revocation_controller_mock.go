@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/revocation/revocation_controller.go
+
+// Package revocation is a generated GoMock package.
+package revocation
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRevocationController is a mock of IRevocationController interface.
+type MockIRevocationController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRevocationControllerMockRecorder
+}
+
+// MockIRevocationControllerMockRecorder is the mock recorder for MockIRevocationController.
+type MockIRevocationControllerMockRecorder struct {
+	mock *MockIRevocationController
+}
+
+// NewMockIRevocationController creates a new mock instance.
+func NewMockIRevocationController(ctrl *gomock.Controller) *MockIRevocationController {
+	mock := &MockIRevocationController{ctrl: ctrl}
+	mock.recorder = &MockIRevocationControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRevocationController) EXPECT() *MockIRevocationControllerMockRecorder {
+	return m.recorder
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIRevocationController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIRevocationControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIRevocationController)(nil).RegisterRoutes), routerGroup)
+}
+
+// RevokeToken mocks base method.
+func (m *MockIRevocationController) RevokeToken(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeToken", context)
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockIRevocationControllerMockRecorder) RevokeToken(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockIRevocationController)(nil).RevokeToken), context)
+}
+
+// RevokeUser mocks base method.
+func (m *MockIRevocationController) RevokeUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeUser", context)
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockIRevocationControllerMockRecorder) RevokeUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockIRevocationController)(nil).RevokeUser), context)
+}
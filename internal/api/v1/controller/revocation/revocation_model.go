@@ -0,0 +1,12 @@
+package revocation
+
+import "time"
+
+type RevokeTokenModel struct {
+	Jti       string     `json:"Jti"`
+	ExpiresAt *time.Time `json:"ExpiresAt"`
+}
+
+type RevokeUserModel struct {
+	UserId int64 `json:"UserId"`
+}
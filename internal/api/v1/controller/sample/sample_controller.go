@@ -1,7 +1,6 @@
 package sample
 
 import (
-	"fmt"
 	"go-clean-architecture/internal/service/sample"
 	"net/http"
 	"strconv"
@@ -9,7 +8,10 @@ import (
 	"go-clean-architecture/internal/api"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/query"
 	"go-clean-architecture/internal/util/validator"
 
 	"github.com/gin-gonic/gin"
@@ -18,10 +20,12 @@ import (
 type ISampleController interface {
 	RegisterRoutes(routerGroup *gin.RouterGroup)
 	Get(context *gin.Context)
+	GetById(context *gin.Context)
 	Add(context *gin.Context)
 	Update(context *gin.Context)
+	Delete(context *gin.Context)
+	Restore(context *gin.Context)
 	GetProxy(context *gin.Context)
-	GetDatabase(context *gin.Context)
 	GetCache(context *gin.Context)
 	PublishPubSubMessage(context *gin.Context)
 	PostSampleXml(context *gin.Context)
@@ -50,7 +54,7 @@ func NewSampleController(environment env.IEnvironment, loggr logger.ILogger, val
 	if sampleService != nil {
 		controller.sampleService = sampleService
 	} else {
-		controller.sampleService = sample.NewSampleService(environment, loggr, validatr, cachr, nil, nil, nil, nil)
+		controller.sampleService = sample.NewSampleService(environment, loggr, validatr, cachr, nil, nil, nil, nil, nil)
 	}
 	return &controller
 }
@@ -59,14 +63,16 @@ func NewSampleController(environment env.IEnvironment, loggr logger.ILogger, val
 // Registers routes to gin.
 func (c *SampleController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	routes := routerGroup.Group(c.path)
-	routes.Use(api.AuthenticationMiddleware(c.environment))
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr))
 	routes.GET("", c.Get)
 	routes.POST("", c.Add)
+	routes.GET(":id", c.GetById)
 	routes.PUT(":id", c.Update)
+	routes.DELETE(":id", c.Delete)
+	routes.POST(":id/restore", api.RequireRole(permission.RoleAdmin), c.Restore)
 	routes.GET("proxy", c.GetProxy)
-	routes.GET("database", c.GetDatabase)
 	routes.GET("cache", c.GetCache)
-	routes.POST("pub-sub", c.PublishPubSubMessage)
+	routes.POST("pub-sub", api.RequirePermission(permission.SamplePublish), c.PublishPubSubMessage)
 	routes.POST("xml", c.PostSampleXml)
 }
 
@@ -74,8 +80,12 @@ func (c *SampleController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 // @basePath     /api
 // @router       /v1/sample [get]
 // @tags         Sample
-// @summary      Gets a sample response.
-// @description  Gets a sample response.
+// @summary      Gets samples.
+// @description  Lists a page of samples with the total count and next page in Page. Filters are formatted as field:op:value
+// @description  with eq, ne, gt, gte, lt, lte, in and prefix operators. Sort takes comma separated fields, prefixed with - for
+// @description  descending order. Filterable fields are id, sampleName, sampleType, sampleCode, sampleStatus, createdAt and
+// @description  updatedAt, all but sampleCode and updatedAt are sortable. Deleted samples are only listed for admins
+// @description  with includeDeleted.
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -85,22 +95,81 @@ func (c *SampleController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
-// @Param        pageSize    query     int  false  "Size of the page."
-// @Param        pageIndex   query     int  false  "Index of the page."
+// @Param        pageSize        query     int       false  "Page size."                                 default(20)
+// @Param        pageIndex       query     int       false  "Zero based page index, ignored by cursor."  default(0)
+// @Param        cursor          query     string    false  "NextCursor of the previous page."
+// @Param        filter          query     []string  false  "Filters formatted as field:op:value."      collectionFormat(multi)
+// @Param        sort            query     string    false  "Sort fields, e.g. -createdAt,sampleName."
+// @Param        includeDeleted  query     bool      false  "Includes deleted samples, admins only."
 func (c *SampleController) Get(context *gin.Context) {
-	pageSize := context.Query("pageSize")
-	pageIndex := context.Query("pageIndex")
+	var model query.Model
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *sample.GetSamplesServiceResponse)
+	defer close(ch)
+	go c.sampleService.GetSamples(context.Request.Context(), ch, &sample.GetSamplesServiceModel{
+		Query:          model,
+		IncludeDeleted: helper.HasRole(context, permission.RoleAdmin) && context.Query("includeDeleted") == "true",
+	})
 
-	if pageSize == "" {
-		pageSize = "Empty"
+	serviceResponse := <-ch
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
 	}
-	if pageIndex == "" {
-		pageIndex = "Empty"
+
+	context.JSON(http.StatusOK, api.OkPage(serviceResponse.Samples, serviceResponse.Page))
+}
+
+// GetById
+// @basePath     /api
+// @router       /v1/sample/{id} [get]
+// @tags         Sample
+// @summary      Gets a sample.
+// @description  Gets a sample by id with its version as ETag. Responds 304 if If-None-Match has the current ETag.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture      header    string  true   "Request culture"   default(tr-TR)
+// @Param        X-Timezone     header    string  true   "Request timezone"  default(Europe/Istanbul)
+// @Param        If-None-Match  header    string  false  "ETag of the cached sample"
+// @success      200            {object}  api.ApiResponse
+// @success      304
+// @failure      400            {object}  api.ApiResponse
+// @failure      401            {object}  api.ApiResponse
+// @failure      404            {object}  api.ApiResponse
+// @failure      500            {object}  api.ApiResponse
+// @Param        id             path      int  true  "Sample Id"
+func (c *SampleController) GetById(context *gin.Context) {
+	sampleId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
 	}
 
-	message := fmt.Sprintf("Sample v1 with PageSize:%v and PageIndex:%v", pageSize, pageIndex)
+	ch := make(chan *sample.SampleServiceResponse)
+	defer close(ch)
+	go c.sampleService.GetSample(context.Request.Context(), ch, &sample.GetSampleByIdServiceModel{
+		SampleId: sampleId,
+	})
 
-	context.JSON(http.StatusOK, api.Ok(message))
+	serviceResponse := <-ch
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	api.SetETag(context, serviceResponse.Sample.Version)
+	if api.IsNotModified(context, serviceResponse.Sample.Version) {
+		context.Status(http.StatusNotModified)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
 }
 
 // Add
@@ -108,13 +177,13 @@ func (c *SampleController) Get(context *gin.Context) {
 // @router       /v1/sample [post]
 // @tags         Sample
 // @summary      Adds new sample.
-// @description  Adds new sample.
+// @description  Adds new sample with the initial status.
 // @security     Bearer
 // @accept       json
 // @produce      json
 // @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
 // @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
-// @success      200         {object}  api.ApiResponse
+// @success      201         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
@@ -127,7 +196,22 @@ func (c *SampleController) Add(context *gin.Context) {
 		return
 	}
 
-	context.JSON(http.StatusOK, api.Ok("Sample is created successfully."))
+	ch := make(chan *sample.SampleServiceResponse)
+	defer close(ch)
+	go c.sampleService.AddSample(context.Request.Context(), ch, &sample.AddSampleServiceModel{
+		SampleName: model.SampleName,
+		SampleType: model.SampleType,
+		SampleCode: model.SampleCode,
+	})
+
+	serviceResponse := <-ch
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	api.SetETag(context, serviceResponse.Sample.Version)
+	context.JSON(http.StatusCreated, api.Ok(serviceResponse))
 }
 
 // Update
@@ -135,15 +219,18 @@ func (c *SampleController) Add(context *gin.Context) {
 // @router       /v1/sample/{id} [put]
 // @tags         Sample
 // @summary      Updates given sample.
-// @description  Updates given sample.
+// @description  Updates status of given sample with who modified it. Responds 412 if If-Match is not the current ETag.
 // @security     Bearer
 // @accept       json
 // @produce      json
-// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
-// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @Param        X-Culture   header    string  true   "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true   "Request timezone"  default(Europe/Istanbul)
+// @Param        If-Match    header    string  false  "ETag of the sample which is updated"
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      412         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
 // @Param        id          path      int                true  "Sample Id"
 // @Param        Model       body      UpdateSampleModel  true  "Request model"
@@ -155,6 +242,12 @@ func (c *SampleController) Update(context *gin.Context) {
 		return
 	}
 
+	version, err := api.IfMatchVersion(context)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
 	var model UpdateSampleModel
 	err = context.ShouldBindJSON(&model)
 	if err != nil {
@@ -162,12 +255,13 @@ func (c *SampleController) Update(context *gin.Context) {
 		return
 	}
 
-	ch := make(chan *sample.UpdateSampleServiceResponse)
+	ch := make(chan *sample.SampleServiceResponse)
 	defer close(ch)
-	go c.sampleService.UpdateSample(ch, &sample.UpdateSampleServiceModel{
+	go c.sampleService.UpdateSample(context.Request.Context(), ch, &sample.UpdateSampleServiceModel{
 		SampleId:     sampleId,
 		SampleStatus: model.SampleStatus,
 		ModifiedBy:   model.ModifiedBy,
+		Version:      version,
 	})
 
 	serviceResponse := <-ch
@@ -176,15 +270,16 @@ func (c *SampleController) Update(context *gin.Context) {
 		return
 	}
 
+	api.SetETag(context, serviceResponse.Sample.Version)
 	context.JSON(http.StatusOK, api.Ok(serviceResponse))
 }
 
-// GetProxy
+// Delete
 // @basePath     /api
-// @router       /v1/sample/proxy [get]
+// @router       /v1/sample/{id} [delete]
 // @tags         Sample
-// @summary      Gets a sample response via proxy.
-// @description  Gets a sample response via proxy.
+// @summary      Deletes given sample.
+// @description  Soft deletes given sample, admins can restore it.
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -193,13 +288,20 @@ func (c *SampleController) Update(context *gin.Context) {
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
-func (c *SampleController) GetProxy(context *gin.Context) {
-	ch := make(chan *sample.GetSampleServiceResponse)
+// @Param        id          path      int  true  "Sample Id"
+func (c *SampleController) Delete(context *gin.Context) {
+	sampleId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *sample.DeleteSampleServiceResponse)
 	defer close(ch)
-	go c.sampleService.GetGoogle(ch, &sample.GetSampleServiceModel{
-		Id:         8,
-		SampleName: "Trying some proxy requests..",
+	go c.sampleService.DeleteSample(context.Request.Context(), ch, &sample.DeleteSampleServiceModel{
+		SampleId: sampleId,
 	})
 
 	serviceResponse := <-ch
@@ -211,12 +313,12 @@ func (c *SampleController) GetProxy(context *gin.Context) {
 	context.JSON(http.StatusOK, api.Ok(serviceResponse))
 }
 
-// GetDatabase
+// Restore
 // @basePath     /api
-// @router       /v1/sample/database [get]
+// @router       /v1/sample/{id}/restore [post]
 // @tags         Sample
-// @summary      Gets a sample response from database.
-// @description  Gets a sample response from database.
+// @summary      Restores given sample.
+// @description  Restores given deleted sample. Requires admin role.
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -225,13 +327,54 @@ func (c *SampleController) GetProxy(context *gin.Context) {
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
-func (c *SampleController) GetDatabase(context *gin.Context) {
+// @Param        id          path      int  true  "Sample Id"
+func (c *SampleController) Restore(context *gin.Context) {
+	sampleId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *sample.SampleServiceResponse)
+	defer close(ch)
+	go c.sampleService.RestoreSample(context.Request.Context(), ch, &sample.RestoreSampleServiceModel{
+		SampleId: sampleId,
+	})
+
+	serviceResponse := <-ch
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	api.SetETag(context, serviceResponse.Sample.Version)
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// GetProxy
+// @basePath     /api
+// @router       /v1/sample/proxy [get]
+// @tags         Sample
+// @summary      Gets a sample response via proxy.
+// @description  Gets a sample response via proxy.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+func (c *SampleController) GetProxy(context *gin.Context) {
 	ch := make(chan *sample.GetSampleServiceResponse)
 	defer close(ch)
-	go c.sampleService.GetDatabase(ch, &sample.GetSampleServiceModel{
-		Id:         7,
-		SampleName: "Trying some database requests..",
+	go c.sampleService.GetGoogle(context.Request.Context(), ch, &sample.GetSampleServiceModel{
+		Id:         8,
+		SampleName: "Trying some proxy requests..",
 	})
 
 	serviceResponse := <-ch
@@ -261,7 +404,7 @@ func (c *SampleController) GetDatabase(context *gin.Context) {
 func (c *SampleController) GetCache(context *gin.Context) {
 	ch := make(chan *sample.GetSampleServiceResponse)
 	defer close(ch)
-	go c.sampleService.GetCache(ch, &sample.GetSampleServiceModel{
+	go c.sampleService.GetCache(context.Request.Context(), ch, &sample.GetSampleServiceModel{
 		Id:         1,
 		SampleName: "Trying some redis client requests..",
 	})
@@ -279,8 +422,8 @@ func (c *SampleController) GetCache(context *gin.Context) {
 // @basePath     /api
 // @router       /v1/sample/pub-sub [post]
 // @tags         Sample
-// @summary      Publishes a sample message to pub sub.
-// @description  Publishes a sample message to pub sub.
+// @summary      Publishes sample messages to pub sub through the outbox.
+// @description  Adds sample messages to the outbox, which are published to pub sub by the outbox relay. Requires sample:publish permission.
 // @security     Bearer
 // @accept       json
 // @produce      json
@@ -289,6 +432,7 @@ func (c *SampleController) GetCache(context *gin.Context) {
 // @success      200         {object}  api.ApiResponse
 // @failure      400         {object}  api.ApiResponse
 // @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
 // @failure      500         {object}  api.ApiResponse
 // @Param        Model       body      PublishPubSubMessageModel  true  "Request model"
 func (c *SampleController) PublishPubSubMessage(context *gin.Context) {
@@ -301,7 +445,7 @@ func (c *SampleController) PublishPubSubMessage(context *gin.Context) {
 
 	ch := make(chan *sample.PublishPubSubMessageServiceResponse)
 	defer close(ch)
-	go c.sampleService.PublishPubSubMessage(ch, &sample.PublishPubSubMessageServiceModel{
+	go c.sampleService.PublishPubSubMessage(context.Request.Context(), ch, &sample.PublishPubSubMessageServiceModel{
 		Message: model.Message,
 		Count:   model.Count,
 	})
@@ -341,7 +485,7 @@ func (c *SampleController) PostSampleXml(context *gin.Context) {
 
 	ch := make(chan *sample.PostSampleXmlServiceResponse)
 	defer close(ch)
-	go c.sampleService.PostSampleXml(ch, &sample.PostSampleXmlServiceModel{
+	go c.sampleService.PostSampleXml(context.Request.Context(), ch, &sample.PostSampleXmlServiceModel{
 		SampleName: model.SampleName,
 		SampleType: model.SampleType,
 		SampleCode: model.SampleCode,
@@ -46,6 +46,18 @@ func (mr *MockISampleControllerMockRecorder) Add(context interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockISampleController)(nil).Add), context)
 }
 
+// Delete mocks base method.
+func (m *MockISampleController) Delete(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", context)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockISampleControllerMockRecorder) Delete(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockISampleController)(nil).Delete), context)
+}
+
 // Get mocks base method.
 func (m *MockISampleController) Get(context *gin.Context) {
 	m.ctrl.T.Helper()
@@ -58,28 +70,28 @@ func (mr *MockISampleControllerMockRecorder) Get(context interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockISampleController)(nil).Get), context)
 }
 
-// GetCache mocks base method.
-func (m *MockISampleController) GetCache(context *gin.Context) {
+// GetById mocks base method.
+func (m *MockISampleController) GetById(context *gin.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetCache", context)
+	m.ctrl.Call(m, "GetById", context)
 }
 
-// GetCache indicates an expected call of GetCache.
-func (mr *MockISampleControllerMockRecorder) GetCache(context interface{}) *gomock.Call {
+// GetById indicates an expected call of GetById.
+func (mr *MockISampleControllerMockRecorder) GetById(context interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCache", reflect.TypeOf((*MockISampleController)(nil).GetCache), context)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISampleController)(nil).GetById), context)
 }
 
-// GetDatabase mocks base method.
-func (m *MockISampleController) GetDatabase(context *gin.Context) {
+// GetCache mocks base method.
+func (m *MockISampleController) GetCache(context *gin.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetDatabase", context)
+	m.ctrl.Call(m, "GetCache", context)
 }
 
-// GetDatabase indicates an expected call of GetDatabase.
-func (mr *MockISampleControllerMockRecorder) GetDatabase(context interface{}) *gomock.Call {
+// GetCache indicates an expected call of GetCache.
+func (mr *MockISampleControllerMockRecorder) GetCache(context interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabase", reflect.TypeOf((*MockISampleController)(nil).GetDatabase), context)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCache", reflect.TypeOf((*MockISampleController)(nil).GetCache), context)
 }
 
 // GetProxy mocks base method.
@@ -130,6 +142,18 @@ func (mr *MockISampleControllerMockRecorder) RegisterRoutes(routerGroup interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockISampleController)(nil).RegisterRoutes), routerGroup)
 }
 
+// Restore mocks base method.
+func (m *MockISampleController) Restore(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Restore", context)
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockISampleControllerMockRecorder) Restore(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockISampleController)(nil).Restore), context)
+}
+
 // Update mocks base method.
 func (m *MockISampleController) Update(context *gin.Context) {
 	m.ctrl.T.Helper()
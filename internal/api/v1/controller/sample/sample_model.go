@@ -5,9 +5,9 @@ import (
 )
 
 type AddSampleModel struct {
-	SampleName *string `json:"SampleName"`
-	SampleType *string `json:"SampleType"`
-	SampleCode *int    `json:"SampleCode"`
+	SampleName string `json:"SampleName"`
+	SampleType string `json:"SampleType"`
+	SampleCode *int   `json:"SampleCode"`
 }
 
 type UpdateSampleModel struct {
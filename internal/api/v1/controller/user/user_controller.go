@@ -0,0 +1,594 @@
+package user
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/service/user"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/permission"
+	"go-clean-architecture/internal/util/query"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IUserController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	GetUsers(context *gin.Context)
+	GetUser(context *gin.Context)
+	AddUser(context *gin.Context)
+	UpdateUser(context *gin.Context)
+	ActivateUser(context *gin.Context)
+	DeactivateUser(context *gin.Context)
+	SetUserProgrammatic(context *gin.Context)
+	ForcePasswordReset(context *gin.Context)
+	DeleteUser(context *gin.Context)
+	RestoreUser(context *gin.Context)
+	GetUserAuditEvents(context *gin.Context)
+}
+
+type UserController struct {
+	path        string
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	userService user.IUserService
+}
+
+// NewUserController
+// Returns a new UserController.
+func NewUserController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, userService user.IUserService) IUserController {
+	controller := UserController{
+		path:        "users",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if userService != nil {
+		controller.userService = userService
+	} else {
+		controller.userService = user.NewUserService(environment, loggr, validatr, cachr, nil, nil, nil, nil)
+	}
+
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *UserController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr), api.RequirePermission(permission.UsersManage))
+	routes.GET("", c.GetUsers)
+	routes.POST("", c.AddUser)
+	routes.GET(":id", c.GetUser)
+	routes.PUT(":id", c.UpdateUser)
+	routes.DELETE(":id", c.DeleteUser)
+	routes.POST(":id/restore", c.RestoreUser)
+	routes.POST(":id/activate", c.ActivateUser)
+	routes.POST(":id/deactivate", c.DeactivateUser)
+	routes.PUT(":id/programmatic", c.SetUserProgrammatic)
+	routes.POST(":id/password-reset", c.ForcePasswordReset)
+	routes.GET(":id/audit-events", c.GetUserAuditEvents)
+}
+
+// GetUsers
+// @basePath     /api
+// @router       /v1/users [get]
+// @tags         User
+// @summary      Gets users.
+// @description  Lists a page of users with the total count and next page in Page. Filters are formatted as field:op:value
+// @description  with eq, ne, gt, gte, lt, lte, in and prefix operators. Sort takes comma separated fields, prefixed with - for
+// @description  descending order. Filterable fields are id, userName, email, isActive, isProgrammatic, createdAt and
+// @description  updatedAt, id, userName and createdAt are sortable. Deleted users are only listed with includeDeleted.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture       header    string    true   "Request culture"   default(tr-TR)
+// @Param        X-Timezone      header    string    true   "Request timezone"  default(Europe/Istanbul)
+// @success      200             {object}  api.ApiResponse
+// @failure      400             {object}  api.ApiResponse
+// @failure      401             {object}  api.ApiResponse
+// @failure      403             {object}  api.ApiResponse
+// @failure      500             {object}  api.ApiResponse
+// @Param        pageSize        query     int       false  "Page size."                                 default(20)
+// @Param        pageIndex       query     int       false  "Zero based page index, ignored by cursor."  default(0)
+// @Param        cursor          query     string    false  "NextCursor of the previous page."
+// @Param        filter          query     []string  false  "Filters formatted as field:op:value."      collectionFormat(multi)
+// @Param        sort            query     string    false  "Sort fields, e.g. -createdAt,userName."
+// @Param        includeDeleted  query     bool      false  "Includes deleted users."
+func (c *UserController) GetUsers(context *gin.Context) {
+	var model query.Model
+	err := context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.GetUsersServiceResponse)
+	defer close(ch)
+
+	go c.userService.GetUsers(context.Request.Context(), ch, &user.GetUsersServiceModel{
+		Query:          model,
+		IncludeDeleted: context.Query("includeDeleted") == "true",
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.OkPage(serviceResponse.Users, serviceResponse.Page))
+}
+
+// GetUser
+// @basePath     /api
+// @router       /v1/users/{id} [get]
+// @tags         User
+// @summary      Gets a user.
+// @description  Gets a user by id.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) GetUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.GetUser(context.Request.Context(), ch, &user.GetUserServiceModel{
+		UserId: id,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// AddUser
+// @basePath     /api
+// @router       /v1/users [post]
+// @tags         User
+// @summary      Adds a user.
+// @description  Adds a user without email verification. Password is optional, users without one can be sent a password reset link.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      201         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      409         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        model       body      AddUserModel  true  "UserName, Email, Password, IsActive and IsProgrammatic"
+func (c *UserController) AddUser(context *gin.Context) {
+	var model AddUserModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.AddUser(context.Request.Context(), ch, &user.AddUserServiceModel{
+		UserName:       model.UserName,
+		Email:          model.Email,
+		Password:       model.Password,
+		IsActive:       model.IsActive,
+		IsProgrammatic: model.IsProgrammatic,
+		ActorUserId:    helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusCreated, api.Ok(serviceResponse))
+}
+
+// UpdateUser
+// @basePath     /api
+// @router       /v1/users/{id} [put]
+// @tags         User
+// @summary      Updates a user.
+// @description  Updates username and email of a user.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      409         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int              true  "User id"
+// @Param        model       body      UpdateUserModel  true  "UserName and Email"
+func (c *UserController) UpdateUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	var model UpdateUserModel
+	err = context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.UpdateUser(context.Request.Context(), ch, &user.UpdateUserServiceModel{
+		UserId:      id,
+		UserName:    model.UserName,
+		Email:       model.Email,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// ActivateUser
+// @basePath     /api
+// @router       /v1/users/{id}/activate [post]
+// @tags         User
+// @summary      Activates a user.
+// @description  Reactivates a deactivated user.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) ActivateUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.SetUserActive(context.Request.Context(), ch, &user.SetUserActiveServiceModel{
+		UserId:      id,
+		IsActive:    true,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// DeactivateUser
+// @basePath     /api
+// @router       /v1/users/{id}/deactivate [post]
+// @tags         User
+// @summary      Deactivates a user.
+// @description  Deactivates a user and revokes its access and refresh tokens.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) DeactivateUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.SetUserActive(context.Request.Context(), ch, &user.SetUserActiveServiceModel{
+		UserId:      id,
+		IsActive:    false,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// SetUserProgrammatic
+// @basePath     /api
+// @router       /v1/users/{id}/programmatic [put]
+// @tags         User
+// @summary      Sets programmatic flag of a user.
+// @description  Programmatic users can only authenticate with api keys and programmatic access tokens.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int                       true  "User id"
+// @Param        model       body      SetUserProgrammaticModel  true  "IsProgrammatic"
+func (c *UserController) SetUserProgrammatic(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	var model SetUserProgrammaticModel
+	err = context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.SetUserProgrammatic(context.Request.Context(), ch, &user.SetUserProgrammaticServiceModel{
+		UserId:         id,
+		IsProgrammatic: model.IsProgrammatic,
+		ActorUserId:    helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// ForcePasswordReset
+// @basePath     /api
+// @router       /v1/users/{id}/password-reset [post]
+// @tags         User
+// @summary      Forces a user to reset its password.
+// @description  Revokes access and refresh tokens of a user and emails a password reset link which expires in a day.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) ForcePasswordReset(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.ForcePasswordReset(context.Request.Context(), ch, &user.ForcePasswordResetServiceModel{
+		UserId:      id,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// DeleteUser
+// @basePath     /api
+// @router       /v1/users/{id} [delete]
+// @tags         User
+// @summary      Deletes a user.
+// @description  Soft deletes a user and its tokens. Mfa, identities and api keys are kept but unusable until it is restored.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) DeleteUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.DeleteUserServiceResponse)
+	defer close(ch)
+
+	go c.userService.DeleteUser(context.Request.Context(), ch, &user.DeleteUserServiceModel{
+		UserId:      id,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// RestoreUser
+// @basePath     /api
+// @router       /v1/users/{id}/restore [post]
+// @tags         User
+// @summary      Restores a user.
+// @description  Restores a deleted user with its mfa, identities and api keys. The user has to log in again.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      404         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true  "User id"
+func (c *UserController) RestoreUser(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.RestoreUser(context.Request.Context(), ch, &user.RestoreUserServiceModel{
+		UserId:      id,
+		ActorUserId: helper.GetUserId(context),
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
+
+// GetUserAuditEvents
+// @basePath     /api
+// @router       /v1/users/{id}/audit-events [get]
+// @tags         User
+// @summary      Gets audit events of a user.
+// @description  Lists changes made to a user by admins with the acting admin and the user before and after, newest first.
+// @security     Bearer
+// @accept       json
+// @produce      json
+// @Param        X-Culture   header    string  true  "Request culture"   default(tr-TR)
+// @Param        X-Timezone  header    string  true  "Request timezone"  default(Europe/Istanbul)
+// @success      200         {object}  api.ApiResponse
+// @failure      400         {object}  api.ApiResponse
+// @failure      401         {object}  api.ApiResponse
+// @failure      403         {object}  api.ApiResponse
+// @failure      500         {object}  api.ApiResponse
+// @Param        id          path      int  true   "User id"
+// @Param        limit       query     int  false  "Maximum number of events."  default(50)
+func (c *UserController) GetUserAuditEvents(context *gin.Context) {
+	id, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	model := GetUserAuditEventsModel{Limit: 50}
+	err = context.ShouldBindQuery(&model)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	ch := make(chan *user.GetUserAuditEventsServiceResponse)
+	defer close(ch)
+
+	go c.userService.GetUserAuditEvents(context.Request.Context(), ch, &user.GetUserAuditEventsServiceModel{
+		UserId: id,
+		Limit:  model.Limit,
+	})
+
+	serviceResponse := <-ch
+
+	if serviceResponse.Error != nil {
+		context.Error(serviceResponse.Error)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.Ok(serviceResponse))
+}
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/user/user_controller.go
+
+// Package user is a generated GoMock package.
+package user
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserController is a mock of IUserController interface.
+type MockIUserController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserControllerMockRecorder
+}
+
+// MockIUserControllerMockRecorder is the mock recorder for MockIUserController.
+type MockIUserControllerMockRecorder struct {
+	mock *MockIUserController
+}
+
+// NewMockIUserController creates a new mock instance.
+func NewMockIUserController(ctrl *gomock.Controller) *MockIUserController {
+	mock := &MockIUserController{ctrl: ctrl}
+	mock.recorder = &MockIUserControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserController) EXPECT() *MockIUserControllerMockRecorder {
+	return m.recorder
+}
+
+// ActivateUser mocks base method.
+func (m *MockIUserController) ActivateUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ActivateUser", context)
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockIUserControllerMockRecorder) ActivateUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockIUserController)(nil).ActivateUser), context)
+}
+
+// AddUser mocks base method.
+func (m *MockIUserController) AddUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUser", context)
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockIUserControllerMockRecorder) AddUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockIUserController)(nil).AddUser), context)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockIUserController) DeactivateUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeactivateUser", context)
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockIUserControllerMockRecorder) DeactivateUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockIUserController)(nil).DeactivateUser), context)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIUserController) DeleteUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", context)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIUserControllerMockRecorder) DeleteUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserController)(nil).DeleteUser), context)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockIUserController) ForcePasswordReset(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForcePasswordReset", context)
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockIUserControllerMockRecorder) ForcePasswordReset(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockIUserController)(nil).ForcePasswordReset), context)
+}
+
+// GetUser mocks base method.
+func (m *MockIUserController) GetUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUser", context)
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockIUserControllerMockRecorder) GetUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserController)(nil).GetUser), context)
+}
+
+// GetUserAuditEvents mocks base method.
+func (m *MockIUserController) GetUserAuditEvents(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserAuditEvents", context)
+}
+
+// GetUserAuditEvents indicates an expected call of GetUserAuditEvents.
+func (mr *MockIUserControllerMockRecorder) GetUserAuditEvents(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAuditEvents", reflect.TypeOf((*MockIUserController)(nil).GetUserAuditEvents), context)
+}
+
+// GetUsers mocks base method.
+func (m *MockIUserController) GetUsers(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUsers", context)
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIUserControllerMockRecorder) GetUsers(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIUserController)(nil).GetUsers), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIUserController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIUserControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIUserController)(nil).RegisterRoutes), routerGroup)
+}
+
+// RestoreUser mocks base method.
+func (m *MockIUserController) RestoreUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreUser", context)
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockIUserControllerMockRecorder) RestoreUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockIUserController)(nil).RestoreUser), context)
+}
+
+// SetUserProgrammatic mocks base method.
+func (m *MockIUserController) SetUserProgrammatic(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserProgrammatic", context)
+}
+
+// SetUserProgrammatic indicates an expected call of SetUserProgrammatic.
+func (mr *MockIUserControllerMockRecorder) SetUserProgrammatic(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserProgrammatic", reflect.TypeOf((*MockIUserController)(nil).SetUserProgrammatic), context)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIUserController) UpdateUser(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUser", context)
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIUserControllerMockRecorder) UpdateUser(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserController)(nil).UpdateUser), context)
+}
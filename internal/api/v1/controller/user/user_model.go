@@ -0,0 +1,22 @@
+package user
+
+type AddUserModel struct {
+	UserName       string `json:"UserName"`
+	Email          string `json:"Email"`
+	Password       string `json:"Password"`
+	IsActive       bool   `json:"IsActive"`
+	IsProgrammatic bool   `json:"IsProgrammatic"`
+}
+
+type UpdateUserModel struct {
+	UserName string `json:"UserName"`
+	Email    string `json:"Email"`
+}
+
+type SetUserProgrammaticModel struct {
+	IsProgrammatic bool `json:"IsProgrammatic"`
+}
+
+type GetUserAuditEventsModel struct {
+	Limit int `form:"limit"`
+}
@@ -0,0 +1,66 @@
+package wellknown
+
+import (
+	"net/http"
+
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/keyring"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IWellKnownController interface {
+	RegisterRoutes(routerGroup *gin.RouterGroup)
+	GetJwks(context *gin.Context)
+}
+
+type WellKnownController struct {
+	path        string
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	keyrng      keyring.IKeyring
+}
+
+// NewWellKnownController
+// Returns a new WellKnownController.
+func NewWellKnownController(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, keyrng keyring.IKeyring) IWellKnownController {
+	controller := WellKnownController{
+		path:        ".well-known",
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if keyrng != nil {
+		controller.keyrng = keyrng
+	} else {
+		controller.keyrng = keyring.New(environment)
+	}
+
+	return &controller
+}
+
+// RegisterRoutes
+// Registers routes to gin.
+func (c *WellKnownController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	routes := routerGroup.Group(c.path)
+	routes.GET("jwks.json", c.GetJwks)
+}
+
+// GetJwks
+// @router       /.well-known/jwks.json [get]
+// @tags         Auth
+// @summary      Gets public keys to verify JWT tokens.
+// @description  Returns a JSON Web Key Set with the active signing key and keys kept for rotation grace period.
+// @produce      json
+// @success      200  {object}  keyring.Jwks
+func (c *WellKnownController) GetJwks(context *gin.Context) {
+	context.Header("Cache-Control", "public, max-age=300")
+	context.JSON(http.StatusOK, c.keyrng.Jwks())
+}
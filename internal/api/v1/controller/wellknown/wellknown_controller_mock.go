@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/api/v1/controller/wellknown/wellknown_controller.go
+
+// Package wellknown is a generated GoMock package.
+package wellknown
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIWellKnownController is a mock of IWellKnownController interface.
+type MockIWellKnownController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWellKnownControllerMockRecorder
+}
+
+// MockIWellKnownControllerMockRecorder is the mock recorder for MockIWellKnownController.
+type MockIWellKnownControllerMockRecorder struct {
+	mock *MockIWellKnownController
+}
+
+// NewMockIWellKnownController creates a new mock instance.
+func NewMockIWellKnownController(ctrl *gomock.Controller) *MockIWellKnownController {
+	mock := &MockIWellKnownController{ctrl: ctrl}
+	mock.recorder = &MockIWellKnownControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWellKnownController) EXPECT() *MockIWellKnownControllerMockRecorder {
+	return m.recorder
+}
+
+// GetJwks mocks base method.
+func (m *MockIWellKnownController) GetJwks(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetJwks", context)
+}
+
+// GetJwks indicates an expected call of GetJwks.
+func (mr *MockIWellKnownControllerMockRecorder) GetJwks(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwks", reflect.TypeOf((*MockIWellKnownController)(nil).GetJwks), context)
+}
+
+// RegisterRoutes mocks base method.
+func (m *MockIWellKnownController) RegisterRoutes(routerGroup *gin.RouterGroup) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterRoutes", routerGroup)
+}
+
+// RegisterRoutes indicates an expected call of RegisterRoutes.
+func (mr *MockIWellKnownControllerMockRecorder) RegisterRoutes(routerGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIWellKnownController)(nil).RegisterRoutes), routerGroup)
+}
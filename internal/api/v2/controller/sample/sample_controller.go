@@ -42,7 +42,7 @@ func NewSampleController(environment env.IEnvironment, loggr logger.ILogger, val
 // Registers routes to gin.
 func (c *SampleController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	routes := routerGroup.Group(c.path)
-	routes.Use(api.AuthenticationMiddleware(c.environment))
+	routes.Use(api.AuthenticationMiddleware(c.environment, c.loggr, c.validatr, c.cachr))
 	routes.GET("", c.Get)
 }
 
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/data/database/health"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/keyring"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+)
+
+const configUsage = `usage: config check`
+
+// requiredVariables
+// Environment variables which must not be empty.
+var requiredVariables = []string{env.AppEnvironment, env.AppName, env.AppHost, env.PostgresqlConnectionString, env.RedisAddress}
+
+type IConfigCommand interface {
+	Run(args []string, output io.Writer) error
+}
+
+type ConfigCommand struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	healthDb    health.IHealthDb
+}
+
+// NewConfigCommand
+// Returns a new ConfigCommand. Db is created on check if healthDb is nil, so invalid pool settings are reported.
+func NewConfigCommand(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, healthDb health.IHealthDb) IConfigCommand {
+	command := ConfigCommand{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		healthDb:    healthDb,
+	}
+
+	return &command
+}
+
+// Run
+// Runs config check, which validates environment variables and connections to db and redis.
+// Writes the result of each check and returns an error if any of them fails.
+func (c *ConfigCommand) Run(args []string, output io.Writer) error {
+	if len(args) != 1 || args[0] != "check" {
+		return errors.New(configUsage)
+	}
+
+	checks := []struct {
+		name string
+		run  func() error
+	}{
+		{"Required variables", c.checkRequiredVariables},
+		{"JWT signing keys", c.checkSigningKeys},
+		{"Password hashing", c.checkPasswordHashing},
+		{"Db pool settings", c.checkPoolSettings},
+		{"Db connection", c.checkDb},
+		{"Redis connection", c.checkRedis},
+	}
+
+	failures := 0
+	for _, check := range checks {
+		err := recoverPanic(check.run)
+		if err != nil {
+			failures++
+			fmt.Fprintf(output, "FAIL  %s: %s\n", check.name, err)
+		} else {
+			fmt.Fprintf(output, "OK    %s\n", check.name)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d config checks failed", failures, len(checks))
+	}
+
+	return nil
+}
+
+func (c *ConfigCommand) checkRequiredVariables() error {
+	var missing []string
+	for _, key := range requiredVariables {
+		if c.environment.Get(key) == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s must be set", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func (c *ConfigCommand) checkSigningKeys() error {
+	if c.environment.Get(env.JwtKeysDirectory) == "" && c.environment.Get(env.JwtSecret) == "" {
+		return fmt.Errorf("%s or %s must be set", env.JwtSecret, env.JwtKeysDirectory)
+	}
+
+	_, err := keyring.New(c.environment).SigningKey()
+	return err
+}
+
+func (c *ConfigCommand) checkPasswordHashing() error {
+	hasher.New(c.environment)
+	return nil
+}
+
+func (c *ConfigCommand) checkPoolSettings() error {
+	return database.New(c.environment).Close()
+}
+
+func (c *ConfigCommand) checkDb() error {
+	if c.healthDb == nil {
+		c.healthDb = health.NewHealthDb(c.environment, nil)
+	}
+
+	return c.healthDb.Ping(context.Background())
+}
+
+func (c *ConfigCommand) checkRedis() error {
+	return c.cachr.Ping(context.Background())
+}
+
+// recoverPanic
+// Runs fn and returns its panic as an error, since constructors panic on invalid configuration.
+func recoverPanic(fn func() error) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%v", rec)
+		}
+	}()
+
+	return fn()
+}
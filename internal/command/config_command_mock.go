@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/command/config_command.go
+
+// Package command is a generated GoMock package.
+package command
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIConfigCommand is a mock of IConfigCommand interface.
+type MockIConfigCommand struct {
+	ctrl     *gomock.Controller
+	recorder *MockIConfigCommandMockRecorder
+}
+
+// MockIConfigCommandMockRecorder is the mock recorder for MockIConfigCommand.
+type MockIConfigCommandMockRecorder struct {
+	mock *MockIConfigCommand
+}
+
+// NewMockIConfigCommand creates a new mock instance.
+func NewMockIConfigCommand(ctrl *gomock.Controller) *MockIConfigCommand {
+	mock := &MockIConfigCommand{ctrl: ctrl}
+	mock.recorder = &MockIConfigCommandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIConfigCommand) EXPECT() *MockIConfigCommandMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIConfigCommand) Run(args []string, output io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", args, output)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIConfigCommandMockRecorder) Run(args, output interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIConfigCommand)(nil).Run), args, output)
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"go-clean-architecture/internal/data/database/health"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigCommandTestSuite struct {
+	suite.Suite
+	configCommand   IConfigCommand
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockHealthDb    *health.MockIHealthDb
+	variables       map[string]string
+}
+
+// Run suite.
+func TestConfigCommand(t *testing.T) {
+	suite.Run(t, new(ConfigCommandTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *ConfigCommandTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockHealthDb = health.NewMockIHealthDb(ctrl)
+
+	s.variables = map[string]string{
+		env.AppEnvironment:             "Test",
+		env.AppName:                    "go-clean-architecture",
+		env.AppHost:                    "localhost:8080",
+		env.PostgresqlConnectionString: "host=localhost sslmode=disable",
+		env.RedisAddress:               "localhost:6379",
+		env.JwtSecret:                  "secret",
+		env.PasswordHashAlgorithm:      "argon2id",
+	}
+	s.mockEnvironment.EXPECT().Get(gomock.Any()).DoAndReturn(func(key string) string { return s.variables[key] }).AnyTimes()
+
+	s.configCommand = NewConfigCommand(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockHealthDb)
+}
+
+func (s *ConfigCommandTestSuite) TestRun_ValidConfig_PassesEveryCheck() {
+	// Given
+	s.mockHealthDb.EXPECT().Ping(gomock.Any()).Return(nil)
+	s.mockCacher.EXPECT().Ping(gomock.Any()).Return(nil)
+	output := &bytes.Buffer{}
+
+	// When
+	err := s.configCommand.Run([]string{"check"}, output)
+
+	// Then
+	s.NoError(err)
+	s.NotContains(output.String(), "FAIL")
+}
+
+func (s *ConfigCommandTestSuite) TestRun_InvalidSettingsAndUnreachableRedis_ReportsEachFailure() {
+	// Given
+	s.variables[env.PasswordHashAlgorithm] = "md5"
+	s.variables[env.PostgresqlMaxOpenConnections] = "many"
+	delete(s.variables, env.RedisAddress)
+	s.mockHealthDb.EXPECT().Ping(gomock.Any()).Return(nil)
+	s.mockCacher.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+	output := &bytes.Buffer{}
+
+	// When
+	err := s.configCommand.Run([]string{"check"}, output)
+
+	// Then
+	s.EqualError(err, "4 of 6 config checks failed")
+	s.Contains(output.String(), "FAIL  Required variables: REDIS_ADDRESS must be set\n")
+	s.Contains(output.String(), "FAIL  Password hashing: PASSWORD_HASH_ALGORITHM variable is not supported.\n")
+	s.Contains(output.String(), "FAIL  Db pool settings: ")
+	s.Contains(output.String(), "FAIL  Redis connection: connection refused\n")
+}
+
+func (s *ConfigCommandTestSuite) TestRun_WithoutCheck_ReturnsUsage() {
+	// When
+	err := s.configCommand.Run([]string{}, &bytes.Buffer{})
+
+	// Then
+	s.EqualError(err, configUsage)
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"go-clean-architecture/internal/service/user"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+)
+
+type ICreateUserCommand interface {
+	Run(args []string, output io.Writer) error
+}
+
+type CreateUserCommand struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	userService user.IUserService
+}
+
+// NewCreateUserCommand
+// Returns a new CreateUserCommand.
+func NewCreateUserCommand(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, userService user.IUserService) ICreateUserCommand {
+	command := CreateUserCommand{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if userService != nil {
+		command.userService = userService
+	} else {
+		command.userService = user.NewUserService(environment, loggr, validatr, cachr, nil, nil, nil, nil)
+	}
+
+	return &command
+}
+
+// Run
+// Creates an active user from command line flags, e.g. to add the first admin or a programmatic user.
+// Password is read from CREATE_USER_PASSWORD so it does not end up in shell history or process list.
+// The user is recorded in the audit trail without an acting admin.
+func (c *CreateUserCommand) Run(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("create-user", flag.ContinueOnError)
+	flags.SetOutput(output)
+	userName := flags.String("username", "", "username of the user")
+	email := flags.String("email", "", "email of the user")
+	isProgrammatic := flags.Bool("programmatic", false, "creates a programmatic user which can only use api keys and programmatic tokens")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	ch := make(chan *user.UserServiceResponse)
+	defer close(ch)
+
+	go c.userService.AddUser(
+		context.Background(), ch, &user.AddUserServiceModel{
+			UserName:       *userName,
+			Email:          *email,
+			Password:       c.environment.Get(env.CreateUserPassword),
+			IsActive:       true,
+			IsProgrammatic: *isProgrammatic,
+		},
+	)
+
+	response := <-ch
+	if response.Error != nil {
+		return response.Error
+	}
+
+	fmt.Fprintf(output, "User %s is created with id %d.\n", response.User.UserName, response.User.Id)
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/command/create_user_command.go
+
+// Package command is a generated GoMock package.
+package command
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICreateUserCommand is a mock of ICreateUserCommand interface.
+type MockICreateUserCommand struct {
+	ctrl     *gomock.Controller
+	recorder *MockICreateUserCommandMockRecorder
+}
+
+// MockICreateUserCommandMockRecorder is the mock recorder for MockICreateUserCommand.
+type MockICreateUserCommandMockRecorder struct {
+	mock *MockICreateUserCommand
+}
+
+// NewMockICreateUserCommand creates a new mock instance.
+func NewMockICreateUserCommand(ctrl *gomock.Controller) *MockICreateUserCommand {
+	mock := &MockICreateUserCommand{ctrl: ctrl}
+	mock.recorder = &MockICreateUserCommandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICreateUserCommand) EXPECT() *MockICreateUserCommandMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockICreateUserCommand) Run(args []string, output io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", args, output)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockICreateUserCommandMockRecorder) Run(args, output interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockICreateUserCommand)(nil).Run), args, output)
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/service/user"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreateUserCommandTestSuite struct {
+	suite.Suite
+	createUserCommand ICreateUserCommand
+	mockEnvironment   *env.MockIEnvironment
+	mockLogger        *logger.MockILogger
+	mockValidator     *validator.MockIValidator
+	mockCacher        *cacher.MockICacher
+	mockUserService   *user.MockIUserService
+}
+
+// Run suite.
+func TestCreateUserCommand(t *testing.T) {
+	suite.Run(t, new(CreateUserCommandTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *CreateUserCommandTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockUserService = user.NewMockIUserService(ctrl)
+
+	s.createUserCommand = NewCreateUserCommand(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockUserService)
+}
+
+func (s *CreateUserCommandTestSuite) TestRun_Programmatic_AddsActiveProgrammaticUser() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.CreateUserPassword).Return("")
+	s.mockUserService.
+		EXPECT().
+		AddUser(gomock.Any(), gomock.Any(), gomock.Eq(&user.AddUserServiceModel{UserName: "robot", IsActive: true, IsProgrammatic: true})).
+		DoAndReturn(func(ctx context.Context, ch chan *user.UserServiceResponse, model *user.AddUserServiceModel) {
+			ch <- &user.UserServiceResponse{User: auth.User{Id: 5, UserName: "robot", IsActive: true, IsProgrammatic: true}}
+		})
+	output := &bytes.Buffer{}
+
+	// When
+	err := s.createUserCommand.Run([]string{"--username", "robot", "--programmatic"}, output)
+
+	// Then
+	s.NoError(err)
+	s.Equal("User robot is created with id 5.\n", output.String())
+}
+
+func (s *CreateUserCommandTestSuite) TestRun_PasswordFromEnvironment_AddsUserWithPassword() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.CreateUserPassword).Return("Secret-123")
+	s.mockUserService.
+		EXPECT().
+		AddUser(gomock.Any(), gomock.Any(), gomock.Eq(&user.AddUserServiceModel{UserName: "admin", Email: "admin@example.com", Password: "Secret-123", IsActive: true})).
+		DoAndReturn(func(ctx context.Context, ch chan *user.UserServiceResponse, model *user.AddUserServiceModel) {
+			ch <- &user.UserServiceResponse{User: auth.User{Id: 6, UserName: "admin", IsActive: true}}
+		})
+	output := &bytes.Buffer{}
+
+	// When
+	err := s.createUserCommand.Run([]string{"--username", "admin", "--email", "admin@example.com"}, output)
+
+	// Then
+	s.NoError(err)
+	s.Equal("User admin is created with id 6.\n", output.String())
+}
+
+func (s *CreateUserCommandTestSuite) TestRun_PasswordFlag_ReturnsError() {
+	// When
+	err := s.createUserCommand.Run([]string{"--username", "admin", "--password", "Secret-123"}, &bytes.Buffer{})
+
+	// Then
+	s.EqualError(err, "flag provided but not defined: -password")
+}
+
+func (s *CreateUserCommandTestSuite) TestRun_UnknownFlag_ReturnsError() {
+	// When
+	err := s.createUserCommand.Run([]string{"--admin"}, &bytes.Buffer{})
+
+	// Then
+	s.EqualError(err, "flag provided but not defined: -admin")
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go-clean-architecture/internal/data/database/migrator"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+
+	"go.uber.org/zap"
+)
+
+const migrateUsage = `usage: migrate <command>
+  up            applies all pending migrations
+  down [N]      rolls back N applied migrations, 1 by default
+  goto V        applies or rolls back migrations until db is at version V
+  status        shows current version and pending migrations
+  force V       sets version V without running migrations, -1 clears it`
+
+type IMigrateCommand interface {
+	Run(args []string, output io.Writer) error
+}
+
+type MigrateCommand struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	migratr     migrator.IMigrator
+}
+
+// NewMigrateCommand
+// Returns a new MigrateCommand.
+func NewMigrateCommand(environment env.IEnvironment, loggr logger.ILogger, migratr migrator.IMigrator) IMigrateCommand {
+	command := MigrateCommand{
+		environment: environment,
+		loggr:       loggr,
+	}
+
+	if migratr != nil {
+		command.migratr = migratr
+	} else {
+		var err error
+		command.migratr, err = migrator.New(environment, loggr, nil, nil)
+		if err != nil {
+			loggr.Panic("Migrations could not be read.", zap.Error(err))
+		}
+	}
+
+	return &command
+}
+
+// Run
+// Runs the migrate command given in args and writes its result to output.
+func (c *MigrateCommand) Run(args []string, output io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+
+		return c.migratr.Up()
+	case "down":
+		steps := 1
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		}
+
+		return c.migratr.Down(steps)
+	case "goto", "force":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		if args[0] == "goto" {
+			return c.migratr.Goto(version)
+		}
+		return c.migratr.Force(version)
+	case "status":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+
+		return c.status(output)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// status
+// Writes current version and every migration with its state.
+func (c *MigrateCommand) status(output io.Writer) error {
+	status, err := c.migratr.Status()
+	if err != nil {
+		return err
+	}
+
+	version := "none"
+	if status.Version != migrator.NilVersion {
+		version = strconv.FormatInt(status.Version, 10)
+	}
+	if status.Dirty {
+		version += " (dirty)"
+	}
+
+	fmt.Fprintf(output, "Version: %s\n", version)
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.IsApplied {
+			state = "applied"
+		}
+
+		fmt.Fprintf(output, "%d_%s\t%s\n", migration.Version, migration.Name, state)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/command/migrate_command.go
+
+// Package command is a generated GoMock package.
+package command
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMigrateCommand is a mock of IMigrateCommand interface.
+type MockIMigrateCommand struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigrateCommandMockRecorder
+}
+
+// MockIMigrateCommandMockRecorder is the mock recorder for MockIMigrateCommand.
+type MockIMigrateCommandMockRecorder struct {
+	mock *MockIMigrateCommand
+}
+
+// NewMockIMigrateCommand creates a new mock instance.
+func NewMockIMigrateCommand(ctrl *gomock.Controller) *MockIMigrateCommand {
+	mock := &MockIMigrateCommand{ctrl: ctrl}
+	mock.recorder = &MockIMigrateCommandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrateCommand) EXPECT() *MockIMigrateCommandMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIMigrateCommand) Run(args []string, output io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", args, output)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIMigrateCommandMockRecorder) Run(args, output interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIMigrateCommand)(nil).Run), args, output)
+}
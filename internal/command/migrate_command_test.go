@@ -0,0 +1,96 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"go-clean-architecture/internal/data/database/migrator"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrateCommandTestSuite struct {
+	suite.Suite
+	migrateCommand  IMigrateCommand
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockMigrator    *migrator.MockIMigrator
+}
+
+// Run suite.
+func TestMigrateCommand(t *testing.T) {
+	suite.Run(t, new(MigrateCommandTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *MigrateCommandTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockMigrator = migrator.NewMockIMigrator(ctrl)
+
+	s.migrateCommand = NewMigrateCommand(s.mockEnvironment, s.mockLogger, s.mockMigrator)
+}
+
+func (s *MigrateCommandTestSuite) TestRun_DownWithoutNumber_RollsBackOne() {
+	// Given
+	s.mockMigrator.EXPECT().Down(1).Return(nil)
+
+	// When
+	err := s.migrateCommand.Run([]string{"down"}, &bytes.Buffer{})
+
+	// Then
+	s.NoError(err)
+}
+
+func (s *MigrateCommandTestSuite) TestRun_Goto_MigratesToVersion() {
+	// Given
+	s.mockMigrator.EXPECT().Goto(int64(20220613000000)).Return(nil)
+
+	// When
+	err := s.migrateCommand.Run([]string{"goto", "20220613000000"}, &bytes.Buffer{})
+
+	// Then
+	s.NoError(err)
+}
+
+func (s *MigrateCommandTestSuite) TestRun_ForceInvalidVersion_ReturnsError() {
+	// When
+	err := s.migrateCommand.Run([]string{"force", "latest"}, &bytes.Buffer{})
+
+	// Then
+	s.EqualError(err, `invalid version "latest"`)
+}
+
+func (s *MigrateCommandTestSuite) TestRun_UnknownCommand_ReturnsUsage() {
+	// When
+	err := s.migrateCommand.Run([]string{"redo"}, &bytes.Buffer{})
+
+	// Then
+	s.EqualError(err, migrateUsage)
+}
+
+func (s *MigrateCommandTestSuite) TestRun_Status_WritesVersionAndMigrations() {
+	// Given
+	s.mockMigrator.EXPECT().Status().Return(&migrator.StatusResponse{
+		Version: 1,
+		Dirty:   true,
+		Migrations: []migrator.MigrationStatus{
+			{Version: 1, Name: "users", IsApplied: true},
+			{Version: 2, Name: "roles"},
+		},
+	}, nil)
+	output := &bytes.Buffer{}
+
+	// When
+	err := s.migrateCommand.Run([]string{"status"}, output)
+
+	// Then
+	s.NoError(err)
+	s.Equal("Version: 1 (dirty)\n1_users\tapplied\n2_roles\tpending\n", output.String())
+}
@@ -0,0 +1,251 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/lib/pq"
+)
+
+type IApiKeyDb interface {
+	AddApiKey(ctx context.Context, ch chan *AddApiKeyResponse, model *AddApiKeyModel)
+	GetApiKeys(ctx context.Context, ch chan *GetApiKeysResponse, model *GetApiKeysModel)
+	GetApiKeyByPrefix(ctx context.Context, ch chan *GetApiKeyByPrefixResponse, model *GetApiKeyByPrefixModel)
+	UpdateLastUsedDate(ctx context.Context, ch chan *UpdateLastUsedDateResponse, model *UpdateLastUsedDateModel)
+	RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyResponse, model *RevokeApiKeyModel)
+}
+
+type ApiKeyDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewApiKeyDb
+// Returns a new ApiKeyDb.
+func NewApiKeyDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IApiKeyDb {
+	db := ApiKeyDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// AddApiKey
+// Adds an api key for an active programmatic user and returns its id.
+// Returns sql.ErrNoRows if user is not found, deleted, inactive or not programmatic.
+func (d *ApiKeyDb) AddApiKey(ctx context.Context, ch chan *AddApiKeyResponse, model *AddApiKeyModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddApiKeyResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	insert into api_keys (user_id, name, prefix, key_hash, scopes, expiry_date, created_by)
+	select u.id, $2, $3, $4, $5, $6, $7
+	from users as u
+	where u.id = $1 and u.is_active = true and u.is_programmatic = true and u.deleted_at is null
+	returning id`
+
+	var response AddApiKeyResponse
+	dbErr := d.pool.QueryRowContext(
+		ctx, query, model.UserId, model.Name, model.Prefix, model.KeyHash, pq.Array(model.Scopes), model.ExpiryDate, model.CreatedBy,
+	).Scan(&response.Id)
+	if dbErr != nil {
+		ch <- &AddApiKeyResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// GetApiKeys
+// Gets api keys without their hashes, optionally filtered by user.
+func (d *ApiKeyDb) GetApiKeys(ctx context.Context, ch chan *GetApiKeysResponse, model *GetApiKeysModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetApiKeysResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select id, user_id, name, prefix, scopes, expiry_date, last_used_date, created_date, created_by, revoked_date, revoked_by
+	from api_keys
+	where ($1 = 0 or user_id = $1)
+	order by id desc`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.UserId)
+	if err != nil {
+		ch <- &GetApiKeysResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	apiKeys := []ApiKey{}
+	for rows.Next() {
+		var apiKey ApiKey
+		var expiryDate, lastUsedDate, revokedDate sql.NullTime
+		var revokedBy sql.NullInt64
+
+		err = rows.Scan(
+			&apiKey.Id, &apiKey.UserId, &apiKey.Name, &apiKey.Prefix, pq.Array(&apiKey.Scopes),
+			&expiryDate, &lastUsedDate, &apiKey.CreatedDate, &apiKey.CreatedBy, &revokedDate, &revokedBy,
+		)
+		if err != nil {
+			ch <- &GetApiKeysResponse{Error: err}
+			return
+		}
+
+		if expiryDate.Valid {
+			apiKey.ExpiryDate = &expiryDate.Time
+		}
+		if lastUsedDate.Valid {
+			apiKey.LastUsedDate = &lastUsedDate.Time
+		}
+		if revokedDate.Valid {
+			apiKey.RevokedDate = &revokedDate.Time
+		}
+		if revokedBy.Valid {
+			apiKey.RevokedBy = &revokedBy.Int64
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetApiKeysResponse{Error: err}
+		return
+	}
+
+	ch <- &GetApiKeysResponse{ApiKeys: apiKeys}
+}
+
+// GetApiKeyByPrefix
+// Gets a usable api key with its hash and user by prefix.
+// Returns sql.ErrNoRows if key is unknown, revoked, expired or its user is deleted or no longer an active programmatic user.
+func (d *ApiKeyDb) GetApiKeyByPrefix(ctx context.Context, ch chan *GetApiKeyByPrefixResponse, model *GetApiKeyByPrefixModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetApiKeyByPrefixResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select k.id, k.key_hash, k.scopes, k.last_used_date, u.id, u.username, u.email
+	from api_keys as k
+	inner join users as u on u.id = k.user_id
+	where k.prefix = $1
+	and k.revoked_date is null
+	and (k.expiry_date is null or k.expiry_date > now())
+	and u.is_active = true
+	and u.is_programmatic = true
+	and u.deleted_at is null`
+
+	var response GetApiKeyByPrefixResponse
+	var lastUsedDate sql.NullTime
+	var email sql.NullString
+	dbErr := d.pool.QueryRowContext(ctx, query, model.Prefix).Scan(
+		&response.Id, &response.KeyHash, pq.Array(&response.Scopes), &lastUsedDate, &response.UserId, &response.UserName, &email,
+	)
+	if dbErr != nil {
+		ch <- &GetApiKeyByPrefixResponse{Error: dbErr}
+		return
+	}
+
+	if lastUsedDate.Valid {
+		response.LastUsedDate = &lastUsedDate.Time
+	}
+	if email.Valid {
+		response.Email = email.String
+	}
+
+	ch <- &response
+}
+
+// UpdateLastUsedDate
+// Sets last used date of the api key to now.
+func (d *ApiKeyDb) UpdateLastUsedDate(ctx context.Context, ch chan *UpdateLastUsedDateResponse, model *UpdateLastUsedDateModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UpdateLastUsedDateResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update api_keys set last_used_date = current_timestamp where id = $1`
+
+	_, err := d.pool.ExecContext(ctx, query, model.Id)
+	if err != nil {
+		ch <- &UpdateLastUsedDateResponse{Error: err}
+		return
+	}
+
+	ch <- &UpdateLastUsedDateResponse{}
+}
+
+// RevokeApiKey
+// Revokes an active api key.
+// Returns sql.ErrNoRows if key is unknown or already revoked.
+func (d *ApiKeyDb) RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyResponse, model *RevokeApiKeyModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeApiKeyResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update api_keys set revoked_date = current_timestamp, revoked_by = $2 where id = $1 and revoked_date is null`
+
+	result, err := d.pool.ExecContext(ctx, query, model.Id, model.RevokedBy)
+	if err != nil {
+		ch <- &RevokeApiKeyResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &RevokeApiKeyResponse{Error: err}
+		return
+	}
+
+	if rows == 0 {
+		ch <- &RevokeApiKeyResponse{Error: sql.ErrNoRows}
+		return
+	}
+
+	ch <- &RevokeApiKeyResponse{}
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/apikey/apikey_db.go
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIApiKeyDb is a mock of IApiKeyDb interface.
+type MockIApiKeyDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIApiKeyDbMockRecorder
+}
+
+// MockIApiKeyDbMockRecorder is the mock recorder for MockIApiKeyDb.
+type MockIApiKeyDbMockRecorder struct {
+	mock *MockIApiKeyDb
+}
+
+// NewMockIApiKeyDb creates a new mock instance.
+func NewMockIApiKeyDb(ctrl *gomock.Controller) *MockIApiKeyDb {
+	mock := &MockIApiKeyDb{ctrl: ctrl}
+	mock.recorder = &MockIApiKeyDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIApiKeyDb) EXPECT() *MockIApiKeyDbMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockIApiKeyDb) AddApiKey(ctx context.Context, ch chan *AddApiKeyResponse, model *AddApiKeyModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddApiKey", ctx, ch, model)
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockIApiKeyDbMockRecorder) AddApiKey(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockIApiKeyDb)(nil).AddApiKey), ctx, ch, model)
+}
+
+// GetApiKeyByPrefix mocks base method.
+func (m *MockIApiKeyDb) GetApiKeyByPrefix(ctx context.Context, ch chan *GetApiKeyByPrefixResponse, model *GetApiKeyByPrefixModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetApiKeyByPrefix", ctx, ch, model)
+}
+
+// GetApiKeyByPrefix indicates an expected call of GetApiKeyByPrefix.
+func (mr *MockIApiKeyDbMockRecorder) GetApiKeyByPrefix(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByPrefix", reflect.TypeOf((*MockIApiKeyDb)(nil).GetApiKeyByPrefix), ctx, ch, model)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIApiKeyDb) GetApiKeys(ctx context.Context, ch chan *GetApiKeysResponse, model *GetApiKeysModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetApiKeys", ctx, ch, model)
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIApiKeyDbMockRecorder) GetApiKeys(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIApiKeyDb)(nil).GetApiKeys), ctx, ch, model)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockIApiKeyDb) RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyResponse, model *RevokeApiKeyModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeApiKey", ctx, ch, model)
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIApiKeyDbMockRecorder) RevokeApiKey(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIApiKeyDb)(nil).RevokeApiKey), ctx, ch, model)
+}
+
+// UpdateLastUsedDate mocks base method.
+func (m *MockIApiKeyDb) UpdateLastUsedDate(ctx context.Context, ch chan *UpdateLastUsedDateResponse, model *UpdateLastUsedDateModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateLastUsedDate", ctx, ch, model)
+}
+
+// UpdateLastUsedDate indicates an expected call of UpdateLastUsedDate.
+func (mr *MockIApiKeyDbMockRecorder) UpdateLastUsedDate(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedDate", reflect.TypeOf((*MockIApiKeyDb)(nil).UpdateLastUsedDate), ctx, ch, model)
+}
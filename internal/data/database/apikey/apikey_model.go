@@ -0,0 +1,30 @@
+package apikey
+
+import "time"
+
+type AddApiKeyModel struct {
+	UserId     int64    `validate:"required"`
+	Name       string   `validate:"required,max=100"`
+	Prefix     string   `validate:"required"`
+	KeyHash    string   `validate:"required"`
+	Scopes     []string `validate:"required,min=1"`
+	ExpiryDate *time.Time
+	CreatedBy  int64 `validate:"required"`
+}
+
+type GetApiKeysModel struct {
+	UserId int64
+}
+
+type GetApiKeyByPrefixModel struct {
+	Prefix string `validate:"required"`
+}
+
+type UpdateLastUsedDateModel struct {
+	Id int64 `validate:"required"`
+}
+
+type RevokeApiKeyModel struct {
+	Id        int64 `validate:"required"`
+	RevokedBy int64 `validate:"required"`
+}
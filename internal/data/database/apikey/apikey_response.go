@@ -0,0 +1,46 @@
+package apikey
+
+import "time"
+
+type AddApiKeyResponse struct {
+	Error error `json:"-"`
+	Id    int64
+}
+
+type GetApiKeysResponse struct {
+	Error   error `json:"-"`
+	ApiKeys []ApiKey
+}
+
+type ApiKey struct {
+	Id           int64
+	UserId       int64
+	Name         string
+	Prefix       string
+	Scopes       []string
+	ExpiryDate   *time.Time
+	LastUsedDate *time.Time
+	CreatedDate  time.Time
+	CreatedBy    int64
+	RevokedDate  *time.Time
+	RevokedBy    *int64
+}
+
+type GetApiKeyByPrefixResponse struct {
+	Error        error `json:"-"`
+	Id           int64
+	KeyHash      string `json:"-"`
+	Scopes       []string
+	LastUsedDate *time.Time
+	UserId       int64
+	UserName     string
+	Email        string
+}
+
+type UpdateLastUsedDateResponse struct {
+	Error error `json:"-"`
+}
+
+type RevokeApiKeyResponse struct {
+	Error error `json:"-"`
+}
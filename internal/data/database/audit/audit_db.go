@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	_ "github.com/lib/pq"
+)
+
+type IAuditDb interface {
+	AddAuditEvents(ctx context.Context, ch chan *AddAuditEventsResponse, model *AddAuditEventsModel)
+	GetAuditEvents(ctx context.Context, ch chan *GetAuditEventsResponse, model *GetAuditEventsModel)
+}
+
+type AuditDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewAuditDb
+// Returns a new AuditDb.
+func NewAuditDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IAuditDb {
+	db := AuditDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// AddAuditEvents
+// Adds a batch of audit events to postgresql db with a single statement.
+func (d *AuditDb) AddAuditEvents(ctx context.Context, ch chan *AddAuditEventsResponse, model *AddAuditEventsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddAuditEventsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	values := make([]string, 0, len(model.Events))
+	args := make([]interface{}, 0, len(model.Events)*7)
+	for i, event := range model.Events {
+		n := i * 7
+		values = append(values, fmt.Sprintf("($%d, nullif($%d, 0), nullif($%d, ''), nullif($%d, ''), nullif($%d, ''), $%d::jsonb, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7))
+
+		var details interface{}
+		if len(event.Details) > 0 {
+			details = string(event.Details)
+		}
+
+		args = append(args, event.EventType, event.UserId, event.UserName, event.ClientIp, event.UserAgent, details, event.CreatedDate)
+	}
+
+	query := `insert into auth_audit_events (event_type, user_id, username, client_ip, user_agent, details, created_date) values ` + strings.Join(values, ", ")
+
+	_, err := d.pool.ExecContext(ctx, query, args...)
+	if err != nil {
+		ch <- &AddAuditEventsResponse{Error: err}
+		return
+	}
+
+	ch <- &AddAuditEventsResponse{}
+}
+
+// GetAuditEvents
+// Gets latest audit events from postgresql db, optionally filtered by user, event type and time range.
+// Time range includes From and excludes To.
+func (d *AuditDb) GetAuditEvents(ctx context.Context, ch chan *GetAuditEventsResponse, model *GetAuditEventsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetAuditEventsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select id, event_type, coalesce(user_id, 0), coalesce(username, ''), coalesce(client_ip, ''), coalesce(user_agent, ''), details, created_date
+	from auth_audit_events
+	where ($1 = 0 or user_id = $1)
+	and ($2 = '' or event_type = $2)
+	and ($3::timestamp is null or created_date >= $3)
+	and ($4::timestamp is null or created_date < $4)
+	order by id desc
+	limit $5`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.UserId, model.EventType, model.From, model.To, model.Limit)
+	if err != nil {
+		ch <- &GetAuditEventsResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var event AuditEvent
+		var details []byte
+
+		err = rows.Scan(&event.Id, &event.EventType, &event.UserId, &event.UserName, &event.ClientIp, &event.UserAgent, &details, &event.CreatedDate)
+		if err != nil {
+			ch <- &GetAuditEventsResponse{Error: err}
+			return
+		}
+
+		event.Details = details
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetAuditEventsResponse{Error: err}
+		return
+	}
+
+	ch <- &GetAuditEventsResponse{Events: events}
+}
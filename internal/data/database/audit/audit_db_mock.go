@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/audit/audit_db.go
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAuditDb is a mock of IAuditDb interface.
+type MockIAuditDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditDbMockRecorder
+}
+
+// MockIAuditDbMockRecorder is the mock recorder for MockIAuditDb.
+type MockIAuditDbMockRecorder struct {
+	mock *MockIAuditDb
+}
+
+// NewMockIAuditDb creates a new mock instance.
+func NewMockIAuditDb(ctrl *gomock.Controller) *MockIAuditDb {
+	mock := &MockIAuditDb{ctrl: ctrl}
+	mock.recorder = &MockIAuditDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditDb) EXPECT() *MockIAuditDbMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEvents mocks base method.
+func (m *MockIAuditDb) AddAuditEvents(ctx context.Context, ch chan *AddAuditEventsResponse, model *AddAuditEventsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddAuditEvents", ctx, ch, model)
+}
+
+// AddAuditEvents indicates an expected call of AddAuditEvents.
+func (mr *MockIAuditDbMockRecorder) AddAuditEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEvents", reflect.TypeOf((*MockIAuditDb)(nil).AddAuditEvents), ctx, ch, model)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockIAuditDb) GetAuditEvents(ctx context.Context, ch chan *GetAuditEventsResponse, model *GetAuditEventsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuditEvents", ctx, ch, model)
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockIAuditDbMockRecorder) GetAuditEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockIAuditDb)(nil).GetAuditEvents), ctx, ch, model)
+}
@@ -0,0 +1,15 @@
+package audit
+
+import "time"
+
+type AddAuditEventsModel struct {
+	Events []AuditEvent `validate:"required,min=1,dive"`
+}
+
+type GetAuditEventsModel struct {
+	UserId    int64
+	EventType string
+	From      *time.Time
+	To        *time.Time
+	Limit     int `validate:"required,gte=1,lte=500"`
+}
@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AddAuditEventsResponse struct {
+	Error error `json:"-"`
+}
+
+type GetAuditEventsResponse struct {
+	Error  error `json:"-"`
+	Events []AuditEvent
+}
+
+type AuditEvent struct {
+	Id          int64
+	EventType   string `validate:"required"`
+	UserId      int64
+	UserName    string
+	ClientIp    string
+	UserAgent   string
+	Details     json.RawMessage
+	CreatedDate time.Time
+}
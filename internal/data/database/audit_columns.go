@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go-clean-architecture/internal/util/helper"
+)
+
+// AuditColumns
+// Standard columns of persisted entities, selected in the order Audit.Dest reads them. created_by and updated_by are
+// ids of the users who made the change, null for anonymous requests and background work. Rows are soft deleted by
+// setting deleted_at, queries exclude them with NotDeleted.
+const AuditColumns = `created_at, created_by, updated_at, updated_by, deleted_at`
+
+// NotDeleted
+// Condition excluding soft deleted rows.
+const NotDeleted = `deleted_at is null`
+
+// Audit
+// Values of AuditColumns. UpdatedAt is nil until the row is updated, DeletedAt until it is soft deleted.
+type Audit struct {
+	CreatedAt time.Time
+	CreatedBy *int64
+	UpdatedAt *time.Time
+	UpdatedBy *int64
+	DeletedAt *time.Time `json:",omitempty"`
+}
+
+// Dest
+// Returns scan destinations of AuditColumns.
+func (a *Audit) Dest() []interface{} {
+	return []interface{}{&a.CreatedAt, &a.CreatedBy, &a.UpdatedAt, &a.UpdatedBy, &a.DeletedAt}
+}
+
+// SoftDelete
+// Sets deleted_at of the row with the id in table, recording the authenticated user of ctx as who updated it.
+// Returns sql.ErrNoRows if there is no row with the id or it is already deleted.
+func SoftDelete(ctx context.Context, executor IExecutor, table string, id int64) error {
+	query := `
+	update ` + table + `
+	set deleted_at = current_timestamp, updated_at = current_timestamp, updated_by = $2
+	where id = $1 and deleted_at is null`
+
+	return execOne(ctx, executor, query, id, helper.GetActorId(ctx))
+}
+
+// Restore
+// Clears deleted_at of the row with the id in table, recording the authenticated user of ctx as who updated it.
+// Returns sql.ErrNoRows if there is no row with the id or it is not deleted.
+func Restore(ctx context.Context, executor IExecutor, table string, id int64) error {
+	query := `
+	update ` + table + `
+	set deleted_at = null, updated_at = current_timestamp, updated_by = $2
+	where id = $1 and deleted_at is not null`
+
+	return execOne(ctx, executor, query, id, helper.GetActorId(ctx))
+}
+
+// execOne
+// Runs query on executor and returns sql.ErrNoRows if no row is affected.
+func execOne(ctx context.Context, executor IExecutor, query string, args ...interface{}) error {
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
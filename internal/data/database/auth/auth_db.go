@@ -3,75 +3,144 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"go-clean-architecture/internal/data/database"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
 	"go-clean-architecture/internal/util/validator"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+var ErrRefreshTokenReused = errors.New("refresh token is reused")
+var ErrUserExists = errors.New("username or email is taken")
+
+// User audit actions
+const (
+	UserAuditActionCreate             = "create"
+	UserAuditActionUpdate             = "update"
+	UserAuditActionActivate           = "activate"
+	UserAuditActionDeactivate         = "deactivate"
+	UserAuditActionSetProgrammatic    = "set-programmatic"
+	UserAuditActionUnsetProgrammatic  = "unset-programmatic"
+	UserAuditActionForcePasswordReset = "force-password-reset"
+	UserAuditActionDelete             = "delete"
+	UserAuditActionRestore            = "restore"
+)
+
+// userColumns
+// Selects users in the order scanUser reads them.
+const userColumns = `id, username, coalesce(email, ''), is_active, is_programmatic, password_hash <> '', ` + database.AuditColumns
+
+// uniqueViolation
+// Postgresql error code of unique constraint violations.
+const uniqueViolation = "23505"
+
+// UserQuerySchema
+// Fields of users which list queries can filter and sort by.
+var UserQuerySchema = query.Schema{
+	Key:        "id",
+	SoftDelete: true,
+	Fields: map[string]query.Field{
+		"id":             {Column: "id", Type: query.Int, Filterable: true, Sortable: true},
+		"userName":       {Column: "username", Type: query.String, Filterable: true, Sortable: true},
+		"email":          {Column: "email", Type: query.String, Filterable: true},
+		"isActive":       {Column: "is_active", Type: query.Bool, Filterable: true},
+		"isProgrammatic": {Column: "is_programmatic", Type: query.Bool, Filterable: true},
+		"createdAt":      {Column: "created_at", Type: query.Time, Filterable: true, Sortable: true},
+		"updatedAt":      {Column: "updated_at", Type: query.Time, Filterable: true},
+	},
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type IAuthDb interface {
-	GetUserByPassword(ch chan *GetUserByPasswordResponse, model *GetUserByPasswordModel)
-	GetUserByRefreshToken(ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel)
-	AddRefreshToken(ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel)
+	GetUserByUserName(ctx context.Context, ch chan *GetUserByUserNameResponse, model *GetUserByUserNameModel)
+	GetUserByRefreshToken(ctx context.Context, ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel)
+	AddRefreshToken(ctx context.Context, ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel)
+	UpdatePasswordHash(ctx context.Context, ch chan *UpdatePasswordHashResponse, model *UpdatePasswordHashModel)
+	CheckUserExists(ctx context.Context, ch chan *CheckUserExistsResponse, model *CheckUserExistsModel)
+	AddUser(ctx context.Context, ch chan *AddUserResponse, model *AddUserModel)
+	AddActivationToken(ctx context.Context, ch chan *AddActivationTokenResponse, model *AddActivationTokenModel)
+	ActivateUser(ctx context.Context, ch chan *ActivateUserResponse, model *ActivateUserModel)
+	RotateRefreshToken(ctx context.Context, ch chan *RotateRefreshTokenResponse, model *RotateRefreshTokenModel)
+	RevokeRefreshToken(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeRefreshTokenModel)
+	RevokeUserRefreshTokens(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeUserRefreshTokensModel)
+	GetSessions(ctx context.Context, ch chan *GetSessionsResponse, model *GetSessionsModel)
+	RevokeSession(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeSessionModel)
+	GetUserPermissions(ctx context.Context, ch chan *GetUserPermissionsResponse, model *GetUserPermissionsModel)
+	GetUserByEmail(ctx context.Context, ch chan *GetUserByEmailResponse, model *GetUserByEmailModel)
+	AddPasswordResetToken(ctx context.Context, ch chan *AddPasswordResetTokenResponse, model *AddPasswordResetTokenModel)
+	ResetPassword(ctx context.Context, ch chan *ResetPasswordResponse, model *ResetPasswordModel)
+	GetUsers(ctx context.Context, ch chan *GetUsersResponse, model *GetUsersModel)
+	GetUserById(ctx context.Context, ch chan *UserResponse, model *GetUserByIdModel)
+	CreateUser(ctx context.Context, ch chan *UserResponse, model *CreateUserModel)
+	UpdateUser(ctx context.Context, ch chan *UserResponse, model *UpdateUserModel)
+	SetUserActive(ctx context.Context, ch chan *UserResponse, model *SetUserActiveModel)
+	SetUserProgrammatic(ctx context.Context, ch chan *UserResponse, model *SetUserProgrammaticModel)
+	ForcePasswordReset(ctx context.Context, ch chan *UserResponse, model *ForcePasswordResetModel)
+	DeleteUser(ctx context.Context, ch chan *DeleteUserResponse, model *DeleteUserModel)
+	RestoreUser(ctx context.Context, ch chan *UserResponse, model *RestoreUserModel)
+	GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsResponse, model *GetUserAuditEventsModel)
 }
 
 type AuthDb struct {
-	loggr            logger.ILogger
-	validatr         validator.IValidator
-	cachr            cacher.ICacher
-	environment      env.IEnvironment
-	connectionString string
-	driverName       string
-	timeout          time.Duration
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
 }
 
 // NewAuthDb
 // Returns a new AuthDb.
-func NewAuthDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) IAuthDb {
+func NewAuthDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IAuthDb {
 	db := AuthDb{
-		environment:      environment,
-		loggr:            loggr,
-		validatr:         validatr,
-		cachr:            cachr,
-		driverName:       "postgres",
-		connectionString: environment.Get(env.PostgresqlConnectionString),
-		timeout:          time.Second * 5,
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
 	}
 
 	return &db
 }
 
-// GetUserByPassword
-// Gets user response from postgresql by password.
-func (d *AuthDb) GetUserByPassword(ch chan *GetUserByPasswordResponse, model *GetUserByPasswordModel) {
+// GetUserByUserName
+// Gets user response with its password hash from postgresql by username.
+func (d *AuthDb) GetUserByUserName(ctx context.Context, ch chan *GetUserByUserNameResponse, model *GetUserByUserNameModel) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
-		ch <- &GetUserByPasswordResponse{Error: modelErr}
-		return
-	}
-
-	connection, err := sql.Open(d.driverName, d.connectionString)
-	if err != nil {
-		ch <- &GetUserByPasswordResponse{Error: err}
+		ch <- &GetUserByUserNameResponse{Error: modelErr}
 		return
 	}
-	defer connection.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
-	query := `select id, username, email, is_active, is_programmatic from users where username = $1 and password_hash = $2`
+	query := `select id, username, email, password_hash, is_active, is_programmatic from users where username = $1 and ` + database.NotDeleted
 
-	var user GetUserByPasswordResponse
+	var user GetUserByUserNameResponse
 	var email sql.NullString
-	dbErr := connection.QueryRowContext(ctx, query, model.UserName, model.PasswordHash).Scan(&user.Id, &user.UserName, &email, &user.IsActive, &user.IsProgrammatic)
+	dbErr := d.pool.QueryRowContext(ctx, query, model.UserName).Scan(&user.Id, &user.UserName, &email, &user.PasswordHash, &user.IsActive, &user.IsProgrammatic)
 
 	if dbErr != nil || dbErr == sql.ErrNoRows {
-		ch <- &GetUserByPasswordResponse{Error: dbErr}
+		ch <- &GetUserByUserNameResponse{Error: dbErr}
 		return
 	}
 
@@ -82,35 +151,30 @@ func (d *AuthDb) GetUserByPassword(ch chan *GetUserByPasswordResponse, model *Ge
 	ch <- &user
 }
 
-// GetUserByPassword
-// Gets user response from postgresql by password.
-func (d *AuthDb) GetUserByRefreshToken(ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel) {
+// GetUserByRefreshToken
+// Gets user response from postgresql by hash of refresh token.
+func (d *AuthDb) GetUserByRefreshToken(ctx context.Context, ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &GetUserByRefreshTokenResponse{Error: modelErr}
 		return
 	}
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
-	if err != nil {
-		ch <- &GetUserByRefreshTokenResponse{Error: err}
-		return
-	}
-	defer connection.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
 	query := `
 	select u.id, username, email, is_active, is_programmatic
 	from users as u
 	inner join users_refresh_tokens as ur on u.id = ur.user_id
-	where ur.refresh_token = $1
-	and ur.expiry_date > now()`
+	where ur.token_hash = $1
+	and ur.expiry_date > now()
+	and ur.revoked_at is null
+	and u.deleted_at is null`
 
 	var user GetUserByRefreshTokenResponse
 	var email sql.NullString
-	dbErr := connection.QueryRowContext(ctx, query, model.RefreshToken).Scan(&user.Id, &user.UserName, &email, &user.IsActive, &user.IsProgrammatic)
+	dbErr := d.pool.QueryRowContext(ctx, query, model.TokenHash).Scan(&user.Id, &user.UserName, &email, &user.IsActive, &user.IsProgrammatic)
 
 	if dbErr != nil || dbErr == sql.ErrNoRows {
 		ch <- &GetUserByRefreshTokenResponse{Error: dbErr}
@@ -125,42 +189,999 @@ func (d *AuthDb) GetUserByRefreshToken(ch chan *GetUserByRefreshTokenResponse, m
 }
 
 // AddRefreshToken
-// Adds refresh token to db
-func (d *AuthDb) AddRefreshToken(ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel) {
+// Adds hash of refresh token to db
+func (d *AuthDb) AddRefreshToken(ctx context.Context, ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &AddRefreshTokenResponse{Error: modelErr}
 		return
 	}
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	insert into users_refresh_tokens (user_id, token_hash, family_id, expiry_date, user_agent, client_ip)
+	values ($1, $2, $3, current_timestamp + interval '30' day, nullif($4, ''), nullif($5, ''))`
+
+	result, err := database.Executor(d.pool, model.Transaction).ExecContext(ctx, query, model.UserId, model.TokenHash, model.FamilyId, model.UserAgent, model.ClientIp)
+
+	if err != nil {
+		ch <- &AddRefreshTokenResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
 	if err != nil {
 		ch <- &AddRefreshTokenResponse{Error: err}
 		return
 	}
-	defer connection.Close()
+	if rows != 1 {
+		ch <- &AddRefreshTokenResponse{Error: errors.New("refresh token is not added")}
+		return
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	ch <- &AddRefreshTokenResponse{}
+}
+
+// UpdatePasswordHash
+// Replaces password hash of the user, only if it still has the expected hash.
+func (d *AuthDb) UpdatePasswordHash(ctx context.Context, ch chan *UpdatePasswordHashResponse, model *UpdatePasswordHashModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UpdatePasswordHashResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
-	query := `insert into users_refresh_tokens (user_id, refresh_token, expiry_date) values ($1, $2, current_timestamp + interval '30' day)`
+	query := `update users set password_hash = $1 where id = $2 and password_hash = $3 and ` + database.NotDeleted
 
-	result, err := connection.ExecContext(ctx, query, model.UserId, model.RefreshToken)
+	result, err := d.pool.ExecContext(ctx, query, model.PasswordHash, model.UserId, model.OldPasswordHash)
+	if err != nil {
+		ch <- &UpdatePasswordHashResponse{Error: err}
+		return
+	}
 
+	rows, err := result.RowsAffected()
 	if err != nil {
-		ch <- &AddRefreshTokenResponse{Error: err}
+		ch <- &UpdatePasswordHashResponse{Error: err}
+		return
+	}
+
+	ch <- &UpdatePasswordHashResponse{IsUpdated: rows > 0}
+}
+
+// CheckUserExists
+// Checks if username or email is already taken by another user. Deleted users keep them, so they can be restored.
+func (d *AuthDb) CheckUserExists(ctx context.Context, ch chan *CheckUserExistsResponse, model *CheckUserExistsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &CheckUserExistsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select
+		exists(select 1 from users where username = $1),
+		exists(select 1 from users where lower(email) = lower($2))`
+
+	var response CheckUserExistsResponse
+	dbErr := d.pool.QueryRowContext(ctx, query, model.UserName, model.Email).Scan(&response.UserNameExists, &response.EmailExists)
+	if dbErr != nil {
+		ch <- &CheckUserExistsResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// AddUser
+// Adds a new user to db on behalf of the authenticated user, if any, and returns its id.
+func (d *AuthDb) AddUser(ctx context.Context, ch chan *AddUserResponse, model *AddUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddUserResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	insert into users (username, email, password_hash, is_active, is_programmatic, created_by)
+	values ($1, $2, $3, $4, $5, $6)
+	returning id`
+
+	var response AddUserResponse
+	dbErr := database.Executor(d.pool, model.Transaction).QueryRowContext(
+		ctx, query, model.UserName, model.Email, model.PasswordHash, model.IsActive, model.IsProgrammatic, helper.GetActorId(ctx),
+	).Scan(&response.Id)
+	if dbErr != nil {
+		ch <- &AddUserResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// AddActivationToken
+// Adds a single use activation token by its hash which expires in a day.
+func (d *AuthDb) AddActivationToken(ctx context.Context, ch chan *AddActivationTokenResponse, model *AddActivationTokenModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddActivationTokenResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into users_activation_tokens (user_id, token_hash, expiry_date) values ($1, $2, current_timestamp + interval '1' day)`
+
+	_, err := database.Executor(d.pool, model.Transaction).ExecContext(ctx, query, model.UserId, model.TokenHash)
+	if err != nil {
+		ch <- &AddActivationTokenResponse{Error: err}
+		return
+	}
+
+	ch <- &AddActivationTokenResponse{}
+}
+
+// ActivateUser
+// Consumes the activation token by its hash and activates its user in a single statement.
+// Returns sql.ErrNoRows if token is unknown, expired or already used.
+func (d *AuthDb) ActivateUser(ctx context.Context, ch chan *ActivateUserResponse, model *ActivateUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ActivateUserResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	with token as (
+		update users_activation_tokens
+		set used_date = current_timestamp
+		where token_hash = $1
+		and used_date is null
+		and expiry_date > now()
+		returning user_id
+	)
+	update users as u
+	set is_active = true, updated_at = current_timestamp
+	from token
+	where u.id = token.user_id
+	and u.deleted_at is null
+	returning u.id`
+
+	var response ActivateUserResponse
+	dbErr := d.pool.QueryRowContext(ctx, query, model.TokenHash).Scan(&response.UserId)
+	if dbErr != nil {
+		ch <- &ActivateUserResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// RotateRefreshToken
+// Revokes presented refresh token and adds a new one to the same family within a single transaction.
+// If presented token is already revoked, it is treated as reused and the whole family is revoked.
+func (d *AuthDb) RotateRefreshToken(ctx context.Context, ch chan *RotateRefreshTokenResponse, model *RotateRefreshTokenModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RotateRefreshTokenResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		ch <- &RotateRefreshTokenResponse{Error: err}
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+	select ur.id, ur.family_id, ur.revoked_at is not null, ur.expiry_date <= now(), u.id, u.username, u.email, u.is_active, u.is_programmatic
+	from users_refresh_tokens as ur
+	inner join users as u on u.id = ur.user_id
+	where ur.token_hash = $1
+	and u.deleted_at is null
+	for update of ur`
+
+	var response RotateRefreshTokenResponse
+	var tokenId int64
+	var isRevoked, isExpired bool
+	var email sql.NullString
+	dbErr := tx.QueryRowContext(ctx, query, model.TokenHash).Scan(
+		&tokenId, &response.FamilyId, &isRevoked, &isExpired,
+		&response.Id, &response.UserName, &email, &response.IsActive, &response.IsProgrammatic,
+	)
+	if dbErr != nil {
+		ch <- &RotateRefreshTokenResponse{Error: dbErr}
+		return
+	}
+
+	if email.Valid {
+		response.Email = email.String
+	}
+
+	if isRevoked {
+		query = `update users_refresh_tokens set revoked_at = current_timestamp where family_id = $1 and revoked_at is null`
+		_, err = tx.ExecContext(ctx, query, response.FamilyId)
+		if err != nil {
+			ch <- &RotateRefreshTokenResponse{Error: err}
+			return
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			ch <- &RotateRefreshTokenResponse{Error: err}
+			return
+		}
+
+		ch <- &RotateRefreshTokenResponse{Error: ErrRefreshTokenReused, Id: response.Id, FamilyId: response.FamilyId}
+		return
+	}
+
+	if isExpired {
+		ch <- &RotateRefreshTokenResponse{Error: sql.ErrNoRows}
+		return
+	}
+
+	query = `update users_refresh_tokens set revoked_at = current_timestamp where id = $1`
+	_, err = tx.ExecContext(ctx, query, tokenId)
+	if err != nil {
+		ch <- &RotateRefreshTokenResponse{Error: err}
+		return
+	}
+
+	query = `
+	insert into users_refresh_tokens (user_id, token_hash, family_id, expiry_date, user_agent, client_ip)
+	values ($1, $2, $3, current_timestamp + interval '30' day, nullif($4, ''), nullif($5, ''))`
+	_, err = tx.ExecContext(ctx, query, response.Id, model.NewTokenHash, response.FamilyId, model.UserAgent, model.ClientIp)
+	if err != nil {
+		ch <- &RotateRefreshTokenResponse{Error: err}
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		ch <- &RotateRefreshTokenResponse{Error: err}
+		return
+	}
+
+	ch <- &response
+}
+
+// RevokeRefreshToken
+// Revokes the family of given refresh token which belongs to the user.
+func (d *AuthDb) RevokeRefreshToken(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeRefreshTokenModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	update users_refresh_tokens
+	set revoked_at = current_timestamp
+	where revoked_at is null
+	and family_id = (select family_id from users_refresh_tokens where token_hash = $1 and user_id = $2)`
+
+	result, err := d.pool.ExecContext(ctx, query, model.TokenHash, model.UserId)
+	if err != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: err}
 		return
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		ch <- &AddRefreshTokenResponse{Error: err}
+		ch <- &RevokeRefreshTokenResponse{Error: err}
 		return
 	}
-	if rows != 1 {
-		ch <- &AddRefreshTokenResponse{Error: err}
+
+	ch <- &RevokeRefreshTokenResponse{RevokedCount: rows}
+}
+
+// RevokeUserRefreshTokens
+// Revokes every active refresh token of the user.
+func (d *AuthDb) RevokeUserRefreshTokens(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeUserRefreshTokensModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: modelErr}
 		return
 	}
 
-	ch <- &AddRefreshTokenResponse{}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update users_refresh_tokens set revoked_at = current_timestamp where user_id = $1 and revoked_at is null`
+
+	result, err := d.pool.ExecContext(ctx, query, model.UserId)
+	if err != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: err}
+		return
+	}
+
+	ch <- &RevokeRefreshTokenResponse{RevokedCount: rows}
+}
+
+// GetSessions
+// Gets active sessions of the user, a session is a refresh token family with an active token.
+// Device and ip are of the latest token in the family, which is added each time the session is refreshed.
+func (d *AuthDb) GetSessions(ctx context.Context, ch chan *GetSessionsResponse, model *GetSessionsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetSessionsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select
+		ur.family_id,
+		coalesce(ur.user_agent, ''),
+		coalesce(ur.client_ip, ''),
+		(select min(f.created_date) from users_refresh_tokens as f where f.family_id = ur.family_id),
+		ur.created_date,
+		ur.expiry_date
+	from users_refresh_tokens as ur
+	where ur.user_id = $1 and ur.revoked_at is null and ur.expiry_date > now()
+	order by ur.created_date desc`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.UserId)
+	if err != nil {
+		ch <- &GetSessionsResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		var session Session
+		err = rows.Scan(&session.Id, &session.UserAgent, &session.ClientIp, &session.CreatedDate, &session.LastUsedDate, &session.ExpiryDate)
+		if err != nil {
+			ch <- &GetSessionsResponse{Error: err}
+			return
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetSessionsResponse{Error: err}
+		return
+	}
+
+	ch <- &GetSessionsResponse{Sessions: sessions}
+}
+
+// RevokeSession
+// Revokes the refresh token family of the user with given session id.
+// Returns sql.ErrNoRows if the session is not found or already revoked.
+func (d *AuthDb) RevokeSession(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeSessionModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update users_refresh_tokens set revoked_at = current_timestamp where user_id = $1 and family_id = $2 and revoked_at is null`
+
+	result, err := d.pool.ExecContext(ctx, query, model.UserId, model.SessionId)
+	if err != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &RevokeRefreshTokenResponse{Error: err}
+		return
+	}
+
+	if rows == 0 {
+		ch <- &RevokeRefreshTokenResponse{Error: sql.ErrNoRows}
+		return
+	}
+
+	ch <- &RevokeRefreshTokenResponse{RevokedCount: rows}
+}
+
+// GetUserPermissions
+// Gets role names of the user and distinct permission names granted by those roles.
+func (d *AuthDb) GetUserPermissions(ctx context.Context, ch chan *GetUserPermissionsResponse, model *GetUserPermissionsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUserPermissionsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select
+		coalesce(array(
+			select r.name
+			from users_roles as ur
+			inner join roles as r on r.id = ur.role_id
+			where ur.user_id = $1
+			order by r.name
+		), '{}'),
+		coalesce(array(
+			select distinct p.name
+			from users_roles as ur
+			inner join roles_permissions as rp on rp.role_id = ur.role_id
+			inner join permissions as p on p.id = rp.permission_id
+			where ur.user_id = $1
+			order by p.name
+		), '{}')`
+
+	var response GetUserPermissionsResponse
+	dbErr := database.Executor(d.pool, model.Transaction).QueryRowContext(ctx, query, model.UserId).Scan(pq.Array(&response.Roles), pq.Array(&response.Permissions))
+	if dbErr != nil {
+		ch <- &GetUserPermissionsResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// GetUserByEmail
+// Gets user response from postgresql by case-insensitive email.
+func (d *AuthDb) GetUserByEmail(ctx context.Context, ch chan *GetUserByEmailResponse, model *GetUserByEmailModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUserByEmailResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select id, username, email, is_active, is_programmatic, password_hash <> '' from users where lower(email) = lower($1) and ` + database.NotDeleted
+
+	var user GetUserByEmailResponse
+	dbErr := d.pool.QueryRowContext(ctx, query, model.Email).Scan(&user.Id, &user.UserName, &user.Email, &user.IsActive, &user.IsProgrammatic, &user.HasPassword)
+	if dbErr != nil {
+		ch <- &GetUserByEmailResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &user
+}
+
+// AddPasswordResetToken
+// Adds a single use password reset token which expires in an hour.
+// Previous unused tokens of the user are invalidated, so only the latest link works.
+func (d *AuthDb) AddPasswordResetToken(ctx context.Context, ch chan *AddPasswordResetTokenResponse, model *AddPasswordResetTokenModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddPasswordResetTokenResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		ch <- &AddPasswordResetTokenResponse{Error: err}
+		return
+	}
+	defer tx.Rollback()
+
+	query := `update users_password_reset_tokens set used_date = current_timestamp where user_id = $1 and used_date is null`
+	_, err = tx.ExecContext(ctx, query, model.UserId)
+	if err != nil {
+		ch <- &AddPasswordResetTokenResponse{Error: err}
+		return
+	}
+
+	query = `insert into users_password_reset_tokens (user_id, token_hash, expiry_date) values ($1, $2, current_timestamp + interval '1' hour)`
+	_, err = tx.ExecContext(ctx, query, model.UserId, model.TokenHash)
+	if err != nil {
+		ch <- &AddPasswordResetTokenResponse{Error: err}
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		ch <- &AddPasswordResetTokenResponse{Error: err}
+		return
+	}
+
+	ch <- &AddPasswordResetTokenResponse{}
+}
+
+// ResetPassword
+// Consumes the password reset token and replaces password hash of its user in a single statement.
+// Returns sql.ErrNoRows if token is unknown, expired or already used.
+func (d *AuthDb) ResetPassword(ctx context.Context, ch chan *ResetPasswordResponse, model *ResetPasswordModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ResetPasswordResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	with token as (
+		update users_password_reset_tokens
+		set used_date = current_timestamp
+		where token_hash = $1
+		and used_date is null
+		and expiry_date > now()
+		returning user_id
+	)
+	update users as u
+	set password_hash = $2, updated_at = current_timestamp
+	from token
+	where u.id = token.user_id
+	and u.deleted_at is null
+	returning u.id, u.username`
+
+	var response ResetPasswordResponse
+	dbErr := d.pool.QueryRowContext(ctx, query, model.TokenHash, model.PasswordHash).Scan(&response.UserId, &response.UserName)
+	if dbErr != nil {
+		ch <- &ResetPasswordResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// GetUsers
+// Gets a page of users filtered and sorted by the fields of UserQuerySchema.
+func (d *AuthDb) GetUsers(ctx context.Context, ch chan *GetUsersResponse, model *GetUsersModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUsersResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	statement, args := model.Query.Select(userColumns, "users", "")
+
+	rows, err := d.pool.QueryContext(ctx, statement, args...)
+	if err != nil {
+		ch <- &GetUsersResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	var totalCount int64
+	users := []User{}
+	for rows.Next() {
+		user, err := scanUser(rows, &totalCount)
+		if err != nil {
+			ch <- &GetUsersResponse{Error: err}
+			return
+		}
+
+		users = append(users, *user)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetUsersResponse{Error: err}
+		return
+	}
+
+	if len(users) == 0 {
+		statement, args = model.Query.Count("users", "")
+		err = d.pool.QueryRowContext(ctx, statement, args...).Scan(&totalCount)
+		if err != nil {
+			ch <- &GetUsersResponse{Error: err}
+			return
+		}
+	}
+
+	kept, page := model.Query.Result(totalCount, len(users), func(i int) map[string]interface{} {
+		return userValues(users[i])
+	})
+
+	ch <- &GetUsersResponse{Users: users[:kept], Page: page}
+}
+
+// GetUserById
+// Gets user from postgresql by id.
+// Returns sql.ErrNoRows if user is not found or deleted.
+func (d *AuthDb) GetUserById(ctx context.Context, ch chan *UserResponse, model *GetUserByIdModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select ` + userColumns + ` from users where id = $1 and ` + database.NotDeleted
+
+	user, err := scanUser(d.pool.QueryRowContext(ctx, query, model.UserId))
+	if err != nil {
+		ch <- &UserResponse{Error: err}
+		return
+	}
+
+	ch <- &UserResponse{User: *user}
+}
+
+// CreateUser
+// Adds a new user on behalf of an admin and records it in the audit trail within a single transaction.
+// Returns ErrUserExists if username or email is taken.
+func (d *AuthDb) CreateUser(ctx context.Context, ch chan *UserResponse, model *CreateUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	query := `
+	insert into users (username, email, password_hash, is_active, is_programmatic, created_by)
+	values ($1, nullif($2, ''), $3, $4, $5, $6)
+	returning ` + userColumns
+
+	ch <- d.auditUser(
+		ctx, 0, model.ActorUserId, UserAuditActionCreate, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			return scanUser(tx.QueryRowContext(ctx, query, model.UserName, model.Email, model.PasswordHash, model.IsActive, model.IsProgrammatic, helper.GetActorId(ctx)))
+		},
+	)
+}
+
+// UpdateUser
+// Updates username and email of the user and records the change in the audit trail within a single transaction.
+// Returns sql.ErrNoRows if user is not found or ErrUserExists if username or email is taken.
+func (d *AuthDb) UpdateUser(ctx context.Context, ch chan *UserResponse, model *UpdateUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	query := `update users set username = $2, email = nullif($3, ''), updated_at = current_timestamp, updated_by = $4 where id = $1 returning ` + userColumns
+
+	ch <- d.auditUser(
+		ctx, model.UserId, model.ActorUserId, UserAuditActionUpdate, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			return scanUser(tx.QueryRowContext(ctx, query, model.UserId, model.UserName, model.Email, helper.GetActorId(ctx)))
+		},
+	)
+}
+
+// SetUserActive
+// Activates or deactivates the user and records it in the audit trail within a single transaction.
+// Deactivation also revokes every refresh token of the user.
+// Returns sql.ErrNoRows if user is not found.
+func (d *AuthDb) SetUserActive(ctx context.Context, ch chan *UserResponse, model *SetUserActiveModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	action := UserAuditActionActivate
+	if !model.IsActive {
+		action = UserAuditActionDeactivate
+	}
+
+	query := `update users set is_active = $2, updated_at = current_timestamp, updated_by = $3 where id = $1 returning ` + userColumns
+
+	ch <- d.auditUser(
+		ctx, model.UserId, model.ActorUserId, action, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			user, err := scanUser(tx.QueryRowContext(ctx, query, model.UserId, model.IsActive, helper.GetActorId(ctx)))
+			if err != nil || model.IsActive {
+				return user, err
+			}
+
+			_, err = tx.ExecContext(ctx, `update users_refresh_tokens set revoked_at = current_timestamp where user_id = $1 and revoked_at is null`, model.UserId)
+			return user, err
+		},
+	)
+}
+
+// SetUserProgrammatic
+// Marks the user as programmatic or interactive and records it in the audit trail within a single transaction.
+// Returns sql.ErrNoRows if user is not found.
+func (d *AuthDb) SetUserProgrammatic(ctx context.Context, ch chan *UserResponse, model *SetUserProgrammaticModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	action := UserAuditActionSetProgrammatic
+	if !model.IsProgrammatic {
+		action = UserAuditActionUnsetProgrammatic
+	}
+
+	query := `update users set is_programmatic = $2, updated_at = current_timestamp, updated_by = $3 where id = $1 returning ` + userColumns
+
+	ch <- d.auditUser(
+		ctx, model.UserId, model.ActorUserId, action, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			return scanUser(tx.QueryRowContext(ctx, query, model.UserId, model.IsProgrammatic, helper.GetActorId(ctx)))
+		},
+	)
+}
+
+// ForcePasswordReset
+// Adds a single use password reset token which expires in a day, revokes every refresh token of the user
+// and records it in the audit trail within a single transaction. Previous reset tokens are marked as used.
+// Returns sql.ErrNoRows if user is not found.
+func (d *AuthDb) ForcePasswordReset(ctx context.Context, ch chan *UserResponse, model *ForcePasswordResetModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	ch <- d.auditUser(
+		ctx, model.UserId, model.ActorUserId, UserAuditActionForcePasswordReset, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			query := `update users_password_reset_tokens set used_date = current_timestamp where user_id = $1 and used_date is null`
+			_, err := tx.ExecContext(ctx, query, model.UserId)
+			if err != nil {
+				return nil, err
+			}
+
+			query = `insert into users_password_reset_tokens (user_id, token_hash, expiry_date) values ($1, $2, current_timestamp + interval '1' day)`
+			_, err = tx.ExecContext(ctx, query, model.UserId, model.TokenHash)
+			if err != nil {
+				return nil, err
+			}
+
+			query = `update users_refresh_tokens set revoked_at = current_timestamp where user_id = $1 and revoked_at is null`
+			_, err = tx.ExecContext(ctx, query, model.UserId)
+			if err != nil {
+				return nil, err
+			}
+
+			return scanUser(tx.QueryRowContext(ctx, `select `+userColumns+` from users where id = $1`, model.UserId))
+		},
+	)
+}
+
+// DeleteUser
+// Soft deletes the user, deletes its tokens and records it in the audit trail within a single transaction.
+// Mfa, identities and api keys are kept for restore, but cannot be used while the user is deleted.
+// Returns sql.ErrNoRows if user is not found or already deleted.
+func (d *AuthDb) DeleteUser(ctx context.Context, ch chan *DeleteUserResponse, model *DeleteUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &DeleteUserResponse{Error: modelErr}
+		return
+	}
+
+	queries := []string{
+		`delete from users_refresh_tokens where user_id = $1`,
+		`delete from users_activation_tokens where user_id = $1`,
+		`delete from users_password_reset_tokens where user_id = $1`,
+	}
+
+	response := d.auditUser(
+		ctx, model.UserId, model.ActorUserId, UserAuditActionDelete, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			for _, query := range queries {
+				_, err := tx.ExecContext(ctx, query, model.UserId)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			err := database.SoftDelete(ctx, tx, "users", model.UserId)
+			if err != nil {
+				return nil, err
+			}
+
+			return scanUser(tx.QueryRowContext(ctx, `select `+userColumns+` from users where id = $1`, model.UserId))
+		},
+	)
+
+	ch <- &DeleteUserResponse{Error: response.Error}
+}
+
+// RestoreUser
+// Restores the soft deleted user and records it in the audit trail within a single transaction.
+// Returns sql.ErrNoRows if user is not found or not deleted.
+func (d *AuthDb) RestoreUser(ctx context.Context, ch chan *UserResponse, model *RestoreUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserResponse{Error: modelErr}
+		return
+	}
+
+	ch <- d.auditUser(
+		ctx, model.UserId, model.ActorUserId, UserAuditActionRestore, func(ctx context.Context, tx *sql.Tx) (*User, error) {
+			err := database.Restore(ctx, tx, "users", model.UserId)
+			if err != nil {
+				return nil, err
+			}
+
+			return scanUser(tx.QueryRowContext(ctx, `select `+userColumns+` from users where id = $1`, model.UserId))
+		},
+	)
+}
+
+// GetUserAuditEvents
+// Gets audit events of the user, newest first.
+func (d *AuthDb) GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsResponse, model *GetUserAuditEventsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUserAuditEventsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select id, user_id, coalesce(actor_user_id, 0), action, before, after, created_date
+	from users_audit_events
+	where user_id = $1
+	order by id desc
+	limit $2`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.UserId, model.Limit)
+	if err != nil {
+		ch <- &GetUserAuditEventsResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	events := []UserAuditEvent{}
+	for rows.Next() {
+		var event UserAuditEvent
+		var before, after []byte
+
+		err = rows.Scan(&event.Id, &event.UserId, &event.ActorUserId, &event.Action, &before, &after, &event.CreatedDate)
+		if err == nil && before != nil {
+			err = json.Unmarshal(before, &event.Before)
+		}
+		if err == nil && after != nil {
+			err = json.Unmarshal(after, &event.After)
+		}
+		if err != nil {
+			ch <- &GetUserAuditEventsResponse{Error: err}
+			return
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetUserAuditEventsResponse{Error: err}
+		return
+	}
+
+	ch <- &GetUserAuditEventsResponse{Events: events}
+}
+
+// auditUser
+// Runs mutate within a transaction and records the user before and after it in the audit trail.
+// User is locked first unless it is created, so concurrent changes are audited in order. Only deleted users are
+// restored, others are only changed if they are not deleted.
+func (d *AuthDb) auditUser(ctx context.Context, userId int64, actorUserId int64, action string, mutate func(ctx context.Context, tx *sql.Tx) (*User, error)) *UserResponse {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+	defer tx.Rollback()
+
+	condition := database.NotDeleted
+	if action == UserAuditActionRestore {
+		condition = `deleted_at is not null`
+	}
+
+	var before *User
+	if userId != 0 {
+		before, err = scanUser(tx.QueryRowContext(ctx, `select `+userColumns+` from users where id = $1 and `+condition+` for update`, userId))
+		if err != nil {
+			return &UserResponse{Error: err}
+		}
+	}
+
+	after, err := mutate(ctx, tx)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return &UserResponse{Error: ErrUserExists}
+	}
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+
+	if after != nil {
+		userId = after.Id
+	}
+
+	beforeJson, err := marshalUser(before)
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+
+	afterJson, err := marshalUser(after)
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+
+	query := `insert into users_audit_events (user_id, actor_user_id, action, before, after) values ($1, nullif($2, 0), $3, $4, $5)`
+	_, err = tx.ExecContext(ctx, query, userId, actorUserId, action, beforeJson, afterJson)
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return &UserResponse{Error: err}
+	}
+
+	response := UserResponse{}
+	if after != nil {
+		response.User = *after
+	}
+
+	return &response
+}
+
+// scanUser
+// Scans a row selected with userColumns, followed by extra destinations if any.
+func scanUser(row rowScanner, extra ...interface{}) (*User, error) {
+	var user User
+	dest := []interface{}{&user.Id, &user.UserName, &user.Email, &user.IsActive, &user.IsProgrammatic, &user.HasPassword}
+	dest = append(dest, user.Audit.Dest()...)
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// userValues
+// Returns values of the sortable fields of UserQuerySchema.
+func userValues(user User) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        user.Id,
+		"userName":  user.UserName,
+		"createdAt": user.CreatedAt,
+	}
+}
+
+// marshalUser
+// Returns user as json for jsonb columns, nil if there is no user.
+func marshalUser(user *User) ([]byte, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(user)
 }
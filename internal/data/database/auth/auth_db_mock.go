@@ -5,11 +5,53 @@
 package auth
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
+
 // MockIAuthDb is a mock of IAuthDb interface.
 type MockIAuthDb struct {
 	ctrl     *gomock.Controller
@@ -33,38 +75,326 @@ func (m *MockIAuthDb) EXPECT() *MockIAuthDbMockRecorder {
 	return m.recorder
 }
 
+// ActivateUser mocks base method.
+func (m *MockIAuthDb) ActivateUser(ctx context.Context, ch chan *ActivateUserResponse, model *ActivateUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ActivateUser", ctx, ch, model)
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockIAuthDbMockRecorder) ActivateUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockIAuthDb)(nil).ActivateUser), ctx, ch, model)
+}
+
+// AddActivationToken mocks base method.
+func (m *MockIAuthDb) AddActivationToken(ctx context.Context, ch chan *AddActivationTokenResponse, model *AddActivationTokenModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddActivationToken", ctx, ch, model)
+}
+
+// AddActivationToken indicates an expected call of AddActivationToken.
+func (mr *MockIAuthDbMockRecorder) AddActivationToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActivationToken", reflect.TypeOf((*MockIAuthDb)(nil).AddActivationToken), ctx, ch, model)
+}
+
+// AddPasswordResetToken mocks base method.
+func (m *MockIAuthDb) AddPasswordResetToken(ctx context.Context, ch chan *AddPasswordResetTokenResponse, model *AddPasswordResetTokenModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddPasswordResetToken", ctx, ch, model)
+}
+
+// AddPasswordResetToken indicates an expected call of AddPasswordResetToken.
+func (mr *MockIAuthDbMockRecorder) AddPasswordResetToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPasswordResetToken", reflect.TypeOf((*MockIAuthDb)(nil).AddPasswordResetToken), ctx, ch, model)
+}
+
 // AddRefreshToken mocks base method.
-func (m *MockIAuthDb) AddRefreshToken(ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel) {
+func (m *MockIAuthDb) AddRefreshToken(ctx context.Context, ch chan *AddRefreshTokenResponse, model *AddRefreshTokenModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddRefreshToken", ch, model)
+	m.ctrl.Call(m, "AddRefreshToken", ctx, ch, model)
 }
 
 // AddRefreshToken indicates an expected call of AddRefreshToken.
-func (mr *MockIAuthDbMockRecorder) AddRefreshToken(ch, model interface{}) *gomock.Call {
+func (mr *MockIAuthDbMockRecorder) AddRefreshToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).AddRefreshToken), ctx, ch, model)
+}
+
+// AddUser mocks base method.
+func (m *MockIAuthDb) AddUser(ctx context.Context, ch chan *AddUserResponse, model *AddUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUser", ctx, ch, model)
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockIAuthDbMockRecorder) AddUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockIAuthDb)(nil).AddUser), ctx, ch, model)
+}
+
+// CheckUserExists mocks base method.
+func (m *MockIAuthDb) CheckUserExists(ctx context.Context, ch chan *CheckUserExistsResponse, model *CheckUserExistsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CheckUserExists", ctx, ch, model)
+}
+
+// CheckUserExists indicates an expected call of CheckUserExists.
+func (mr *MockIAuthDbMockRecorder) CheckUserExists(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExists", reflect.TypeOf((*MockIAuthDb)(nil).CheckUserExists), ctx, ch, model)
+}
+
+// CreateUser mocks base method.
+func (m *MockIAuthDb) CreateUser(ctx context.Context, ch chan *UserResponse, model *CreateUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateUser", ctx, ch, model)
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIAuthDbMockRecorder) CreateUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIAuthDb)(nil).CreateUser), ctx, ch, model)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIAuthDb) DeleteUser(ctx context.Context, ch chan *DeleteUserResponse, model *DeleteUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", ctx, ch, model)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIAuthDbMockRecorder) DeleteUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIAuthDb)(nil).DeleteUser), ctx, ch, model)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockIAuthDb) ForcePasswordReset(ctx context.Context, ch chan *UserResponse, model *ForcePasswordResetModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForcePasswordReset", ctx, ch, model)
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockIAuthDbMockRecorder) ForcePasswordReset(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockIAuthDb)(nil).ForcePasswordReset), ctx, ch, model)
+}
+
+// GetSessions mocks base method.
+func (m *MockIAuthDb) GetSessions(ctx context.Context, ch chan *GetSessionsResponse, model *GetSessionsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSessions", ctx, ch, model)
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockIAuthDbMockRecorder) GetSessions(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockIAuthDb)(nil).GetSessions), ctx, ch, model)
+}
+
+// GetUserAuditEvents mocks base method.
+func (m *MockIAuthDb) GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsResponse, model *GetUserAuditEventsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserAuditEvents", ctx, ch, model)
+}
+
+// GetUserAuditEvents indicates an expected call of GetUserAuditEvents.
+func (mr *MockIAuthDbMockRecorder) GetUserAuditEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAuditEvents", reflect.TypeOf((*MockIAuthDb)(nil).GetUserAuditEvents), ctx, ch, model)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockIAuthDb) GetUserByEmail(ctx context.Context, ch chan *GetUserByEmailResponse, model *GetUserByEmailModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserByEmail", ctx, ch, model)
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockIAuthDbMockRecorder) GetUserByEmail(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).AddRefreshToken), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockIAuthDb)(nil).GetUserByEmail), ctx, ch, model)
 }
 
-// GetUserByPassword mocks base method.
-func (m *MockIAuthDb) GetUserByPassword(ch chan *GetUserByPasswordResponse, model *GetUserByPasswordModel) {
+// GetUserById mocks base method.
+func (m *MockIAuthDb) GetUserById(ctx context.Context, ch chan *UserResponse, model *GetUserByIdModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetUserByPassword", ch, model)
+	m.ctrl.Call(m, "GetUserById", ctx, ch, model)
 }
 
-// GetUserByPassword indicates an expected call of GetUserByPassword.
-func (mr *MockIAuthDbMockRecorder) GetUserByPassword(ch, model interface{}) *gomock.Call {
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockIAuthDbMockRecorder) GetUserById(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPassword", reflect.TypeOf((*MockIAuthDb)(nil).GetUserByPassword), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockIAuthDb)(nil).GetUserById), ctx, ch, model)
 }
 
 // GetUserByRefreshToken mocks base method.
-func (m *MockIAuthDb) GetUserByRefreshToken(ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel) {
+func (m *MockIAuthDb) GetUserByRefreshToken(ctx context.Context, ch chan *GetUserByRefreshTokenResponse, model *GetUserByRefreshTokenModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetUserByRefreshToken", ch, model)
+	m.ctrl.Call(m, "GetUserByRefreshToken", ctx, ch, model)
 }
 
 // GetUserByRefreshToken indicates an expected call of GetUserByRefreshToken.
-func (mr *MockIAuthDbMockRecorder) GetUserByRefreshToken(ch, model interface{}) *gomock.Call {
+func (mr *MockIAuthDbMockRecorder) GetUserByRefreshToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).GetUserByRefreshToken), ctx, ch, model)
+}
+
+// GetUserByUserName mocks base method.
+func (m *MockIAuthDb) GetUserByUserName(ctx context.Context, ch chan *GetUserByUserNameResponse, model *GetUserByUserNameModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserByUserName", ctx, ch, model)
+}
+
+// GetUserByUserName indicates an expected call of GetUserByUserName.
+func (mr *MockIAuthDbMockRecorder) GetUserByUserName(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUserName", reflect.TypeOf((*MockIAuthDb)(nil).GetUserByUserName), ctx, ch, model)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockIAuthDb) GetUserPermissions(ctx context.Context, ch chan *GetUserPermissionsResponse, model *GetUserPermissionsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserPermissions", ctx, ch, model)
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockIAuthDbMockRecorder) GetUserPermissions(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockIAuthDb)(nil).GetUserPermissions), ctx, ch, model)
+}
+
+// GetUsers mocks base method.
+func (m *MockIAuthDb) GetUsers(ctx context.Context, ch chan *GetUsersResponse, model *GetUsersModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUsers", ctx, ch, model)
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIAuthDbMockRecorder) GetUsers(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIAuthDb)(nil).GetUsers), ctx, ch, model)
+}
+
+// ResetPassword mocks base method.
+func (m *MockIAuthDb) ResetPassword(ctx context.Context, ch chan *ResetPasswordResponse, model *ResetPasswordModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetPassword", ctx, ch, model)
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIAuthDbMockRecorder) ResetPassword(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIAuthDb)(nil).ResetPassword), ctx, ch, model)
+}
+
+// RestoreUser mocks base method.
+func (m *MockIAuthDb) RestoreUser(ctx context.Context, ch chan *UserResponse, model *RestoreUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreUser", ctx, ch, model)
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockIAuthDbMockRecorder) RestoreUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockIAuthDb)(nil).RestoreUser), ctx, ch, model)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockIAuthDb) RevokeRefreshToken(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeRefreshTokenModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeRefreshToken", ctx, ch, model)
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockIAuthDbMockRecorder) RevokeRefreshToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).RevokeRefreshToken), ctx, ch, model)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIAuthDb) RevokeSession(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeSessionModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeSession", ctx, ch, model)
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIAuthDbMockRecorder) RevokeSession(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIAuthDb)(nil).RevokeSession), ctx, ch, model)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *MockIAuthDb) RevokeUserRefreshTokens(ctx context.Context, ch chan *RevokeRefreshTokenResponse, model *RevokeUserRefreshTokensModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, ch, model)
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockIAuthDbMockRecorder) RevokeUserRefreshTokens(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockIAuthDb)(nil).RevokeUserRefreshTokens), ctx, ch, model)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockIAuthDb) RotateRefreshToken(ctx context.Context, ch chan *RotateRefreshTokenResponse, model *RotateRefreshTokenModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RotateRefreshToken", ctx, ch, model)
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockIAuthDbMockRecorder) RotateRefreshToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).RotateRefreshToken), ctx, ch, model)
+}
+
+// SetUserActive mocks base method.
+func (m *MockIAuthDb) SetUserActive(ctx context.Context, ch chan *UserResponse, model *SetUserActiveModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserActive", ctx, ch, model)
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockIAuthDbMockRecorder) SetUserActive(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockIAuthDb)(nil).SetUserActive), ctx, ch, model)
+}
+
+// SetUserProgrammatic mocks base method.
+func (m *MockIAuthDb) SetUserProgrammatic(ctx context.Context, ch chan *UserResponse, model *SetUserProgrammaticModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserProgrammatic", ctx, ch, model)
+}
+
+// SetUserProgrammatic indicates an expected call of SetUserProgrammatic.
+func (mr *MockIAuthDbMockRecorder) SetUserProgrammatic(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserProgrammatic", reflect.TypeOf((*MockIAuthDb)(nil).SetUserProgrammatic), ctx, ch, model)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockIAuthDb) UpdatePasswordHash(ctx context.Context, ch chan *UpdatePasswordHashResponse, model *UpdatePasswordHashModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdatePasswordHash", ctx, ch, model)
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockIAuthDbMockRecorder) UpdatePasswordHash(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockIAuthDb)(nil).UpdatePasswordHash), ctx, ch, model)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIAuthDb) UpdateUser(ctx context.Context, ch chan *UserResponse, model *UpdateUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUser", ctx, ch, model)
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIAuthDbMockRecorder) UpdateUser(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByRefreshToken", reflect.TypeOf((*MockIAuthDb)(nil).GetUserByRefreshToken), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIAuthDb)(nil).UpdateUser), ctx, ch, model)
 }
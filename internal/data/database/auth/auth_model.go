@@ -1,15 +1,154 @@
 package auth
 
-type GetUserByPasswordModel struct {
-	UserName     string `validate:"required"`
-	PasswordHash string `validate:"required"`
+import (
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/query"
+)
+
+type GetUserByUserNameModel struct {
+	UserName string `validate:"required"`
 }
 
 type GetUserByRefreshTokenModel struct {
-	RefreshToken string `validate:"required"`
+	TokenHash string `validate:"required"`
 }
 
 type AddRefreshTokenModel struct {
-	UserId       int    `validate:"required"`
-	RefreshToken string `validate:"required"`
+	UserId      int    `validate:"required"`
+	TokenHash   string `validate:"required"`
+	FamilyId    string `validate:"required"`
+	UserAgent   string `validate:"max=500"`
+	ClientIp    string `validate:"max=45"`
+	Transaction database.ITransaction
+}
+
+type UpdatePasswordHashModel struct {
+	UserId          int64  `validate:"required"`
+	PasswordHash    string `validate:"required"`
+	OldPasswordHash string `validate:"required"`
+}
+
+type CheckUserExistsModel struct {
+	UserName string `validate:"required"`
+	Email    string `validate:"required"`
+}
+
+type AddUserModel struct {
+	UserName       string `validate:"required"`
+	Email          string `validate:"required"`
+	PasswordHash   string `validate:"required"`
+	IsActive       bool
+	IsProgrammatic bool
+	Transaction    database.ITransaction
+}
+
+type AddActivationTokenModel struct {
+	UserId      int64  `validate:"required"`
+	TokenHash   string `validate:"required"`
+	Transaction database.ITransaction
+}
+
+type ActivateUserModel struct {
+	TokenHash string `validate:"required"`
+}
+
+type RotateRefreshTokenModel struct {
+	TokenHash    string `validate:"required"`
+	NewTokenHash string `validate:"required"`
+	UserAgent    string `validate:"max=500"`
+	ClientIp     string `validate:"max=45"`
+}
+
+type RevokeRefreshTokenModel struct {
+	UserId    int64  `validate:"required"`
+	TokenHash string `validate:"required"`
+}
+
+type RevokeUserRefreshTokensModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type GetSessionsModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type RevokeSessionModel struct {
+	UserId    int64  `validate:"required"`
+	SessionId string `validate:"required,uuid"`
+}
+
+type GetUserPermissionsModel struct {
+	UserId      int64 `validate:"required"`
+	Transaction database.ITransaction
+}
+
+type GetUserByEmailModel struct {
+	Email string `validate:"required"`
+}
+
+type AddPasswordResetTokenModel struct {
+	UserId    int64  `validate:"required"`
+	TokenHash string `validate:"required"`
+}
+
+type ResetPasswordModel struct {
+	TokenHash    string `validate:"required"`
+	PasswordHash string `validate:"required"`
+}
+
+type GetUsersModel struct {
+	Query *query.Query `validate:"required"`
+}
+
+type GetUserByIdModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type CreateUserModel struct {
+	UserName       string `validate:"required"`
+	Email          string
+	PasswordHash   string
+	IsActive       bool
+	IsProgrammatic bool
+	ActorUserId    int64 // 0 for users created by the system, e.g. from command line
+}
+
+type UpdateUserModel struct {
+	UserId      int64  `validate:"required"`
+	UserName    string `validate:"required"`
+	Email       string
+	ActorUserId int64 `validate:"required"`
+}
+
+type SetUserActiveModel struct {
+	UserId      int64 `validate:"required"`
+	IsActive    bool
+	ActorUserId int64 `validate:"required"`
+}
+
+type SetUserProgrammaticModel struct {
+	UserId         int64 `validate:"required"`
+	IsProgrammatic bool
+	ActorUserId    int64 `validate:"required"`
+}
+
+type ForcePasswordResetModel struct {
+	UserId      int64  `validate:"required"`
+	TokenHash   string `validate:"required"`
+	ActorUserId int64  `validate:"required"`
+}
+
+type DeleteUserModel struct {
+	UserId      int64 `validate:"required"`
+	ActorUserId int64 `validate:"required"`
+}
+
+type RestoreUserModel struct {
+	UserId      int64 `validate:"required"`
+	ActorUserId int64 `validate:"required"`
+}
+
+type GetUserAuditEventsModel struct {
+	UserId int64 `validate:"required"`
+	Limit  int   `validate:"required,gte=1,lte=500"`
 }
@@ -1,10 +1,18 @@
 package auth
 
-type GetUserByPasswordResponse struct {
+import (
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/query"
+)
+
+type GetUserByUserNameResponse struct {
 	Error          error `json:"-"`
 	Id             int64
 	UserName       string
 	Email          string
+	PasswordHash   string `json:"-"`
 	IsActive       bool
 	IsProgrammatic bool
 }
@@ -21,3 +29,123 @@ type GetUserByRefreshTokenResponse struct {
 type AddRefreshTokenResponse struct {
 	Error error `json:"-"`
 }
+
+type UpdatePasswordHashResponse struct {
+	Error     error `json:"-"`
+	IsUpdated bool
+}
+
+type CheckUserExistsResponse struct {
+	Error          error `json:"-"`
+	UserNameExists bool
+	EmailExists    bool
+}
+
+type AddUserResponse struct {
+	Error error `json:"-"`
+	Id    int64
+}
+
+type AddActivationTokenResponse struct {
+	Error error `json:"-"`
+}
+
+type ActivateUserResponse struct {
+	Error  error `json:"-"`
+	UserId int64
+}
+
+type RotateRefreshTokenResponse struct {
+	Error          error `json:"-"`
+	Id             int64
+	UserName       string
+	Email          string
+	IsActive       bool
+	IsProgrammatic bool
+	FamilyId       string
+}
+
+type RevokeRefreshTokenResponse struct {
+	Error        error `json:"-"`
+	RevokedCount int64
+}
+
+type GetSessionsResponse struct {
+	Error    error `json:"-"`
+	Sessions []Session
+}
+
+type Session struct {
+	Id           string
+	UserAgent    string
+	ClientIp     string
+	CreatedDate  time.Time
+	LastUsedDate time.Time
+	ExpiryDate   time.Time
+}
+
+type GetUserPermissionsResponse struct {
+	Error       error `json:"-"`
+	Roles       []string
+	Permissions []string
+}
+
+type GetUserByEmailResponse struct {
+	Error          error `json:"-"`
+	Id             int64
+	UserName       string
+	Email          string
+	IsActive       bool
+	IsProgrammatic bool
+	HasPassword    bool
+}
+
+type AddPasswordResetTokenResponse struct {
+	Error error `json:"-"`
+}
+
+type ResetPasswordResponse struct {
+	Error    error `json:"-"`
+	UserId   int64
+	UserName string
+}
+
+type User struct {
+	Id             int64
+	UserName       string
+	Email          string
+	IsActive       bool
+	IsProgrammatic bool
+	HasPassword    bool
+	database.Audit
+}
+
+type GetUsersResponse struct {
+	Error error `json:"-"`
+	Users []User
+	Page  query.Page
+}
+
+type UserResponse struct {
+	Error error `json:"-"`
+	User  User
+}
+
+type DeleteUserResponse struct {
+	Error error `json:"-"`
+}
+
+type GetUserAuditEventsResponse struct {
+	Error  error `json:"-"`
+	Events []UserAuditEvent
+}
+
+type UserAuditEvent struct {
+	Id          int64
+	UserId      int64
+	ActorUserId int64
+	Action      string
+	Before      *User
+	After       *User
+	CreatedDate time.Time
+}
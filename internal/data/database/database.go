@@ -0,0 +1,76 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-clean-architecture/internal/util/env"
+
+	_ "github.com/lib/pq"
+)
+
+// Default pool settings, used when the environment variable is not set.
+const (
+	defaultMaxOpenConnections    = 25
+	defaultMaxIdleConnections    = 25
+	defaultConnectionMaxLifetime = 30 * time.Minute
+	defaultConnectionMaxIdleTime = 5 * time.Minute
+)
+
+var (
+	pool     *sql.DB
+	poolOnce sync.Once
+)
+
+// Open
+// Returns the process-wide postgresql connection pool, creating it on first call.
+// Panics if pool settings in environment are invalid.
+func Open(environment env.IEnvironment) *sql.DB {
+	poolOnce.Do(func() {
+		pool = New(environment)
+	})
+
+	return pool
+}
+
+// New
+// Returns a new postgresql connection pool configured from environment.
+// Connections are opened lazily, so no connection is made until the pool is used.
+func New(environment env.IEnvironment) *sql.DB {
+	db, err := sql.Open("postgres", environment.Get(env.PostgresqlConnectionString))
+	if err != nil {
+		panic(err)
+	}
+
+	db.SetMaxOpenConns(getInt(environment, env.PostgresqlMaxOpenConnections, defaultMaxOpenConnections))
+	db.SetMaxIdleConns(getInt(environment, env.PostgresqlMaxIdleConnections, defaultMaxIdleConnections))
+	db.SetConnMaxLifetime(getSeconds(environment, env.PostgresqlConnectionMaxLifetime, defaultConnectionMaxLifetime))
+	db.SetConnMaxIdleTime(getSeconds(environment, env.PostgresqlConnectionMaxIdleTime, defaultConnectionMaxIdleTime))
+
+	return db
+}
+
+// getInt
+// Returns environment variable as int, or defaultValue if it is not set.
+func getInt(environment env.IEnvironment, key string, defaultValue int) int {
+	value := environment.Get(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		panic(fmt.Sprintf("Couldn't convert %s environment variable to a non-negative int !", key))
+	}
+
+	return number
+}
+
+// getSeconds
+// Returns environment variable given in seconds as time.Duration, or defaultValue if it is not set.
+func getSeconds(environment env.IEnvironment, key string, defaultValue time.Duration) time.Duration {
+	return time.Second * time.Duration(getInt(environment, key, int(defaultValue/time.Second)))
+}
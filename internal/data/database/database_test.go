@@ -0,0 +1,64 @@
+package database
+
+import (
+	"testing"
+
+	"go-clean-architecture/internal/util/env"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseTestSuite struct {
+	suite.Suite
+	mockEnvironment *env.MockIEnvironment
+}
+
+// Run suite.
+func TestDatabase(t *testing.T) {
+	suite.Run(t, new(DatabaseTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *DatabaseTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlConnectionString).Return("host=localhost sslmode=disable").AnyTimes()
+}
+
+func (s *DatabaseTestSuite) TestNew_PoolSettingsSet_ConfiguresPool() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlMaxOpenConnections).Return("10")
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlMaxIdleConnections).Return("5")
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlConnectionMaxLifetime).Return("600")
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlConnectionMaxIdleTime).Return("60")
+
+	// When
+	pool := New(s.mockEnvironment)
+	defer pool.Close()
+
+	// Then
+	s.Equal(10, pool.Stats().MaxOpenConnections)
+}
+
+func (s *DatabaseTestSuite) TestNew_PoolSettingsNotSet_UsesDefaults() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(gomock.Any()).Return("").AnyTimes()
+
+	// When
+	pool := New(s.mockEnvironment)
+	defer pool.Close()
+
+	// Then
+	s.Equal(defaultMaxOpenConnections, pool.Stats().MaxOpenConnections)
+}
+
+func (s *DatabaseTestSuite) TestNew_InvalidPoolSetting_Panics() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.PostgresqlMaxOpenConnections).Return("many")
+
+	// When & Then
+	s.Panics(func() { New(s.mockEnvironment) })
+}
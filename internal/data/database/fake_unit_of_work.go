@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/lib/pq"
+)
+
+// FakeUnitOfWork
+// Runs units of work without a db for tests in which db types are mocked. Mocks ignore the transaction in models.
+// Set SerializationFailures to fail that many commits with a serialization failure, so units of work are run again.
+type FakeUnitOfWork struct {
+	SerializationFailures int
+	Attempts              int
+	Commits               int
+	Rollbacks             int
+	Savepoints            int
+	SavepointRollbacks    int
+	mutex                 sync.Mutex
+}
+
+// NewFakeUnitOfWork
+// Returns a new FakeUnitOfWork.
+func NewFakeUnitOfWork() *FakeUnitOfWork {
+	return &FakeUnitOfWork{}
+}
+
+func (u *FakeUnitOfWork) Run(ctx context.Context, fn func(tx ITransaction) error) error {
+	return retry(func() error {
+		return u.run(fn)
+	})
+}
+
+func (u *FakeUnitOfWork) RunSerializable(ctx context.Context, fn func(tx ITransaction) error) error {
+	return u.Run(ctx, fn)
+}
+
+func (u *FakeUnitOfWork) run(fn func(tx ITransaction) error) error {
+	u.count(&u.Attempts)
+
+	err := fn(&fakeTransaction{unitOfWork: u})
+	if err != nil {
+		u.count(&u.Rollbacks)
+		return err
+	}
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.SerializationFailures > 0 {
+		u.SerializationFailures--
+		u.Rollbacks++
+		return &pq.Error{Code: serializationFailure, Message: "could not serialize access due to concurrent update"}
+	}
+
+	u.Commits++
+	return nil
+}
+
+func (u *FakeUnitOfWork) count(counter *int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	*counter++
+}
+
+type fakeTransaction struct {
+	unitOfWork *FakeUnitOfWork
+}
+
+func (t *fakeTransaction) Savepoint(fn func(tx ITransaction) error) error {
+	t.unitOfWork.count(&t.unitOfWork.Savepoints)
+
+	err := fn(t)
+	if err != nil {
+		t.unitOfWork.count(&t.unitOfWork.SavepointRollbacks)
+	}
+
+	return err
+}
+
+func (t *fakeTransaction) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	panic("FakeUnitOfWork cannot run queries, db types must be mocked.")
+}
+
+func (t *fakeTransaction) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	panic("FakeUnitOfWork cannot run queries, db types must be mocked.")
+}
+
+func (t *fakeTransaction) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	panic("FakeUnitOfWork cannot run queries, db types must be mocked.")
+}
@@ -1,48 +1,62 @@
 package health
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
-	_ "github.com/lib/pq"
+	"go-clean-architecture/internal/data/database"
 	"go-clean-architecture/internal/util/env"
 )
 
 type IHealthDb interface {
-	Ping() error
+	Ping(ctx context.Context) error
+	Stats() *PoolStatsResponse
 }
 
 type HealthDb struct {
-	environment      env.IEnvironment
-	connectionString string
-	driverName       string
-	timeout          time.Duration
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
 }
 
 // Returns a new HealthDb.
-func NewHealthDb(environment env.IEnvironment) IHealthDb {
+func NewHealthDb(environment env.IEnvironment, pool *sql.DB) IHealthDb {
 	db := HealthDb{
-		environment:      environment,
-		driverName:       "postgres",
-		connectionString: environment.Get(env.PostgresqlConnectionString),
-		timeout:          time.Second * 5,
+		environment: environment,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
 	}
 
 	return &db
 }
 
-func (d *HealthDb) Ping() error {
+func (d *HealthDb) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
-	if err != nil {
-		return err
-	}
-	defer connection.Close()
+	return d.pool.PingContext(ctx)
+}
 
-	pingErr := connection.Ping()
-	if pingErr != nil {
-		return pingErr
+// Stats
+// Returns connection pool statistics.
+func (d *HealthDb) Stats() *PoolStatsResponse {
+	stats := d.pool.Stats()
+
+	return &PoolStatsResponse{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
 	}
-
-	return nil
 }
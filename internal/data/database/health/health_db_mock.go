@@ -5,6 +5,7 @@
 package health
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,15 +35,29 @@ func (m *MockIHealthDb) EXPECT() *MockIHealthDbMockRecorder {
 }
 
 // Ping mocks base method.
-func (m *MockIHealthDb) Ping() error {
+func (m *MockIHealthDb) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Ping")
+	ret := m.ctrl.Call(m, "Ping", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Ping indicates an expected call of Ping.
-func (mr *MockIHealthDbMockRecorder) Ping() *gomock.Call {
+func (mr *MockIHealthDbMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthDb)(nil).Ping))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthDb)(nil).Ping), ctx)
+}
+
+// Stats mocks base method.
+func (m *MockIHealthDb) Stats() *PoolStatsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(*PoolStatsResponse)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockIHealthDbMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockIHealthDb)(nil).Stats))
 }
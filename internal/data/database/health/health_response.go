@@ -3,3 +3,15 @@ package health
 type HealthCheckResponse struct {
 	Error error `json:"-"`
 }
+
+type PoolStatsResponse struct {
+	MaxOpenConnections int    `json:"MaxOpenConnections"`
+	OpenConnections    int    `json:"OpenConnections"`
+	InUse              int    `json:"InUse"`
+	Idle               int    `json:"Idle"`
+	WaitCount          int64  `json:"WaitCount"`
+	WaitDuration       string `json:"WaitDuration"`
+	MaxIdleClosed      int64  `json:"MaxIdleClosed"`
+	MaxIdleTimeClosed  int64  `json:"MaxIdleTimeClosed"`
+	MaxLifetimeClosed  int64  `json:"MaxLifetimeClosed"`
+}
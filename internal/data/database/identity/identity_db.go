@@ -0,0 +1,166 @@
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	_ "github.com/lib/pq"
+)
+
+type IIdentityDb interface {
+	ProvisionUser(ctx context.Context, ch chan *ProvisionUserResponse, model *ProvisionUserModel)
+}
+
+type IdentityDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewIdentityDb
+// Returns a new IdentityDb.
+func NewIdentityDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IIdentityDb {
+	db := IdentityDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// ProvisionUser
+// Gets the user linked to an external identity, adding an active user without a password on first login.
+// Users are only linked by issuer and subject, never by email, so an identity cannot take over an existing account.
+// Username gets a suffix derived from the identity if it is already taken. Deleted users are returned as inactive.
+func (d *IdentityDb) ProvisionUser(ctx context.Context, ch chan *ProvisionUserResponse, model *ProvisionUserModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ProvisionUserResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		ch <- &ProvisionUserResponse{Error: err}
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+	select u.id, u.username, coalesce(u.email, ''), u.is_active and u.deleted_at is null
+	from users_identities as i
+	inner join users as u on u.id = i.user_id
+	where i.issuer = $1 and i.subject = $2
+	for update of i`
+
+	response := ProvisionUserResponse{}
+	err = tx.QueryRowContext(ctx, query, model.Issuer, model.Subject).Scan(&response.UserId, &response.UserName, &response.Email, &response.IsActive)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		ch <- &ProvisionUserResponse{Error: err}
+		return
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		userName, err := d.availableUserName(ctx, tx, model)
+		if err != nil {
+			ch <- &ProvisionUserResponse{Error: err}
+			return
+		}
+
+		query = `
+		insert into users (username, email, password_hash, is_active, is_programmatic)
+		values ($1, nullif($2, ''), '', true, false)
+		returning id`
+
+		err = tx.QueryRowContext(ctx, query, userName, model.Email).Scan(&response.UserId)
+		if err != nil {
+			ch <- &ProvisionUserResponse{Error: err}
+			return
+		}
+
+		response = ProvisionUserResponse{
+			UserId:    response.UserId,
+			UserName:  userName,
+			Email:     model.Email,
+			IsActive:  true,
+			IsCreated: true,
+		}
+
+		query = `
+		insert into users_identities (user_id, issuer, subject, email, last_login_date)
+		values ($1, $2, $3, nullif($4, ''), current_timestamp)`
+
+		_, err = tx.ExecContext(ctx, query, response.UserId, model.Issuer, model.Subject, model.Email)
+		if err != nil {
+			ch <- &ProvisionUserResponse{Error: err}
+			return
+		}
+	} else {
+		query = `
+		update users_identities
+		set email = nullif($3, ''), last_login_date = current_timestamp
+		where issuer = $1 and subject = $2`
+
+		_, err = tx.ExecContext(ctx, query, model.Issuer, model.Subject, model.Email)
+		if err != nil {
+			ch <- &ProvisionUserResponse{Error: err}
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		ch <- &ProvisionUserResponse{Error: err}
+		return
+	}
+
+	ch <- &response
+}
+
+// availableUserName
+// Returns the requested username, or a shortened one with a suffix derived from the identity if it is taken.
+func (d *IdentityDb) availableUserName(ctx context.Context, tx *sql.Tx, model *ProvisionUserModel) (string, error) {
+	var isTaken bool
+	query := `select exists(select 1 from users where username = $1)`
+	err := tx.QueryRowContext(ctx, query, model.UserName).Scan(&isTaken)
+	if err != nil {
+		return "", err
+	}
+
+	if !isTaken {
+		return model.UserName, nil
+	}
+
+	hash := sha256.Sum256([]byte(model.Issuer + " " + model.Subject))
+	userName := model.UserName
+	if runes := []rune(userName); len(runes) > 23 {
+		userName = string(runes[:23])
+	}
+
+	return userName + "-" + hex.EncodeToString(hash[:3]), nil
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/identity/identity_db.go
+
+// Package identity is a generated GoMock package.
+package identity
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIIdentityDb is a mock of IIdentityDb interface.
+type MockIIdentityDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdentityDbMockRecorder
+}
+
+// MockIIdentityDbMockRecorder is the mock recorder for MockIIdentityDb.
+type MockIIdentityDbMockRecorder struct {
+	mock *MockIIdentityDb
+}
+
+// NewMockIIdentityDb creates a new mock instance.
+func NewMockIIdentityDb(ctrl *gomock.Controller) *MockIIdentityDb {
+	mock := &MockIIdentityDb{ctrl: ctrl}
+	mock.recorder = &MockIIdentityDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdentityDb) EXPECT() *MockIIdentityDbMockRecorder {
+	return m.recorder
+}
+
+// ProvisionUser mocks base method.
+func (m *MockIIdentityDb) ProvisionUser(ctx context.Context, ch chan *ProvisionUserResponse, model *ProvisionUserModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ProvisionUser", ctx, ch, model)
+}
+
+// ProvisionUser indicates an expected call of ProvisionUser.
+func (mr *MockIIdentityDbMockRecorder) ProvisionUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionUser", reflect.TypeOf((*MockIIdentityDb)(nil).ProvisionUser), ctx, ch, model)
+}
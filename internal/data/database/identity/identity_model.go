@@ -0,0 +1,8 @@
+package identity
+
+type ProvisionUserModel struct {
+	Issuer   string `validate:"required,max=255"`
+	Subject  string `validate:"required,max=255"`
+	UserName string `validate:"required,max=30"`
+	Email    string `validate:"omitempty,max=100"`
+}
@@ -0,0 +1,10 @@
+package identity
+
+type ProvisionUserResponse struct {
+	Error     error `json:"-"`
+	UserId    int64
+	UserName  string
+	Email     string
+	IsActive  bool
+	IsCreated bool
+}
@@ -0,0 +1,169 @@
+package lockout
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	_ "github.com/lib/pq"
+)
+
+type ILockoutDb interface {
+	AddLockoutEvent(ctx context.Context, ch chan *AddLockoutEventResponse, model *AddLockoutEventModel)
+	GetLockoutEvents(ctx context.Context, ch chan *GetLockoutEventsResponse, model *GetLockoutEventsModel)
+	ClearLockoutEvents(ctx context.Context, ch chan *ClearLockoutEventsResponse, model *ClearLockoutEventsModel)
+}
+
+type LockoutDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewLockoutDb
+// Returns a new LockoutDb.
+func NewLockoutDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) ILockoutDb {
+	db := LockoutDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// AddLockoutEvent
+// Adds a lockout event to postgresql db.
+func (d *LockoutDb) AddLockoutEvent(ctx context.Context, ch chan *AddLockoutEventResponse, model *AddLockoutEventModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddLockoutEventResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into auth_lockout_events (scope, username, client_ip, failed_attempts, locked_until) values ($1, $2, $3, $4, $5)`
+
+	_, err := d.pool.ExecContext(ctx, query, model.Scope, model.UserName, model.ClientIp, model.FailedAttempts, model.LockedUntil)
+	if err != nil {
+		ch <- &AddLockoutEventResponse{Error: err}
+		return
+	}
+
+	ch <- &AddLockoutEventResponse{}
+}
+
+// GetLockoutEvents
+// Gets latest lockout events from postgresql db, optionally filtered by username, client ip and activeness.
+func (d *LockoutDb) GetLockoutEvents(ctx context.Context, ch chan *GetLockoutEventsResponse, model *GetLockoutEventsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetLockoutEventsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select id, scope, username, client_ip, failed_attempts, locked_until, created_date, cleared_date, cleared_by
+	from auth_lockout_events
+	where ($1 = '' or username = $1)
+	and ($2 = '' or client_ip = $2)
+	and (not $3 or (cleared_date is null and locked_until > now()))
+	order by id desc
+	limit $4`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.UserName, model.ClientIp, model.OnlyActive, model.Limit)
+	if err != nil {
+		ch <- &GetLockoutEventsResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	events := []LockoutEvent{}
+	for rows.Next() {
+		var event LockoutEvent
+		var userName, clientIp sql.NullString
+		var clearedDate sql.NullTime
+		var clearedBy sql.NullInt64
+
+		err = rows.Scan(&event.Id, &event.Scope, &userName, &clientIp, &event.FailedAttempts, &event.LockedUntil, &event.CreatedDate, &clearedDate, &clearedBy)
+		if err != nil {
+			ch <- &GetLockoutEventsResponse{Error: err}
+			return
+		}
+
+		event.UserName = userName.String
+		event.ClientIp = clientIp.String
+		if clearedDate.Valid {
+			event.ClearedDate = &clearedDate.Time
+		}
+		if clearedBy.Valid {
+			event.ClearedBy = &clearedBy.Int64
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetLockoutEventsResponse{Error: err}
+		return
+	}
+
+	ch <- &GetLockoutEventsResponse{Events: events}
+}
+
+// ClearLockoutEvents
+// Marks active lockout events of given username or client ip as cleared.
+func (d *LockoutDb) ClearLockoutEvents(ctx context.Context, ch chan *ClearLockoutEventsResponse, model *ClearLockoutEventsModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ClearLockoutEventsResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	update auth_lockout_events
+	set cleared_date = current_timestamp, cleared_by = $3
+	where scope = $1
+	and (case when $1 = 'user' then username else client_ip end) = $2
+	and cleared_date is null
+	and locked_until > now()`
+
+	result, err := d.pool.ExecContext(ctx, query, model.Scope, model.Key, model.ClearedBy)
+	if err != nil {
+		ch <- &ClearLockoutEventsResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &ClearLockoutEventsResponse{Error: err}
+		return
+	}
+
+	ch <- &ClearLockoutEventsResponse{ClearedCount: rows}
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/lockout/lockout_db.go
+
+// Package lockout is a generated GoMock package.
+package lockout
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILockoutDb is a mock of ILockoutDb interface.
+type MockILockoutDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockoutDbMockRecorder
+}
+
+// MockILockoutDbMockRecorder is the mock recorder for MockILockoutDb.
+type MockILockoutDbMockRecorder struct {
+	mock *MockILockoutDb
+}
+
+// NewMockILockoutDb creates a new mock instance.
+func NewMockILockoutDb(ctrl *gomock.Controller) *MockILockoutDb {
+	mock := &MockILockoutDb{ctrl: ctrl}
+	mock.recorder = &MockILockoutDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILockoutDb) EXPECT() *MockILockoutDbMockRecorder {
+	return m.recorder
+}
+
+// AddLockoutEvent mocks base method.
+func (m *MockILockoutDb) AddLockoutEvent(ctx context.Context, ch chan *AddLockoutEventResponse, model *AddLockoutEventModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddLockoutEvent", ctx, ch, model)
+}
+
+// AddLockoutEvent indicates an expected call of AddLockoutEvent.
+func (mr *MockILockoutDbMockRecorder) AddLockoutEvent(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLockoutEvent", reflect.TypeOf((*MockILockoutDb)(nil).AddLockoutEvent), ctx, ch, model)
+}
+
+// ClearLockoutEvents mocks base method.
+func (m *MockILockoutDb) ClearLockoutEvents(ctx context.Context, ch chan *ClearLockoutEventsResponse, model *ClearLockoutEventsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearLockoutEvents", ctx, ch, model)
+}
+
+// ClearLockoutEvents indicates an expected call of ClearLockoutEvents.
+func (mr *MockILockoutDbMockRecorder) ClearLockoutEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLockoutEvents", reflect.TypeOf((*MockILockoutDb)(nil).ClearLockoutEvents), ctx, ch, model)
+}
+
+// GetLockoutEvents mocks base method.
+func (m *MockILockoutDb) GetLockoutEvents(ctx context.Context, ch chan *GetLockoutEventsResponse, model *GetLockoutEventsModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLockoutEvents", ctx, ch, model)
+}
+
+// GetLockoutEvents indicates an expected call of GetLockoutEvents.
+func (mr *MockILockoutDbMockRecorder) GetLockoutEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockoutEvents", reflect.TypeOf((*MockILockoutDb)(nil).GetLockoutEvents), ctx, ch, model)
+}
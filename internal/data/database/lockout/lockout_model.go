@@ -0,0 +1,24 @@
+package lockout
+
+import "time"
+
+type AddLockoutEventModel struct {
+	Scope          string    `validate:"required,oneof=user ip"`
+	UserName       string    `validate:"required"`
+	ClientIp       string    `validate:"required"`
+	FailedAttempts int64     `validate:"required"`
+	LockedUntil    time.Time `validate:"required"`
+}
+
+type GetLockoutEventsModel struct {
+	UserName   string
+	ClientIp   string
+	OnlyActive bool
+	Limit      int `validate:"required,gte=1,lte=500"`
+}
+
+type ClearLockoutEventsModel struct {
+	Scope     string `validate:"required,oneof=user ip"`
+	Key       string `validate:"required"`
+	ClearedBy int64  `validate:"required"`
+}
@@ -0,0 +1,29 @@
+package lockout
+
+import "time"
+
+type AddLockoutEventResponse struct {
+	Error error `json:"-"`
+}
+
+type GetLockoutEventsResponse struct {
+	Error  error `json:"-"`
+	Events []LockoutEvent
+}
+
+type LockoutEvent struct {
+	Id             int64
+	Scope          string
+	UserName       string
+	ClientIp       string
+	FailedAttempts int64
+	LockedUntil    time.Time
+	CreatedDate    time.Time
+	ClearedDate    *time.Time
+	ClearedBy      *int64
+}
+
+type ClearLockoutEventsResponse struct {
+	Error        error `json:"-"`
+	ClearedCount int64
+}
@@ -0,0 +1,259 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	_ "github.com/lib/pq"
+)
+
+// ErrMfaAlreadyEnabled
+// Returned when an enrollment is started for a user whose MFA is already enabled.
+var ErrMfaAlreadyEnabled = errors.New("mfa is already enabled")
+
+type IMfaDb interface {
+	AddMfaEnrollment(ctx context.Context, ch chan *AddMfaEnrollmentResponse, model *AddMfaEnrollmentModel)
+	GetMfa(ctx context.Context, ch chan *GetMfaResponse, model *GetMfaModel)
+	EnableMfa(ctx context.Context, ch chan *EnableMfaResponse, model *EnableMfaModel)
+	UseTimeStep(ctx context.Context, ch chan *UseTimeStepResponse, model *UseTimeStepModel)
+	UseRecoveryCode(ctx context.Context, ch chan *UseRecoveryCodeResponse, model *UseRecoveryCodeModel)
+}
+
+type MfaDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewMfaDb
+// Returns a new MfaDb.
+func NewMfaDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IMfaDb {
+	db := MfaDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// AddMfaEnrollment
+// Adds a pending MFA secret with its recovery codes, replacing a previous pending enrollment.
+// Returns ErrMfaAlreadyEnabled if MFA of the user is already enabled.
+func (d *MfaDb) AddMfaEnrollment(ctx context.Context, ch chan *AddMfaEnrollmentResponse, model *AddMfaEnrollmentModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.pool.BeginTx(ctx, nil)
+	if err != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: err}
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+	insert into users_mfa (user_id, secret) values ($1, $2)
+	on conflict (user_id) do update
+	set secret = excluded.secret, last_used_step = null, created_date = current_timestamp
+	where users_mfa.is_enabled = false`
+
+	result, err := tx.ExecContext(ctx, query, model.UserId, model.Secret)
+	if err != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: err}
+		return
+	}
+
+	if rows == 0 {
+		ch <- &AddMfaEnrollmentResponse{Error: ErrMfaAlreadyEnabled}
+		return
+	}
+
+	query = `delete from users_mfa_recovery_codes where user_id = $1`
+	_, err = tx.ExecContext(ctx, query, model.UserId)
+	if err != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: err}
+		return
+	}
+
+	query = `insert into users_mfa_recovery_codes (user_id, code_hash) values ($1, $2)`
+	for _, codeHash := range model.RecoveryCodeHashes {
+		_, err = tx.ExecContext(ctx, query, model.UserId, codeHash)
+		if err != nil {
+			ch <- &AddMfaEnrollmentResponse{Error: err}
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		ch <- &AddMfaEnrollmentResponse{Error: err}
+		return
+	}
+
+	ch <- &AddMfaEnrollmentResponse{}
+}
+
+// GetMfa
+// Gets MFA secret and status of the user from postgresql db.
+// Returns sql.ErrNoRows if user has never enrolled.
+func (d *MfaDb) GetMfa(ctx context.Context, ch chan *GetMfaResponse, model *GetMfaModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetMfaResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select secret, is_enabled from users_mfa where user_id = $1`
+
+	var response GetMfaResponse
+	dbErr := d.pool.QueryRowContext(ctx, query, model.UserId).Scan(&response.Secret, &response.IsEnabled)
+	if dbErr != nil {
+		ch <- &GetMfaResponse{Error: dbErr}
+		return
+	}
+
+	ch <- &response
+}
+
+// EnableMfa
+// Enables pending MFA of the user and marks the confirming time step as used.
+// Returns sql.ErrNoRows if there is no pending enrollment.
+func (d *MfaDb) EnableMfa(ctx context.Context, ch chan *EnableMfaResponse, model *EnableMfaModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &EnableMfaResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	update users_mfa
+	set is_enabled = true, enabled_date = current_timestamp, last_used_step = $2
+	where user_id = $1 and is_enabled = false`
+
+	result, err := d.pool.ExecContext(ctx, query, model.UserId, model.Step)
+	if err != nil {
+		ch <- &EnableMfaResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &EnableMfaResponse{Error: err}
+		return
+	}
+
+	if rows == 0 {
+		ch <- &EnableMfaResponse{Error: sql.ErrNoRows}
+		return
+	}
+
+	ch <- &EnableMfaResponse{}
+}
+
+// UseTimeStep
+// Marks a time step as used so its code cannot be replayed.
+// IsUsed is false if the same or a later time step is already used.
+func (d *MfaDb) UseTimeStep(ctx context.Context, ch chan *UseTimeStepResponse, model *UseTimeStepModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UseTimeStepResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	update users_mfa
+	set last_used_step = $2
+	where user_id = $1 and is_enabled = true and (last_used_step is null or last_used_step < $2)`
+
+	result, err := d.pool.ExecContext(ctx, query, model.UserId, model.Step)
+	if err != nil {
+		ch <- &UseTimeStepResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &UseTimeStepResponse{Error: err}
+		return
+	}
+
+	ch <- &UseTimeStepResponse{IsUsed: rows > 0}
+}
+
+// UseRecoveryCode
+// Marks an unused recovery code of the user as used.
+// IsUsed is false if the code does not exist or is already used.
+func (d *MfaDb) UseRecoveryCode(ctx context.Context, ch chan *UseRecoveryCodeResponse, model *UseRecoveryCodeModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UseRecoveryCodeResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	update users_mfa_recovery_codes
+	set used_date = current_timestamp
+	where id = (
+		select id from users_mfa_recovery_codes
+		where user_id = $1 and code_hash = $2 and used_date is null
+		limit 1
+		for update
+	)`
+
+	result, err := d.pool.ExecContext(ctx, query, model.UserId, model.CodeHash)
+	if err != nil {
+		ch <- &UseRecoveryCodeResponse{Error: err}
+		return
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		ch <- &UseRecoveryCodeResponse{Error: err}
+		return
+	}
+
+	ch <- &UseRecoveryCodeResponse{IsUsed: rows > 0}
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/mfa/mfa_db.go
+
+// Package mfa is a generated GoMock package.
+package mfa
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMfaDb is a mock of IMfaDb interface.
+type MockIMfaDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMfaDbMockRecorder
+}
+
+// MockIMfaDbMockRecorder is the mock recorder for MockIMfaDb.
+type MockIMfaDbMockRecorder struct {
+	mock *MockIMfaDb
+}
+
+// NewMockIMfaDb creates a new mock instance.
+func NewMockIMfaDb(ctrl *gomock.Controller) *MockIMfaDb {
+	mock := &MockIMfaDb{ctrl: ctrl}
+	mock.recorder = &MockIMfaDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMfaDb) EXPECT() *MockIMfaDbMockRecorder {
+	return m.recorder
+}
+
+// AddMfaEnrollment mocks base method.
+func (m *MockIMfaDb) AddMfaEnrollment(ctx context.Context, ch chan *AddMfaEnrollmentResponse, model *AddMfaEnrollmentModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMfaEnrollment", ctx, ch, model)
+}
+
+// AddMfaEnrollment indicates an expected call of AddMfaEnrollment.
+func (mr *MockIMfaDbMockRecorder) AddMfaEnrollment(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMfaEnrollment", reflect.TypeOf((*MockIMfaDb)(nil).AddMfaEnrollment), ctx, ch, model)
+}
+
+// EnableMfa mocks base method.
+func (m *MockIMfaDb) EnableMfa(ctx context.Context, ch chan *EnableMfaResponse, model *EnableMfaModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableMfa", ctx, ch, model)
+}
+
+// EnableMfa indicates an expected call of EnableMfa.
+func (mr *MockIMfaDbMockRecorder) EnableMfa(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMfa", reflect.TypeOf((*MockIMfaDb)(nil).EnableMfa), ctx, ch, model)
+}
+
+// GetMfa mocks base method.
+func (m *MockIMfaDb) GetMfa(ctx context.Context, ch chan *GetMfaResponse, model *GetMfaModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetMfa", ctx, ch, model)
+}
+
+// GetMfa indicates an expected call of GetMfa.
+func (mr *MockIMfaDbMockRecorder) GetMfa(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMfa", reflect.TypeOf((*MockIMfaDb)(nil).GetMfa), ctx, ch, model)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockIMfaDb) UseRecoveryCode(ctx context.Context, ch chan *UseRecoveryCodeResponse, model *UseRecoveryCodeModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UseRecoveryCode", ctx, ch, model)
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockIMfaDbMockRecorder) UseRecoveryCode(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockIMfaDb)(nil).UseRecoveryCode), ctx, ch, model)
+}
+
+// UseTimeStep mocks base method.
+func (m *MockIMfaDb) UseTimeStep(ctx context.Context, ch chan *UseTimeStepResponse, model *UseTimeStepModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UseTimeStep", ctx, ch, model)
+}
+
+// UseTimeStep indicates an expected call of UseTimeStep.
+func (mr *MockIMfaDbMockRecorder) UseTimeStep(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTimeStep", reflect.TypeOf((*MockIMfaDb)(nil).UseTimeStep), ctx, ch, model)
+}
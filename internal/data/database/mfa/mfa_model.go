@@ -0,0 +1,26 @@
+package mfa
+
+type AddMfaEnrollmentModel struct {
+	UserId             int64    `validate:"required"`
+	Secret             string   `validate:"required"`
+	RecoveryCodeHashes []string `validate:"required,min=1"`
+}
+
+type GetMfaModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type EnableMfaModel struct {
+	UserId int64 `validate:"required"`
+	Step   int64 `validate:"required"`
+}
+
+type UseTimeStepModel struct {
+	UserId int64 `validate:"required"`
+	Step   int64 `validate:"required"`
+}
+
+type UseRecoveryCodeModel struct {
+	UserId   int64  `validate:"required"`
+	CodeHash string `validate:"required"`
+}
@@ -0,0 +1,25 @@
+package mfa
+
+type AddMfaEnrollmentResponse struct {
+	Error error `json:"-"`
+}
+
+type GetMfaResponse struct {
+	Error     error `json:"-"`
+	Secret    string
+	IsEnabled bool
+}
+
+type EnableMfaResponse struct {
+	Error error `json:"-"`
+}
+
+type UseTimeStepResponse struct {
+	Error  error `json:"-"`
+	IsUsed bool
+}
+
+type UseRecoveryCodeResponse struct {
+	Error  error `json:"-"`
+	IsUsed bool
+}
@@ -0,0 +1,393 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/migration"
+
+	"go.uber.org/zap"
+)
+
+// NilVersion
+// Version of a db without any applied migration.
+const NilVersion int64 = -1
+
+// lockId
+// Key of the postgresql advisory lock held while migrating, so concurrent runners wait for each other.
+const lockId int64 = 7163240458313510361
+
+var ErrDirty = errors.New("db is dirty, fix it manually and force the version")
+
+// fileNameRegexp
+// Matches migration file names like 20220216004310_users.up.sql.
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type IMigrator interface {
+	Up() error
+	Down(steps int) error
+	Goto(version int64) error
+	Status() (*StatusResponse, error)
+	Force(version int64) error
+}
+
+type Migrator struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	pool        *sql.DB
+	migrations  []Migration
+	timeout     time.Duration
+}
+
+// New
+// Returns a new Migrator reading migrations from source, which defaults to migrations embedded into the binary.
+// Versions are kept in schema_migrations table compatible with golang-migrate.
+func New(environment env.IEnvironment, loggr logger.ILogger, pool *sql.DB, source fs.FS) (IMigrator, error) {
+	migrator := Migrator{
+		environment: environment,
+		loggr:       loggr,
+		timeout:     time.Minute * 10,
+	}
+
+	if pool != nil {
+		migrator.pool = pool
+	} else {
+		migrator.pool = database.Open(environment)
+	}
+
+	if source == nil {
+		source = migration.Files
+	}
+
+	migrations, err := readMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	migrator.migrations = migrations
+
+	return &migrator, nil
+}
+
+// Up
+// Applies all pending migrations.
+func (m *Migrator) Up() error {
+	return m.migrate(func(current int64) (int64, error) {
+		if len(m.migrations) == 0 {
+			return current, nil
+		}
+
+		return m.migrations[len(m.migrations)-1].Version, nil
+	})
+}
+
+// Down
+// Rolls back given number of applied migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return errors.New("number of migrations to roll back must be at least 1")
+	}
+
+	return m.migrate(func(current int64) (int64, error) {
+		index := m.indexOf(current)
+		if index < 0 && current != NilVersion {
+			return 0, fmt.Errorf("version %d is not found in migrations", current)
+		}
+
+		if index-steps < 0 {
+			return NilVersion, nil
+		}
+
+		return m.migrations[index-steps].Version, nil
+	})
+}
+
+// Goto
+// Applies or rolls back migrations until db is at given version.
+func (m *Migrator) Goto(version int64) error {
+	if version != NilVersion && m.indexOf(version) < 0 {
+		return fmt.Errorf("version %d is not found in migrations", version)
+	}
+
+	return m.migrate(func(current int64) (int64, error) {
+		return version, nil
+	})
+}
+
+// Status
+// Returns current version of db and whether each migration is applied.
+func (m *Migrator) Status() (*StatusResponse, error) {
+	var response StatusResponse
+
+	err := m.withLock(func(ctx context.Context, connection *sql.Conn) error {
+		version, dirty, err := getVersion(ctx, connection)
+		if err != nil {
+			return err
+		}
+
+		response = StatusResponse{Version: version, Dirty: dirty}
+		for _, migration := range m.migrations {
+			response.Migrations = append(response.Migrations, MigrationStatus{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				IsApplied: migration.Version <= version,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Force
+// Sets version of db without running migrations and clears dirty state.
+func (m *Migrator) Force(version int64) error {
+	if version != NilVersion && m.indexOf(version) < 0 {
+		return fmt.Errorf("version %d is not found in migrations", version)
+	}
+
+	return m.withLock(func(ctx context.Context, connection *sql.Conn) error {
+		return setVersion(ctx, connection, version, false)
+	})
+}
+
+// migrate
+// Applies the steps from current version to the version returned by target.
+func (m *Migrator) migrate(target func(current int64) (int64, error)) error {
+	return m.withLock(func(ctx context.Context, connection *sql.Conn) error {
+		current, dirty, err := getVersion(ctx, connection)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return ErrDirty
+		}
+
+		targetVersion, err := target(current)
+		if err != nil {
+			return err
+		}
+
+		steps, err := m.plan(current, targetVersion)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			err = m.apply(ctx, connection, step)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// plan
+// Returns the steps to migrate from current version to target version in order.
+func (m *Migrator) plan(current int64, target int64) ([]step, error) {
+	var steps []step
+
+	if target >= current {
+		for _, migration := range m.migrations {
+			if migration.Version > current && migration.Version <= target {
+				steps = append(steps, step{migration: migration, isUp: true, version: migration.Version})
+			}
+		}
+
+		return steps, nil
+	}
+
+	index := m.indexOf(current)
+	if index < 0 {
+		return nil, fmt.Errorf("version %d is not found in migrations", current)
+	}
+
+	for i := index; i >= 0 && m.migrations[i].Version > target; i-- {
+		version := NilVersion
+		if i > 0 {
+			version = m.migrations[i-1].Version
+		}
+
+		steps = append(steps, step{migration: m.migrations[i], isUp: false, version: version})
+	}
+
+	return steps, nil
+}
+
+// apply
+// Runs a migration and sets the new version in a single transaction, so a failed migration leaves db unchanged.
+func (m *Migrator) apply(ctx context.Context, connection *sql.Conn, step step) error {
+	fileName, query := step.migration.DownFile, step.migration.Down
+	if step.isUp {
+		fileName, query = step.migration.UpFile, step.migration.Up
+	}
+
+	tx, err := connection.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	err = setVersion(ctx, tx, step.version, false)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	m.loggr.Info("Migration is applied.", zap.String("file", fileName), zap.Int64("version", step.version))
+	return nil
+}
+
+// withLock
+// Runs fn on a single connection holding the advisory lock, after creating schema_migrations table if it is missing.
+func (m *Migrator) withLock(fn func(ctx context.Context, connection *sql.Conn) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	connection, err := m.pool.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	_, err = connection.ExecContext(ctx, `select pg_advisory_lock($1)`, lockId)
+	if err != nil {
+		return err
+	}
+	defer connection.ExecContext(context.Background(), `select pg_advisory_unlock($1)`, lockId)
+
+	_, err = connection.ExecContext(ctx, `create table if not exists schema_migrations (version bigint not null primary key, dirty boolean not null)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, connection)
+}
+
+// indexOf
+// Returns index of the migration with given version, or -1 if it is not found.
+func (m *Migrator) indexOf(version int64) int {
+	index := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	if index < len(m.migrations) && m.migrations[index].Version == version {
+		return index
+	}
+
+	return -1
+}
+
+// getVersion
+// Returns current version of db, or NilVersion if no migration is applied.
+func getVersion(ctx context.Context, connection *sql.Conn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := connection.QueryRowContext(ctx, `select version, dirty from schema_migrations limit 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+// setVersion
+// Replaces the single row of schema_migrations, or leaves it empty for NilVersion.
+func setVersion(ctx context.Context, executor database.IExecutor, version int64, dirty bool) error {
+	_, err := executor.ExecContext(ctx, `delete from schema_migrations`)
+	if err != nil {
+		return err
+	}
+
+	if version == NilVersion {
+		return nil
+	}
+
+	_, err = executor.ExecContext(ctx, `insert into schema_migrations (version, dirty) values ($1, $2)`, version, dirty)
+	return err
+}
+
+// readMigrations
+// Reads up and down files from source and returns migrations ordered by version.
+// Returns an error if a file name is invalid, a version is duplicated or a migration misses one of its files.
+func readMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			migrations[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.UpFile, migration.Up = entry.Name(), string(content)
+		} else {
+			migration.DownFile, migration.Down = entry.Name(), string(content)
+		}
+	}
+
+	response := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if migration.UpFile == "" || migration.DownFile == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+
+		response = append(response, *migration)
+	}
+
+	sort.Slice(response, func(i, j int) bool { return response[i].Version < response[j].Version })
+
+	return response, nil
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/migrator/migrator.go
+
+// Package migrator is a generated GoMock package.
+package migrator
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMigrator is a mock of IMigrator interface.
+type MockIMigrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigratorMockRecorder
+}
+
+// MockIMigratorMockRecorder is the mock recorder for MockIMigrator.
+type MockIMigratorMockRecorder struct {
+	mock *MockIMigrator
+}
+
+// NewMockIMigrator creates a new mock instance.
+func NewMockIMigrator(ctrl *gomock.Controller) *MockIMigrator {
+	mock := &MockIMigrator{ctrl: ctrl}
+	mock.recorder = &MockIMigratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrator) EXPECT() *MockIMigratorMockRecorder {
+	return m.recorder
+}
+
+// Down mocks base method.
+func (m *MockIMigrator) Down(steps int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", steps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockIMigratorMockRecorder) Down(steps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*MockIMigrator)(nil).Down), steps)
+}
+
+// Force mocks base method.
+func (m *MockIMigrator) Force(version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Force", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Force indicates an expected call of Force.
+func (mr *MockIMigratorMockRecorder) Force(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Force", reflect.TypeOf((*MockIMigrator)(nil).Force), version)
+}
+
+// Goto mocks base method.
+func (m *MockIMigrator) Goto(version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Goto", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Goto indicates an expected call of Goto.
+func (mr *MockIMigratorMockRecorder) Goto(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Goto", reflect.TypeOf((*MockIMigrator)(nil).Goto), version)
+}
+
+// Status mocks base method.
+func (m *MockIMigrator) Status() (*StatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(*StatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIMigratorMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIMigrator)(nil).Status))
+}
+
+// Up mocks base method.
+func (m *MockIMigrator) Up() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockIMigratorMockRecorder) Up() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockIMigrator)(nil).Up))
+}
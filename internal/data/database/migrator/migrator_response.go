@@ -0,0 +1,30 @@
+package migrator
+
+type Migration struct {
+	Version  int64
+	Name     string
+	UpFile   string
+	Up       string
+	DownFile string
+	Down     string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	IsApplied bool
+}
+
+type StatusResponse struct {
+	Version    int64
+	Dirty      bool
+	Migrations []MigrationStatus
+}
+
+// step
+// A migration to apply and the version of db after it.
+type step struct {
+	migration Migration
+	isUp      bool
+	version   int64
+}
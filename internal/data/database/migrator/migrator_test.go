@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"go-clean-architecture/migration"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MigratorTestSuite struct {
+	suite.Suite
+	migrator *Migrator
+}
+
+// Run suite.
+func TestMigrator(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *MigratorTestSuite) SetupTest() {
+	migrations, err := readMigrations(fstest.MapFS{
+		"3_sessions.up.sql":   {Data: []byte("create table sessions ();")},
+		"3_sessions.down.sql": {Data: []byte("drop table sessions;")},
+		"1_users.up.sql":      {Data: []byte("create table users ();")},
+		"1_users.down.sql":    {Data: []byte("drop table users;")},
+		"2_roles.up.sql":      {Data: []byte("create table roles ();")},
+		"2_roles.down.sql":    {Data: []byte("drop table roles;")},
+		"migration.go":        {Data: []byte("package migration")},
+	})
+	s.Require().NoError(err)
+
+	s.migrator = &Migrator{migrations: migrations}
+}
+
+func (s *MigratorTestSuite) TestReadMigrations_ValidFiles_OrdersByVersion() {
+	// Then
+	s.Len(s.migrator.migrations, 3)
+	s.Equal(int64(1), s.migrator.migrations[0].Version)
+	s.Equal("users", s.migrator.migrations[0].Name)
+	s.Equal("drop table users;", s.migrator.migrations[0].Down)
+	s.Equal(int64(3), s.migrator.migrations[2].Version)
+}
+
+func (s *MigratorTestSuite) TestReadMigrations_MissingDownFile_ReturnsError() {
+	// When
+	_, err := readMigrations(fstest.MapFS{"1_users.up.sql": {Data: []byte("create table users ();")}})
+
+	// Then
+	s.EqualError(err, "migration 1_users must have both up and down files")
+}
+
+func (s *MigratorTestSuite) TestPlan_NilVersionToLatest_AppliesAllUp() {
+	// When
+	steps, err := s.migrator.plan(NilVersion, 3)
+
+	// Then
+	s.NoError(err)
+	s.Len(steps, 3)
+	s.True(steps[0].isUp)
+	s.Equal(int64(1), steps[0].version)
+	s.Equal(int64(3), steps[2].version)
+}
+
+func (s *MigratorTestSuite) TestPlan_RollBackToNilVersion_AppliesDownInReverseOrder() {
+	// When
+	steps, err := s.migrator.plan(3, NilVersion)
+
+	// Then
+	s.NoError(err)
+	s.Len(steps, 3)
+	s.False(steps[0].isUp)
+	s.Equal("sessions", steps[0].migration.Name)
+	s.Equal(int64(2), steps[0].version)
+	s.Equal(NilVersion, steps[2].version)
+}
+
+func (s *MigratorTestSuite) TestPlan_CurrentVersionUnknown_ReturnsError() {
+	// When
+	_, err := s.migrator.plan(5, 1)
+
+	// Then
+	s.EqualError(err, "version 5 is not found in migrations")
+}
+
+func (s *MigratorTestSuite) TestEmbeddedMigrations_AreValid() {
+	// When
+	migrations, err := readMigrations(migration.Files)
+
+	// Then
+	s.NoError(err)
+	s.NotEmpty(migrations)
+}
@@ -0,0 +1,277 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// relayLockId
+// Key of the postgresql advisory lock held while relaying, so a single relay publishes at a time and keeps the order.
+const relayLockId int64 = 7163240458313510362
+
+// maxErrorLength
+// Length of last_error column, longer errors are truncated.
+const maxErrorLength = 500
+
+type IOutboxDb interface {
+	AddMessage(ctx context.Context, ch chan *AddMessageResponse, model *AddMessageModel)
+	LockRelay(ctx context.Context, ch chan *LockRelayResponse)
+	GetPendingMessages(ctx context.Context, ch chan *GetPendingMessagesResponse, model *GetPendingMessagesModel)
+	MarkSent(ctx context.Context, ch chan *MarkSentResponse, model *MarkSentModel)
+	MarkFailed(ctx context.Context, ch chan *MarkFailedResponse, model *MarkFailedModel)
+	GetLag(ctx context.Context, ch chan *GetLagResponse)
+}
+
+type OutboxDb struct {
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewOutboxDb
+// Returns a new OutboxDb.
+func NewOutboxDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) IOutboxDb {
+	db := OutboxDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
+	}
+
+	return &db
+}
+
+// AddMessage
+// Adds a message to be published by the relay, inside the transaction of the change it belongs to.
+func (d *OutboxDb) AddMessage(ctx context.Context, ch chan *AddMessageResponse, model *AddMessageModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddMessageResponse{Error: modelErr}
+		return
+	}
+
+	attributes, err := json.Marshal(model.Attributes)
+	if err != nil {
+		ch <- &AddMessageResponse{Error: err}
+		return
+	}
+	if model.Attributes == nil {
+		attributes = []byte("{}")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into outbox_messages (topic, ordering_key, payload, attributes) values ($1, $2, $3, $4) returning id`
+
+	var response AddMessageResponse
+	err = model.Transaction.QueryRowContext(ctx, query, model.Topic, model.OrderingKey, model.Payload, attributes).Scan(&response.Id)
+	if err != nil {
+		ch <- &AddMessageResponse{Error: err}
+		return
+	}
+
+	ch <- &response
+}
+
+// LockRelay
+// Tries to take the relay lock on a dedicated connection without waiting. IsLocked is false if another relay holds it.
+// Unlock must be called to release the lock and the connection.
+func (d *OutboxDb) LockRelay(ctx context.Context, ch chan *LockRelayResponse) {
+	connection, err := d.pool.Conn(ctx)
+	if err != nil {
+		ch <- &LockRelayResponse{Error: err}
+		return
+	}
+
+	lockCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var isLocked bool
+	err = connection.QueryRowContext(lockCtx, `select pg_try_advisory_lock($1)`, relayLockId).Scan(&isLocked)
+	if err != nil || !isLocked {
+		connection.Close()
+		ch <- &LockRelayResponse{Error: err}
+		return
+	}
+
+	ch <- &LockRelayResponse{
+		IsLocked: true,
+		Unlock: func() {
+			// Released with a fresh context, so the lock is not left behind when the relay is cancelled.
+			unlockCtx, cancel := context.WithTimeout(context.Background(), d.timeout)
+			defer cancel()
+
+			_, err := connection.ExecContext(unlockCtx, `select pg_advisory_unlock($1)`, relayLockId)
+			if err != nil {
+				d.loggr.Error("Outbox relay lock could not be released.", zap.Error(err))
+			}
+			connection.Close()
+		},
+	}
+}
+
+// GetPendingMessages
+// Gets unsent messages which are due, oldest first. Messages behind an unsent message with the same ordering key which
+// is waiting for a retry are left out, so messages of an ordering key are published in the order they are added.
+func (d *OutboxDb) GetPendingMessages(ctx context.Context, ch chan *GetPendingMessagesResponse, model *GetPendingMessagesModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetPendingMessagesResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select m.id, m.topic, m.ordering_key, m.payload, m.attributes, m.attempts, m.created_date
+	from outbox_messages as m
+	where m.sent_date is null
+	and m.next_attempt_date <= current_timestamp
+	and (m.ordering_key = '' or not exists (
+		select 1
+		from outbox_messages as p
+		where p.ordering_key = m.ordering_key
+		and p.sent_date is null
+		and p.id < m.id
+		and p.next_attempt_date > current_timestamp
+	))
+	order by m.id
+	limit $1`
+
+	rows, err := d.pool.QueryContext(ctx, query, model.Limit)
+	if err != nil {
+		ch <- &GetPendingMessagesResponse{Error: err}
+		return
+	}
+	defer rows.Close()
+
+	messages := []Message{}
+	for rows.Next() {
+		var message Message
+		var attributes []byte
+		err = rows.Scan(&message.Id, &message.Topic, &message.OrderingKey, &message.Payload, &attributes, &message.Attempts, &message.CreatedDate)
+		if err != nil {
+			ch <- &GetPendingMessagesResponse{Error: err}
+			return
+		}
+
+		err = json.Unmarshal(attributes, &message.Attributes)
+		if err != nil {
+			ch <- &GetPendingMessagesResponse{Error: err}
+			return
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetPendingMessagesResponse{Error: err}
+		return
+	}
+
+	ch <- &GetPendingMessagesResponse{Messages: messages}
+}
+
+// MarkSent
+// Marks a message as sent with the id given by pub-sub.
+func (d *OutboxDb) MarkSent(ctx context.Context, ch chan *MarkSentResponse, model *MarkSentModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &MarkSentResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update outbox_messages set sent_date = current_timestamp, message_id = nullif($2, '') where id = $1`
+
+	_, err := d.pool.ExecContext(ctx, query, model.Id, model.MessageId)
+	if err != nil {
+		ch <- &MarkSentResponse{Error: err}
+		return
+	}
+
+	ch <- &MarkSentResponse{}
+}
+
+// MarkFailed
+// Records a failed attempt of a message, which is tried again after the delay. The date is set by postgresql, so it is
+// compared with the same clock as current_timestamp in GetPendingMessages.
+func (d *OutboxDb) MarkFailed(ctx context.Context, ch chan *MarkFailedResponse, model *MarkFailedModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &MarkFailedResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	lastError := model.Error
+	if runes := []rune(lastError); len(runes) > maxErrorLength {
+		lastError = string(runes[:maxErrorLength])
+	}
+
+	query := `update outbox_messages set attempts = attempts + 1, last_error = $2, next_attempt_date = current_timestamp + $3 * interval '1 second' where id = $1`
+
+	_, err := d.pool.ExecContext(ctx, query, model.Id, lastError, model.RetryDelay.Seconds())
+	if err != nil {
+		ch <- &MarkFailedResponse{Error: err}
+		return
+	}
+
+	ch <- &MarkFailedResponse{}
+}
+
+// GetLag
+// Gets the count of unsent messages, how many of them failed at least once and when the oldest one is added.
+// Lag is computed by postgresql, so it is not affected by the time zone of the dates.
+func (d *OutboxDb) GetLag(ctx context.Context, ch chan *GetLagResponse) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `
+	select count(*), count(*) filter (where attempts > 0), min(created_date),
+		coalesce(extract(epoch from current_timestamp - min(created_date)), 0)
+	from outbox_messages
+	where sent_date is null`
+
+	var response GetLagResponse
+	var oldestPendingDate sql.NullTime
+	var lagSeconds float64
+	err := d.pool.QueryRowContext(ctx, query).Scan(&response.PendingCount, &response.FailingCount, &oldestPendingDate, &lagSeconds)
+	if err != nil {
+		ch <- &GetLagResponse{Error: err}
+		return
+	}
+
+	if oldestPendingDate.Valid {
+		response.OldestPendingDate = &oldestPendingDate.Time
+	}
+	response.Lag = time.Duration(lagSeconds * float64(time.Second))
+
+	ch <- &response
+}
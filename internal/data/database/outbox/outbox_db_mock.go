@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/outbox/outbox_db.go
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOutboxDb is a mock of IOutboxDb interface.
+type MockIOutboxDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxDbMockRecorder
+}
+
+// MockIOutboxDbMockRecorder is the mock recorder for MockIOutboxDb.
+type MockIOutboxDbMockRecorder struct {
+	mock *MockIOutboxDb
+}
+
+// NewMockIOutboxDb creates a new mock instance.
+func NewMockIOutboxDb(ctrl *gomock.Controller) *MockIOutboxDb {
+	mock := &MockIOutboxDb{ctrl: ctrl}
+	mock.recorder = &MockIOutboxDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxDb) EXPECT() *MockIOutboxDbMockRecorder {
+	return m.recorder
+}
+
+// AddMessage mocks base method.
+func (m *MockIOutboxDb) AddMessage(ctx context.Context, ch chan *AddMessageResponse, model *AddMessageModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMessage", ctx, ch, model)
+}
+
+// AddMessage indicates an expected call of AddMessage.
+func (mr *MockIOutboxDbMockRecorder) AddMessage(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessage", reflect.TypeOf((*MockIOutboxDb)(nil).AddMessage), ctx, ch, model)
+}
+
+// GetLag mocks base method.
+func (m *MockIOutboxDb) GetLag(ctx context.Context, ch chan *GetLagResponse) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLag", ctx, ch)
+}
+
+// GetLag indicates an expected call of GetLag.
+func (mr *MockIOutboxDbMockRecorder) GetLag(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLag", reflect.TypeOf((*MockIOutboxDb)(nil).GetLag), ctx, ch)
+}
+
+// GetPendingMessages mocks base method.
+func (m *MockIOutboxDb) GetPendingMessages(ctx context.Context, ch chan *GetPendingMessagesResponse, model *GetPendingMessagesModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetPendingMessages", ctx, ch, model)
+}
+
+// GetPendingMessages indicates an expected call of GetPendingMessages.
+func (mr *MockIOutboxDbMockRecorder) GetPendingMessages(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingMessages", reflect.TypeOf((*MockIOutboxDb)(nil).GetPendingMessages), ctx, ch, model)
+}
+
+// LockRelay mocks base method.
+func (m *MockIOutboxDb) LockRelay(ctx context.Context, ch chan *LockRelayResponse) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LockRelay", ctx, ch)
+}
+
+// LockRelay indicates an expected call of LockRelay.
+func (mr *MockIOutboxDbMockRecorder) LockRelay(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRelay", reflect.TypeOf((*MockIOutboxDb)(nil).LockRelay), ctx, ch)
+}
+
+// MarkFailed mocks base method.
+func (m *MockIOutboxDb) MarkFailed(ctx context.Context, ch chan *MarkFailedResponse, model *MarkFailedModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkFailed", ctx, ch, model)
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockIOutboxDbMockRecorder) MarkFailed(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockIOutboxDb)(nil).MarkFailed), ctx, ch, model)
+}
+
+// MarkSent mocks base method.
+func (m *MockIOutboxDb) MarkSent(ctx context.Context, ch chan *MarkSentResponse, model *MarkSentModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkSent", ctx, ch, model)
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockIOutboxDbMockRecorder) MarkSent(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockIOutboxDb)(nil).MarkSent), ctx, ch, model)
+}
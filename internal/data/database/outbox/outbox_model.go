@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"time"
+
+	"go-clean-architecture/internal/data/database"
+)
+
+type AddMessageModel struct {
+	Topic       string `validate:"required,max=100"`
+	OrderingKey string `validate:"max=100"`
+	Payload     []byte `validate:"required"`
+	Attributes  map[string]string
+	Transaction database.ITransaction `validate:"required"` // messages are only added in a transaction, along with the change they announce if any
+}
+
+type GetPendingMessagesModel struct {
+	Limit int `validate:"required,gte=1,lte=1000"`
+}
+
+type MarkSentModel struct {
+	Id        int64  `validate:"required"`
+	MessageId string `validate:"max=100"`
+}
+
+type MarkFailedModel struct {
+	Id         int64         `validate:"required"`
+	Error      string        `validate:"required"`
+	RetryDelay time.Duration `validate:"gte=0"`
+}
@@ -0,0 +1,45 @@
+package outbox
+
+import "time"
+
+type AddMessageResponse struct {
+	Error error `json:"-"`
+	Id    int64
+}
+
+type LockRelayResponse struct {
+	Error    error `json:"-"`
+	IsLocked bool
+	Unlock   func() `json:"-"`
+}
+
+type Message struct {
+	Id          int64
+	Topic       string
+	OrderingKey string
+	Payload     []byte
+	Attributes  map[string]string
+	Attempts    int
+	CreatedDate time.Time
+}
+
+type GetPendingMessagesResponse struct {
+	Error    error `json:"-"`
+	Messages []Message
+}
+
+type MarkSentResponse struct {
+	Error error `json:"-"`
+}
+
+type MarkFailedResponse struct {
+	Error error `json:"-"`
+}
+
+type GetLagResponse struct {
+	Error             error `json:"-"`
+	PendingCount      int64
+	FailingCount      int64
+	OldestPendingDate *time.Time
+	Lag               time.Duration
+}
@@ -5,67 +5,306 @@ import (
 	"database/sql"
 	"time"
 
+	"go-clean-architecture/internal/data/database"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
 	"go-clean-architecture/internal/util/validator"
 
 	_ "github.com/lib/pq"
 )
 
+// sampleColumns
+// Selects samples in the order scanSample reads them.
+const sampleColumns = `id, sample_name, sample_type, sample_code, sample_status, modified_by, version, ` + database.AuditColumns
+
+// QuerySchema
+// Fields of samples which list queries can filter and sort by.
+var QuerySchema = query.Schema{
+	Key:        "id",
+	SoftDelete: true,
+	Fields: map[string]query.Field{
+		"id":           {Column: "id", Type: query.Int, Filterable: true, Sortable: true},
+		"sampleName":   {Column: "sample_name", Type: query.String, Filterable: true, Sortable: true},
+		"sampleType":   {Column: "sample_type", Type: query.String, Filterable: true, Sortable: true},
+		"sampleCode":   {Column: "sample_code", Type: query.Int, Filterable: true},
+		"sampleStatus": {Column: "sample_status", Type: query.Int, Filterable: true, Sortable: true},
+		"createdAt":    {Column: "created_at", Type: query.Time, Filterable: true, Sortable: true},
+		"updatedAt":    {Column: "updated_at", Type: query.Time, Filterable: true},
+	},
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type ISampleDb interface {
-	GetSample(ch chan *GetSampleDbResponse, model *GetSampleDbModel)
+	GetSample(ctx context.Context, ch chan *SampleDbResponse, model *GetSampleDbModel)
+	GetSamples(ctx context.Context, ch chan *GetSamplesDbResponse, model *GetSamplesDbModel)
+	AddSample(ctx context.Context, ch chan *SampleDbResponse, model *AddSampleDbModel)
+	UpdateSample(ctx context.Context, ch chan *SampleDbResponse, model *UpdateSampleDbModel)
+	DeleteSample(ctx context.Context, ch chan *SampleDbResponse, model *DeleteSampleDbModel)
+	RestoreSample(ctx context.Context, ch chan *SampleDbResponse, model *RestoreSampleDbModel)
 }
 
 type SampleDb struct {
-	loggr            logger.ILogger
-	validatr         validator.IValidator
-	cachr            cacher.ICacher
-	environment      env.IEnvironment
-	connectionString string
-	driverName       string
-	timeout          time.Duration
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
 }
 
 // NewSampleDb
 // Returns a new SampleDb.
-func NewSampleDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) ISampleDb {
+func NewSampleDb(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, pool *sql.DB) ISampleDb {
 	db := SampleDb{
-		environment:      environment,
-		loggr:            loggr,
-		validatr:         validatr,
-		cachr:            cachr,
-		driverName:       "postgres",
-		connectionString: environment.Get(env.PostgresqlConnectionString),
-		timeout:          time.Second * 5,
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		timeout:     time.Second * 5,
+	}
+
+	if pool != nil {
+		db.pool = pool
+	} else {
+		db.pool = database.Open(environment)
 	}
 
 	return &db
 }
 
 // GetSample
-// Gets sample response from postgresql db.
-func (d *SampleDb) GetSample(ch chan *GetSampleDbResponse, model *GetSampleDbModel) {
+// Gets sample from postgresql by id.
+// Returns sql.ErrNoRows if sample is not found or deleted.
+func (d *SampleDb) GetSample(ctx context.Context, ch chan *SampleDbResponse, model *GetSampleDbModel) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
-		ch <- &GetSampleDbResponse{Error: modelErr}
+		ch <- &SampleDbResponse{Error: modelErr}
 		return
 	}
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select ` + sampleColumns + ` from samples where id = $1 and ` + database.NotDeleted
+
+	sample, err := scanSample(d.pool.QueryRowContext(ctx, query, model.Id))
 	if err != nil {
-		ch <- &GetSampleDbResponse{Error: err}
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	ch <- &SampleDbResponse{Sample: *sample}
+}
+
+// GetSamples
+// Gets a page of samples matching the filters and sorting of the query with the total count of matching samples.
+func (d *SampleDb) GetSamples(ctx context.Context, ch chan *GetSamplesDbResponse, model *GetSamplesDbModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetSamplesDbResponse{Error: modelErr}
 		return
 	}
-	defer connection.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
-	err = connection.PingContext(ctx)
+
+	statement, args := model.Query.Select(sampleColumns, "samples", "")
+
+	rows, err := d.pool.QueryContext(ctx, statement, args...)
 	if err != nil {
-		ch <- &GetSampleDbResponse{Error: err}
+		ch <- &GetSamplesDbResponse{Error: err}
 		return
 	}
+	defer rows.Close()
+
+	var totalCount int64
+	samples := []Sample{}
+	for rows.Next() {
+		sample, err := scanSample(rows, &totalCount)
+		if err != nil {
+			ch <- &GetSamplesDbResponse{Error: err}
+			return
+		}
+
+		samples = append(samples, *sample)
+	}
+
+	if err = rows.Err(); err != nil {
+		ch <- &GetSamplesDbResponse{Error: err}
+		return
+	}
+
+	if len(samples) == 0 {
+		statement, args = model.Query.Count("samples", "")
+		err = d.pool.QueryRowContext(ctx, statement, args...).Scan(&totalCount)
+		if err != nil {
+			ch <- &GetSamplesDbResponse{Error: err}
+			return
+		}
+	}
+
+	kept, page := model.Query.Result(totalCount, len(samples), func(i int) map[string]interface{} {
+		return sampleValues(samples[i])
+	})
+
+	ch <- &GetSamplesDbResponse{Samples: samples[:kept], Page: page}
+}
+
+// AddSample
+// Adds a sample with the initial status on behalf of the authenticated user and returns it.
+func (d *SampleDb) AddSample(ctx context.Context, ch chan *SampleDbResponse, model *AddSampleDbModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleDbResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into samples (sample_name, sample_type, sample_code, created_by) values ($1, $2, $3, $4) returning ` + sampleColumns
+
+	executor := database.Executor(d.pool, model.Transaction)
+	sample, err := scanSample(executor.QueryRowContext(ctx, query, model.SampleName, model.SampleType, model.SampleCode, helper.GetActorId(ctx)))
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	ch <- &SampleDbResponse{Sample: *sample}
+}
+
+// UpdateSample
+// Updates status of a sample with who modified it and returns it. If Version is set, the sample is only updated if it
+// still has that version.
+// Returns sql.ErrNoRows if sample is not found or deleted, database.ErrVersionMismatch if it has another version.
+func (d *SampleDb) UpdateSample(ctx context.Context, ch chan *SampleDbResponse, model *UpdateSampleDbModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleDbResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
 
-	ch <- &GetSampleDbResponse{SampleName: "sample name here!!", Id: 1}
+	var sample *Sample
+	err := database.UpdateVersioned(ctx, database.Executor(d.pool, model.Transaction), database.VersionedUpdate{
+		Table:     "samples",
+		Id:        model.Id,
+		Version:   model.Version,
+		Set:       `sample_status = $3, modified_by = $4`,
+		Args:      []interface{}{model.SampleStatus, model.ModifiedBy},
+		Returning: sampleColumns,
+	}, func(row *sql.Row) (err error) {
+		sample, err = scanSample(row)
+		return err
+	})
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	ch <- &SampleDbResponse{Sample: *sample}
+}
+
+// DeleteSample
+// Soft deletes a sample, so it can be restored, and returns it.
+// Returns sql.ErrNoRows if sample is not found or already deleted.
+func (d *SampleDb) DeleteSample(ctx context.Context, ch chan *SampleDbResponse, model *DeleteSampleDbModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleDbResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	executor := database.Executor(d.pool, model.Transaction)
+	err := database.SoftDelete(ctx, executor, "samples", model.Id)
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	sample, err := scanSample(executor.QueryRowContext(ctx, `select `+sampleColumns+` from samples where id = $1`, model.Id))
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	ch <- &SampleDbResponse{Sample: *sample}
+}
+
+// RestoreSample
+// Restores a soft deleted sample and returns it.
+// Returns sql.ErrNoRows if sample is not found or not deleted.
+func (d *SampleDb) RestoreSample(ctx context.Context, ch chan *SampleDbResponse, model *RestoreSampleDbModel) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleDbResponse{Error: modelErr}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	executor := database.Executor(d.pool, model.Transaction)
+	err := database.Restore(ctx, executor, "samples", model.Id)
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	sample, err := scanSample(executor.QueryRowContext(ctx, `select `+sampleColumns+` from samples where id = $1`, model.Id))
+	if err != nil {
+		ch <- &SampleDbResponse{Error: err}
+		return
+	}
+
+	ch <- &SampleDbResponse{Sample: *sample}
+}
+
+// scanSample
+// Reads a row selected with sampleColumns, followed by extra destinations if any.
+func scanSample(row rowScanner, extra ...interface{}) (*Sample, error) {
+	var sample Sample
+	var sampleCode sql.NullInt32
+	var modifiedBy sql.NullString
+
+	dest := []interface{}{&sample.Id, &sample.SampleName, &sample.SampleType, &sampleCode, &sample.SampleStatus, &modifiedBy, &sample.Version}
+	dest = append(dest, sample.Audit.Dest()...)
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if sampleCode.Valid {
+		code := int(sampleCode.Int32)
+		sample.SampleCode = &code
+	}
+	if modifiedBy.Valid {
+		sample.ModifiedBy = &modifiedBy.String
+	}
+
+	return &sample, nil
+}
+
+// sampleValues
+// Returns values of the sortable fields of QuerySchema.
+func sampleValues(sample Sample) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           sample.Id,
+		"sampleName":   sample.SampleName,
+		"sampleType":   sample.SampleType,
+		"sampleStatus": sample.SampleStatus,
+		"createdAt":    sample.CreatedAt,
+	}
 }
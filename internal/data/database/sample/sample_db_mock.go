@@ -5,11 +5,53 @@
 package sample
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockrowScanner is a mock of rowScanner interface.
+type MockrowScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockrowScannerMockRecorder
+}
+
+// MockrowScannerMockRecorder is the mock recorder for MockrowScanner.
+type MockrowScannerMockRecorder struct {
+	mock *MockrowScanner
+}
+
+// NewMockrowScanner creates a new mock instance.
+func NewMockrowScanner(ctrl *gomock.Controller) *MockrowScanner {
+	mock := &MockrowScanner{ctrl: ctrl}
+	mock.recorder = &MockrowScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrowScanner) EXPECT() *MockrowScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockrowScanner) Scan(dest ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockrowScannerMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockrowScanner)(nil).Scan), dest...)
+}
+
 // MockISampleDb is a mock of ISampleDb interface.
 type MockISampleDb struct {
 	ctrl     *gomock.Controller
@@ -33,14 +75,74 @@ func (m *MockISampleDb) EXPECT() *MockISampleDbMockRecorder {
 	return m.recorder
 }
 
+// AddSample mocks base method.
+func (m *MockISampleDb) AddSample(ctx context.Context, ch chan *SampleDbResponse, model *AddSampleDbModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddSample", ctx, ch, model)
+}
+
+// AddSample indicates an expected call of AddSample.
+func (mr *MockISampleDbMockRecorder) AddSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSample", reflect.TypeOf((*MockISampleDb)(nil).AddSample), ctx, ch, model)
+}
+
+// DeleteSample mocks base method.
+func (m *MockISampleDb) DeleteSample(ctx context.Context, ch chan *SampleDbResponse, model *DeleteSampleDbModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSample", ctx, ch, model)
+}
+
+// DeleteSample indicates an expected call of DeleteSample.
+func (mr *MockISampleDbMockRecorder) DeleteSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSample", reflect.TypeOf((*MockISampleDb)(nil).DeleteSample), ctx, ch, model)
+}
+
 // GetSample mocks base method.
-func (m *MockISampleDb) GetSample(ch chan *GetSampleDbResponse, model *GetSampleDbModel) {
+func (m *MockISampleDb) GetSample(ctx context.Context, ch chan *SampleDbResponse, model *GetSampleDbModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetSample", ch, model)
+	m.ctrl.Call(m, "GetSample", ctx, ch, model)
 }
 
 // GetSample indicates an expected call of GetSample.
-func (mr *MockISampleDbMockRecorder) GetSample(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleDbMockRecorder) GetSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSample", reflect.TypeOf((*MockISampleDb)(nil).GetSample), ctx, ch, model)
+}
+
+// GetSamples mocks base method.
+func (m *MockISampleDb) GetSamples(ctx context.Context, ch chan *GetSamplesDbResponse, model *GetSamplesDbModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSamples", ctx, ch, model)
+}
+
+// GetSamples indicates an expected call of GetSamples.
+func (mr *MockISampleDbMockRecorder) GetSamples(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSamples", reflect.TypeOf((*MockISampleDb)(nil).GetSamples), ctx, ch, model)
+}
+
+// RestoreSample mocks base method.
+func (m *MockISampleDb) RestoreSample(ctx context.Context, ch chan *SampleDbResponse, model *RestoreSampleDbModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreSample", ctx, ch, model)
+}
+
+// RestoreSample indicates an expected call of RestoreSample.
+func (mr *MockISampleDbMockRecorder) RestoreSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSample", reflect.TypeOf((*MockISampleDb)(nil).RestoreSample), ctx, ch, model)
+}
+
+// UpdateSample mocks base method.
+func (m *MockISampleDb) UpdateSample(ctx context.Context, ch chan *SampleDbResponse, model *UpdateSampleDbModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSample", ctx, ch, model)
+}
+
+// UpdateSample indicates an expected call of UpdateSample.
+func (mr *MockISampleDbMockRecorder) UpdateSample(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSample", reflect.TypeOf((*MockISampleDb)(nil).GetSample), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSample", reflect.TypeOf((*MockISampleDb)(nil).UpdateSample), ctx, ch, model)
 }
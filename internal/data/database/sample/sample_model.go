@@ -1,6 +1,39 @@
 package sample
 
+import (
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/query"
+)
+
 type GetSampleDbModel struct {
-	Id         int    `validate:"required"`
-	SampleName string `validate:"required"`
+	Id int64 `validate:"required"`
+}
+
+type GetSamplesDbModel struct {
+	Query *query.Query `validate:"required"`
+}
+
+type AddSampleDbModel struct {
+	SampleName  string `validate:"required,max=100"`
+	SampleType  string `validate:"required,max=50"`
+	SampleCode  *int
+	Transaction database.ITransaction
+}
+
+type UpdateSampleDbModel struct {
+	Id           int64  `validate:"required"`
+	SampleStatus int    `validate:"gte=0"`
+	ModifiedBy   string `validate:"required,max=100"`
+	Version      int    `validate:"gte=0"`
+	Transaction  database.ITransaction
+}
+
+type DeleteSampleDbModel struct {
+	Id          int64 `validate:"required"`
+	Transaction database.ITransaction
+}
+
+type RestoreSampleDbModel struct {
+	Id          int64 `validate:"required"`
+	Transaction database.ITransaction
 }
@@ -1,7 +1,28 @@
 package sample
 
-type GetSampleDbResponse struct {
-	Error      error `json:"-"`
-	Id         int
-	SampleName string
+import (
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/util/query"
+)
+
+type Sample struct {
+	Id           int64
+	SampleName   string
+	SampleType   string
+	SampleCode   *int
+	SampleStatus int
+	ModifiedBy   *string
+	Version      int
+	database.Audit
+}
+
+type SampleDbResponse struct {
+	Error  error `json:"-"`
+	Sample Sample
+}
+
+type GetSamplesDbResponse struct {
+	Error   error `json:"-"`
+	Samples []Sample
+	Page    query.Page
 }
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-clean-architecture/internal/util/env"
+
+	"github.com/lib/pq"
+)
+
+// Postgresql error codes of transactions which may succeed when they are run again.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// Retry settings
+const (
+	maxAttempts = 3
+	retryDelay  = time.Millisecond * 20
+)
+
+// IExecutor
+// Runs queries either directly on the pool or inside a transaction.
+type IExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ITransaction
+// A transaction of a unit of work, passed in models of db methods so they run inside it.
+type ITransaction interface {
+	IExecutor
+	Savepoint(fn func(tx ITransaction) error) error
+}
+
+// IUnitOfWork
+// Runs several db method calls in a single transaction.
+type IUnitOfWork interface {
+	Run(ctx context.Context, fn func(tx ITransaction) error) error
+	RunSerializable(ctx context.Context, fn func(tx ITransaction) error) error
+}
+
+type UnitOfWork struct {
+	environment env.IEnvironment
+	pool        *sql.DB
+	timeout     time.Duration
+}
+
+// NewUnitOfWork
+// Returns a new UnitOfWork.
+func NewUnitOfWork(environment env.IEnvironment, pool *sql.DB) IUnitOfWork {
+	unitOfWork := UnitOfWork{
+		environment: environment,
+		timeout:     time.Second * 30,
+	}
+
+	if pool != nil {
+		unitOfWork.pool = pool
+	} else {
+		unitOfWork.pool = Open(environment)
+	}
+
+	return &unitOfWork
+}
+
+// Run
+// Runs fn in a read committed transaction. Commits if fn returns nil, otherwise rolls back and returns its error.
+// fn is run again on serialization failures and deadlocks, so it must not have side effects outside the transaction.
+func (u *UnitOfWork) Run(ctx context.Context, fn func(tx ITransaction) error) error {
+	return retry(func() error {
+		return u.run(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted}, fn)
+	})
+}
+
+// RunSerializable
+// Runs fn like Run in a serializable transaction.
+func (u *UnitOfWork) RunSerializable(ctx context.Context, fn func(tx ITransaction) error) error {
+	return retry(func() error {
+		return u.run(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable}, fn)
+	})
+}
+
+func (u *UnitOfWork) run(ctx context.Context, options *sql.TxOptions, fn func(tx ITransaction) error) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.pool.BeginTx(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(&transaction{Tx: tx, ctx: ctx})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Executor
+// Returns transaction if a db method is called inside a unit of work, otherwise the pool.
+func Executor(pool *sql.DB, transaction ITransaction) IExecutor {
+	if transaction != nil {
+		return transaction
+	}
+
+	return pool
+}
+
+// transaction
+// Wraps sql.Tx with nested savepoints.
+type transaction struct {
+	*sql.Tx
+	ctx   context.Context
+	depth int
+}
+
+// Savepoint
+// Runs fn inside a savepoint. If fn returns an error, only its changes are rolled back and the transaction can go on.
+func (t *transaction) Savepoint(fn func(tx ITransaction) error) error {
+	t.depth++
+	defer func() { t.depth-- }()
+
+	name := fmt.Sprintf("savepoint_%d", t.depth)
+	_, err := t.ExecContext(t.ctx, "savepoint "+name)
+	if err != nil {
+		return err
+	}
+
+	err = fn(t)
+	if err != nil {
+		_, rollbackErr := t.ExecContext(t.ctx, "rollback to savepoint "+name)
+		if rollbackErr != nil {
+			return rollbackErr
+		}
+
+		return err
+	}
+
+	_, err = t.ExecContext(t.ctx, "release savepoint "+name)
+	return err
+}
+
+// retry
+// Runs run until it succeeds, fails with an error which cannot be retried or reaches maxAttempts.
+func retry(run func() error) error {
+	err := run()
+	for attempt := 1; attempt < maxAttempts && isRetryable(err); attempt++ {
+		time.Sleep(retryDelay * time.Duration(attempt))
+		err = run()
+	}
+
+	return err
+}
+
+// isRetryable
+// Returns true if the transaction failed due to concurrent transactions and may succeed when run again.
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
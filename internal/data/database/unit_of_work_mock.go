@@ -0,0 +1,242 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/unit_of_work.go
+
+// Package database is a generated GoMock package.
+package database
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIExecutor is a mock of IExecutor interface.
+type MockIExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExecutorMockRecorder
+}
+
+// MockIExecutorMockRecorder is the mock recorder for MockIExecutor.
+type MockIExecutorMockRecorder struct {
+	mock *MockIExecutor
+}
+
+// NewMockIExecutor creates a new mock instance.
+func NewMockIExecutor(ctrl *gomock.Controller) *MockIExecutor {
+	mock := &MockIExecutor{ctrl: ctrl}
+	mock.recorder = &MockIExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExecutor) EXPECT() *MockIExecutorMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *MockIExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockIExecutorMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MockIExecutor)(nil).ExecContext), varargs...)
+}
+
+// QueryContext mocks base method.
+func (m *MockIExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MockIExecutorMockRecorder) QueryContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*MockIExecutor)(nil).QueryContext), varargs...)
+}
+
+// QueryRowContext mocks base method.
+func (m *MockIExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockIExecutorMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MockIExecutor)(nil).QueryRowContext), varargs...)
+}
+
+// MockITransaction is a mock of ITransaction interface.
+type MockITransaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransactionMockRecorder
+}
+
+// MockITransactionMockRecorder is the mock recorder for MockITransaction.
+type MockITransactionMockRecorder struct {
+	mock *MockITransaction
+}
+
+// NewMockITransaction creates a new mock instance.
+func NewMockITransaction(ctrl *gomock.Controller) *MockITransaction {
+	mock := &MockITransaction{ctrl: ctrl}
+	mock.recorder = &MockITransactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransaction) EXPECT() *MockITransactionMockRecorder {
+	return m.recorder
+}
+
+// ExecContext mocks base method.
+func (m *MockITransaction) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockITransactionMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MockITransaction)(nil).ExecContext), varargs...)
+}
+
+// QueryContext mocks base method.
+func (m *MockITransaction) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryContext", varargs...)
+	ret0, _ := ret[0].(*sql.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryContext indicates an expected call of QueryContext.
+func (mr *MockITransactionMockRecorder) QueryContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryContext", reflect.TypeOf((*MockITransaction)(nil).QueryContext), varargs...)
+}
+
+// QueryRowContext mocks base method.
+func (m *MockITransaction) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRowContext", varargs...)
+	ret0, _ := ret[0].(*sql.Row)
+	return ret0
+}
+
+// QueryRowContext indicates an expected call of QueryRowContext.
+func (mr *MockITransactionMockRecorder) QueryRowContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRowContext", reflect.TypeOf((*MockITransaction)(nil).QueryRowContext), varargs...)
+}
+
+// Savepoint mocks base method.
+func (m *MockITransaction) Savepoint(fn func(ITransaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Savepoint", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Savepoint indicates an expected call of Savepoint.
+func (mr *MockITransactionMockRecorder) Savepoint(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Savepoint", reflect.TypeOf((*MockITransaction)(nil).Savepoint), fn)
+}
+
+// MockIUnitOfWork is a mock of IUnitOfWork interface.
+type MockIUnitOfWork struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUnitOfWorkMockRecorder
+}
+
+// MockIUnitOfWorkMockRecorder is the mock recorder for MockIUnitOfWork.
+type MockIUnitOfWorkMockRecorder struct {
+	mock *MockIUnitOfWork
+}
+
+// NewMockIUnitOfWork creates a new mock instance.
+func NewMockIUnitOfWork(ctrl *gomock.Controller) *MockIUnitOfWork {
+	mock := &MockIUnitOfWork{ctrl: ctrl}
+	mock.recorder = &MockIUnitOfWorkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUnitOfWork) EXPECT() *MockIUnitOfWorkMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIUnitOfWork) Run(ctx context.Context, fn func(ITransaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIUnitOfWorkMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIUnitOfWork)(nil).Run), ctx, fn)
+}
+
+// RunSerializable mocks base method.
+func (m *MockIUnitOfWork) RunSerializable(ctx context.Context, fn func(ITransaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSerializable", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunSerializable indicates an expected call of RunSerializable.
+func (mr *MockIUnitOfWorkMockRecorder) RunSerializable(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSerializable", reflect.TypeOf((*MockIUnitOfWork)(nil).RunSerializable), ctx, fn)
+}
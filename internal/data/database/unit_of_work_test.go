@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitOfWorkTestSuite struct {
+	suite.Suite
+	unitOfWork *FakeUnitOfWork
+}
+
+// Run suite.
+func TestUnitOfWork(t *testing.T) {
+	suite.Run(t, new(UnitOfWorkTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *UnitOfWorkTestSuite) SetupTest() {
+	s.unitOfWork = NewFakeUnitOfWork()
+}
+
+func (s *UnitOfWorkTestSuite) TestRetry_SerializationFailure_RunsAgain() {
+	// Given
+	attempts := 0
+	run := func() error {
+		attempts++
+		if attempts == 1 {
+			return &pq.Error{Code: serializationFailure}
+		}
+		return nil
+	}
+
+	// When
+	err := retry(run)
+
+	// Then
+	s.NoError(err)
+	s.Equal(2, attempts)
+}
+
+func (s *UnitOfWorkTestSuite) TestRetry_DeadlockOnEveryAttempt_StopsAtMaxAttempts() {
+	// Given
+	attempts := 0
+	run := func() error {
+		attempts++
+		return &pq.Error{Code: deadlockDetected}
+	}
+
+	// When
+	err := retry(run)
+
+	// Then
+	s.True(isRetryable(err))
+	s.Equal(maxAttempts, attempts)
+}
+
+func (s *UnitOfWorkTestSuite) TestRetry_OtherError_DoesNotRunAgain() {
+	// Given
+	attempts := 0
+	run := func() error {
+		attempts++
+		return &pq.Error{Code: "23505"}
+	}
+
+	// When
+	err := retry(run)
+
+	// Then
+	s.Error(err)
+	s.Equal(1, attempts)
+}
+
+func (s *UnitOfWorkTestSuite) TestFakeRun_FailingSavepoint_RollsBackOnlySavepoint() {
+	// Given
+	savepointErr := errors.New("savepoint failed")
+
+	// When
+	err := s.unitOfWork.Run(context.Background(), func(tx ITransaction) error {
+		s.ErrorIs(tx.Savepoint(func(tx ITransaction) error { return savepointErr }), savepointErr)
+		return tx.Savepoint(func(tx ITransaction) error { return nil })
+	})
+
+	// Then
+	s.NoError(err)
+	s.Equal(2, s.unitOfWork.Savepoints)
+	s.Equal(1, s.unitOfWork.SavepointRollbacks)
+	s.Equal(1, s.unitOfWork.Commits)
+}
+
+func (s *UnitOfWorkTestSuite) TestFakeRun_Error_RollsBack() {
+	// Given
+	runErr := errors.New("run failed")
+
+	// When
+	err := s.unitOfWork.Run(context.Background(), func(tx ITransaction) error { return runErr })
+
+	// Then
+	s.ErrorIs(err, runErr)
+	s.Equal(1, s.unitOfWork.Rollbacks)
+	s.Equal(0, s.unitOfWork.Commits)
+}
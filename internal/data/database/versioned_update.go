@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/helper"
+)
+
+// ErrVersionMismatch
+// Returned by UpdateVersioned if the row is modified since the caller read the version it expects.
+var ErrVersionMismatch = customerror.New(fmt.Errorf("resource is modified by another request, %w", customerror.ErrPreconditionFailed), customerror.LogLevelInfo)
+
+// VersionedUpdate
+// An update of a row of a table following the version convention: an id primary key and a version integer column
+// incremented by every update, along with AuditColumns. Set assigns columns with placeholders starting from $3, whose arguments are Args.
+// Version is the version the caller expects the row to have, zero updates the row whatever its version is.
+type VersionedUpdate struct {
+	Table     string
+	Id        int64
+	Version   int
+	Set       string
+	Args      []interface{}
+	Returning string
+}
+
+// UpdateVersioned
+// Runs update on executor, increments the version of the row, records the authenticated user of ctx as who updated it
+// and scans the columns selected by Returning. Soft deleted rows are not updated.
+// Returns ErrVersionMismatch if the row has another version, or sql.ErrNoRows if there is no row with the id.
+func UpdateVersioned(ctx context.Context, executor IExecutor, update VersionedUpdate, scan func(row *sql.Row) error) error {
+	args := append([]interface{}{update.Id, update.Version}, update.Args...)
+	args = append(args, helper.GetActorId(ctx))
+
+	query := `
+	update ` + update.Table + `
+	set ` + update.Set + `, version = version + 1, updated_at = current_timestamp, updated_by = $` + strconv.Itoa(len(args)) + `
+	where id = $1 and ($2::integer = 0 or version = $2) and ` + NotDeleted + `
+	returning ` + update.Returning
+
+	err := scan(executor.QueryRowContext(ctx, query, args...))
+	if update.Version == 0 || !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	// No row is updated, either it does not exist or its version is changed.
+	var exists bool
+	err = executor.QueryRowContext(ctx, `select exists (select 1 from `+update.Table+` where id = $1 and `+NotDeleted+`)`, update.Id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return ErrVersionMismatch
+	}
+
+	return sql.ErrNoRows
+}
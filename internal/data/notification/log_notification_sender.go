@@ -0,0 +1,50 @@
+package notification
+
+import (
+	"context"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+type LogNotificationSender struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+}
+
+// NewLogNotificationSender
+// Returns a new LogNotificationSender which only writes notifications to the log.
+// Useful for local development until a real delivery channel is plugged in.
+func NewLogNotificationSender(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) INotificationSender {
+	sender := LogNotificationSender{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	return &sender
+}
+
+// Send
+// Logs notification instead of delivering it.
+func (s *LogNotificationSender) Send(ctx context.Context, ch chan *SendNotificationResponse, model *SendNotificationModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SendNotificationResponse{Error: modelErr}
+		return
+	}
+
+	s.loggr.Info("Notification is sent.",
+		zap.String("recipient", model.Recipient),
+		zap.String("subject", model.Subject),
+		zap.String("body", model.Body),
+	)
+
+	ch <- &SendNotificationResponse{}
+}
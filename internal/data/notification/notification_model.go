@@ -0,0 +1,7 @@
+package notification
+
+type SendNotificationModel struct {
+	Recipient string `validate:"required"`
+	Subject   string `validate:"required"`
+	Body      string `validate:"required"`
+}
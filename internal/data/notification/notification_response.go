@@ -0,0 +1,5 @@
+package notification
+
+type SendNotificationResponse struct {
+	Error error `json:"-"`
+}
@@ -0,0 +1,7 @@
+package notification
+
+import "context"
+
+type INotificationSender interface {
+	Send(ctx context.Context, ch chan *SendNotificationResponse, model *SendNotificationModel)
+}
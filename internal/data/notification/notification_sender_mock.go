@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/notification/notification_sender.go
+
+// Package notification is a generated GoMock package.
+package notification
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockINotificationSender is a mock of INotificationSender interface.
+type MockINotificationSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationSenderMockRecorder
+}
+
+// MockINotificationSenderMockRecorder is the mock recorder for MockINotificationSender.
+type MockINotificationSenderMockRecorder struct {
+	mock *MockINotificationSender
+}
+
+// NewMockINotificationSender creates a new mock instance.
+func NewMockINotificationSender(ctrl *gomock.Controller) *MockINotificationSender {
+	mock := &MockINotificationSender{ctrl: ctrl}
+	mock.recorder = &MockINotificationSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationSender) EXPECT() *MockINotificationSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockINotificationSender) Send(ctx context.Context, ch chan *SendNotificationResponse, model *SendNotificationModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", ctx, ch, model)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockINotificationSenderMockRecorder) Send(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockINotificationSender)(nil).Send), ctx, ch, model)
+}
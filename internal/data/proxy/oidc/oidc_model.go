@@ -0,0 +1,19 @@
+package oidc
+
+type GetDiscoveryModel struct {
+	IssuerUrl string `validate:"required,url"`
+}
+
+type GetJwksModel struct {
+	JwksUri string `validate:"required,url"`
+	Refresh bool
+}
+
+type ExchangeCodeModel struct {
+	TokenEndpoint string `validate:"required,url"`
+	ClientId      string `validate:"required"`
+	ClientSecret  string
+	Code          string `validate:"required"`
+	CodeVerifier  string `validate:"required"`
+	RedirectUri   string `validate:"required,url"`
+}
@@ -0,0 +1,238 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/keyring"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+)
+
+type IOidcProxy interface {
+	GetDiscovery(ctx context.Context, ch chan *GetDiscoveryResponse, model *GetDiscoveryModel)
+	GetJwks(ctx context.Context, ch chan *GetJwksResponse, model *GetJwksModel)
+	ExchangeCode(ctx context.Context, ch chan *ExchangeCodeResponse, model *ExchangeCodeModel)
+}
+
+type OidcProxy struct {
+	environment    env.IEnvironment
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	cachr          cacher.ICacher
+	client         *http.Client
+	timeout        time.Duration
+	cacheKeyPrefix string
+	cacheDuration  time.Duration
+}
+
+// NewOidcProxy
+// Returns a new OidcProxy.
+func NewOidcProxy(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) IOidcProxy {
+	proxy := OidcProxy{
+		environment:    environment,
+		loggr:          loggr,
+		validatr:       validatr,
+		cachr:          cachr,
+		client:         &http.Client{Timeout: time.Second * time.Duration(5)},
+		timeout:        time.Second * 5,
+		cacheKeyPrefix: "oidc",
+		cacheDuration:  time.Hour,
+	}
+	return &proxy
+}
+
+// GetDiscovery
+// Gets OpenID provider metadata of the issuer, cached for an hour.
+// Returns an error if the document is issued for another issuer.
+func (p *OidcProxy) GetDiscovery(ctx context.Context, ch chan *GetDiscoveryResponse, model *GetDiscoveryModel) {
+	modelErr := p.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetDiscoveryResponse{Error: modelErr}
+		return
+	}
+
+	cacheKey := p.cacheKeyPrefix + ":discovery:" + model.IssuerUrl
+	cached, err := p.cachr.Get(ctx, cacheKey)
+	if err != nil {
+		ch <- &GetDiscoveryResponse{Error: err}
+		return
+	}
+
+	if cached != nil {
+		var discovery GetDiscoveryResponse
+		if json.Unmarshal([]byte(*cached), &discovery) == nil {
+			ch <- &discovery
+			return
+		}
+	}
+
+	var discovery GetDiscoveryResponse
+	err = p.get(ctx, strings.TrimSuffix(model.IssuerUrl, "/")+"/.well-known/openid-configuration", &discovery)
+	if err != nil {
+		ch <- &GetDiscoveryResponse{Error: err}
+		return
+	}
+
+	if discovery.Issuer != model.IssuerUrl {
+		ch <- &GetDiscoveryResponse{Error: errors.New("discovery document is issued for another issuer: " + discovery.Issuer)}
+		return
+	}
+
+	err = p.cachr.Set(ctx, cacheKey, discovery, p.cacheDuration)
+	if err != nil {
+		ch <- &GetDiscoveryResponse{Error: err}
+		return
+	}
+
+	ch <- &discovery
+}
+
+// GetJwks
+// Gets signing keys of the issuer, cached for an hour.
+// Refresh skips the cache, it is used when a token is signed with a key which is not cached yet.
+func (p *OidcProxy) GetJwks(ctx context.Context, ch chan *GetJwksResponse, model *GetJwksModel) {
+	modelErr := p.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetJwksResponse{Error: modelErr}
+		return
+	}
+
+	cacheKey := p.cacheKeyPrefix + ":jwks:" + model.JwksUri
+	if !model.Refresh {
+		cached, err := p.cachr.Get(ctx, cacheKey)
+		if err != nil {
+			ch <- &GetJwksResponse{Error: err}
+			return
+		}
+
+		if cached != nil {
+			var jwks keyring.Jwks
+			if json.Unmarshal([]byte(*cached), &jwks) == nil {
+				ch <- &GetJwksResponse{Jwks: jwks}
+				return
+			}
+		}
+	}
+
+	var jwks keyring.Jwks
+	err := p.get(ctx, model.JwksUri, &jwks)
+	if err != nil {
+		ch <- &GetJwksResponse{Error: err}
+		return
+	}
+
+	err = p.cachr.Set(ctx, cacheKey, jwks, p.cacheDuration)
+	if err != nil {
+		ch <- &GetJwksResponse{Error: err}
+		return
+	}
+
+	ch <- &GetJwksResponse{Jwks: jwks}
+}
+
+// ExchangeCode
+// Exchanges an authorization code for tokens with the PKCE code verifier.
+// Returns an error if the provider rejects the code.
+func (p *OidcProxy) ExchangeCode(ctx context.Context, ch chan *ExchangeCodeResponse, model *ExchangeCodeModel) {
+	modelErr := p.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ExchangeCodeResponse{Error: modelErr}
+		return
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {model.Code},
+		"code_verifier": {model.CodeVerifier},
+		"redirect_uri":  {model.RedirectUri},
+		"client_id":     {model.ClientId},
+	}
+	if model.ClientSecret != "" {
+		form.Set("client_secret", model.ClientSecret)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, model.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		ch <- &ExchangeCodeResponse{Error: err}
+		return
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		ch <- &ExchangeCodeResponse{Error: err}
+		return
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		ch <- &ExchangeCodeResponse{Error: err}
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		var tokenError struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &tokenError)
+		ch <- &ExchangeCodeResponse{Error: customerror.New(
+			fmt.Errorf("authorization code is rejected: %d %s %s", response.StatusCode, tokenError.Error, tokenError.ErrorDescription),
+			customerror.LogLevelWarn,
+		)}
+		return
+	}
+
+	var tokens ExchangeCodeResponse
+	err = json.Unmarshal(body, &tokens)
+	if err != nil {
+		ch <- &ExchangeCodeResponse{Error: err}
+		return
+	}
+
+	if tokens.IdToken == "" {
+		ch <- &ExchangeCodeResponse{Error: errors.New("token response has no id_token")}
+		return
+	}
+
+	ch <- &tokens
+}
+
+// get
+// Gets a JSON document and decodes it into value.
+func (p *OidcProxy) get(ctx context.Context, documentUrl string, value interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, documentUrl, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded %d", documentUrl, response.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(value)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/proxy/oidc/oidc_proxy.go
+
+// Package oidc is a generated GoMock package.
+package oidc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOidcProxy is a mock of IOidcProxy interface.
+type MockIOidcProxy struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOidcProxyMockRecorder
+}
+
+// MockIOidcProxyMockRecorder is the mock recorder for MockIOidcProxy.
+type MockIOidcProxyMockRecorder struct {
+	mock *MockIOidcProxy
+}
+
+// NewMockIOidcProxy creates a new mock instance.
+func NewMockIOidcProxy(ctrl *gomock.Controller) *MockIOidcProxy {
+	mock := &MockIOidcProxy{ctrl: ctrl}
+	mock.recorder = &MockIOidcProxyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOidcProxy) EXPECT() *MockIOidcProxyMockRecorder {
+	return m.recorder
+}
+
+// ExchangeCode mocks base method.
+func (m *MockIOidcProxy) ExchangeCode(ctx context.Context, ch chan *ExchangeCodeResponse, model *ExchangeCodeModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ExchangeCode", ctx, ch, model)
+}
+
+// ExchangeCode indicates an expected call of ExchangeCode.
+func (mr *MockIOidcProxyMockRecorder) ExchangeCode(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCode", reflect.TypeOf((*MockIOidcProxy)(nil).ExchangeCode), ctx, ch, model)
+}
+
+// GetDiscovery mocks base method.
+func (m *MockIOidcProxy) GetDiscovery(ctx context.Context, ch chan *GetDiscoveryResponse, model *GetDiscoveryModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetDiscovery", ctx, ch, model)
+}
+
+// GetDiscovery indicates an expected call of GetDiscovery.
+func (mr *MockIOidcProxyMockRecorder) GetDiscovery(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscovery", reflect.TypeOf((*MockIOidcProxy)(nil).GetDiscovery), ctx, ch, model)
+}
+
+// GetJwks mocks base method.
+func (m *MockIOidcProxy) GetJwks(ctx context.Context, ch chan *GetJwksResponse, model *GetJwksModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetJwks", ctx, ch, model)
+}
+
+// GetJwks indicates an expected call of GetJwks.
+func (mr *MockIOidcProxyMockRecorder) GetJwks(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJwks", reflect.TypeOf((*MockIOidcProxy)(nil).GetJwks), ctx, ch, model)
+}
@@ -0,0 +1,23 @@
+package oidc
+
+import "go-clean-architecture/internal/util/keyring"
+
+type GetDiscoveryResponse struct {
+	Error                 error  `json:"-"`
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type GetJwksResponse struct {
+	Error error `json:"-"`
+	Jwks  keyring.Jwks
+}
+
+type ExchangeCodeResponse struct {
+	Error       error  `json:"-"`
+	IdToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
@@ -15,7 +15,7 @@ import (
 )
 
 type ISampleProxy interface {
-	GetGoogle(ch chan *GetSampleProxyResponse, model *GetSampleProxyModel)
+	GetGoogle(ctx context.Context, ch chan *GetSampleProxyResponse, model *GetSampleProxyModel)
 }
 
 type SampleProxy struct {
@@ -55,7 +55,7 @@ func NewSampleProxy(environment env.IEnvironment, loggr logger.ILogger, validatr
 
 // GetGoogle
 // Gets sample response from google.com.
-func (p *SampleProxy) GetGoogle(ch chan *GetSampleProxyResponse, model *GetSampleProxyModel) {
+func (p *SampleProxy) GetGoogle(ctx context.Context, ch chan *GetSampleProxyResponse, model *GetSampleProxyModel) {
 	modelErr := p.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &GetSampleProxyResponse{Error: modelErr}
@@ -63,7 +63,7 @@ func (p *SampleProxy) GetGoogle(ch chan *GetSampleProxyResponse, model *GetSampl
 	}
 
 	// You can override the default timeout here.
-	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseUrl.String()+"", nil)
 
@@ -5,6 +5,7 @@
 package sample
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,13 +35,13 @@ func (m *MockISampleProxy) EXPECT() *MockISampleProxyMockRecorder {
 }
 
 // GetGoogle mocks base method.
-func (m *MockISampleProxy) GetGoogle(ch chan *GetSampleProxyResponse, model *GetSampleProxyModel) {
+func (m *MockISampleProxy) GetGoogle(ctx context.Context, ch chan *GetSampleProxyResponse, model *GetSampleProxyModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetGoogle", ch, model)
+	m.ctrl.Call(m, "GetGoogle", ctx, ch, model)
 }
 
 // GetGoogle indicates an expected call of GetGoogle.
-func (mr *MockISampleProxyMockRecorder) GetGoogle(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleProxyMockRecorder) GetGoogle(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoogle", reflect.TypeOf((*MockISampleProxy)(nil).GetGoogle), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoogle", reflect.TypeOf((*MockISampleProxy)(nil).GetGoogle), ctx, ch, model)
 }
@@ -17,7 +17,7 @@ import (
 )
 
 type ISampleXmlProxy interface {
-	PostSampleXml(ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel)
+	PostSampleXml(ctx context.Context, ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel)
 }
 
 type SampleXmlProxy struct {
@@ -57,7 +57,7 @@ func NewSampleXmlProxy(environment env.IEnvironment, loggr logger.ILogger, valid
 
 // PostSampleXml
 // Post sample xml example
-func (p *SampleXmlProxy) PostSampleXml(ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel) {
+func (p *SampleXmlProxy) PostSampleXml(ctx context.Context, ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel) {
 	modelErr := p.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &PostSampleXmlProxyResponse{Error: modelErr}
@@ -92,7 +92,7 @@ func (p *SampleXmlProxy) PostSampleXml(ch chan *PostSampleXmlProxyResponse, mode
 	}
 
 	// You can override the default timeout here.
-	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 
 	defer cancel()
 	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseUrl.String()+"", bytes.NewReader(baseModelXmlOut))
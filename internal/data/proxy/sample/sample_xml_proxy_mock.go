@@ -5,6 +5,7 @@
 package sample
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,13 +35,13 @@ func (m *MockISampleXmlProxy) EXPECT() *MockISampleXmlProxyMockRecorder {
 }
 
 // PostSampleXml mocks base method.
-func (m *MockISampleXmlProxy) PostSampleXml(ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel) {
+func (m *MockISampleXmlProxy) PostSampleXml(ctx context.Context, ch chan *PostSampleXmlProxyResponse, model *PostSampleXmlProxyModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "PostSampleXml", ch, model)
+	m.ctrl.Call(m, "PostSampleXml", ctx, ch, model)
 }
 
 // PostSampleXml indicates an expected call of PostSampleXml.
-func (mr *MockISampleXmlProxyMockRecorder) PostSampleXml(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleXmlProxyMockRecorder) PostSampleXml(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSampleXml", reflect.TypeOf((*MockISampleXmlProxy)(nil).PostSampleXml), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSampleXml", reflect.TypeOf((*MockISampleXmlProxy)(nil).PostSampleXml), ctx, ch, model)
 }
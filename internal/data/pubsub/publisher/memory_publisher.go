@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// MemoryPublisher
+// Keeps published messages in memory instead of sending them to pub-sub, for tests and local development.
+type MemoryPublisher struct {
+	messages []MessageModel
+	failures []error
+	mutex    sync.Mutex
+}
+
+// NewMemoryPublisher
+// Returns a new MemoryPublisher.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// PublishMessage
+// Keeps the message and returns its index as message id, or the next queued failure.
+func (p *MemoryPublisher) PublishMessage(ctx context.Context, ch chan PublisherResponse, model *MessageModel) {
+	ch <- p.publish(model)
+}
+
+func (p *MemoryPublisher) publish(model *MessageModel) PublisherResponse {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.failures) > 0 {
+		err := p.failures[0]
+		p.failures = p.failures[1:]
+		return PublisherResponse{Error: err}
+	}
+
+	p.messages = append(p.messages, *model)
+	messageId := strconv.Itoa(len(p.messages))
+	return PublisherResponse{MessageId: &messageId}
+}
+
+// Fail
+// Queues an error to be returned by the next publish instead of keeping the message.
+func (p *MemoryPublisher) Fail(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.failures = append(p.failures, err)
+}
+
+// Messages
+// Returns the published messages in the order they are published.
+func (p *MemoryPublisher) Messages() []MessageModel {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]MessageModel(nil), p.messages...)
+}
@@ -0,0 +1,21 @@
+package publisher
+
+import "context"
+
+// Topics
+// Names of the topics outbox messages are added to, each relayed by the publisher registered with the same name.
+const (
+	SampleTopic = "sample"
+)
+
+type MessageModel struct {
+	Data        []byte `validate:"required"`
+	Attributes  map[string]string
+	OrderingKey string
+}
+
+// IMessagePublisher
+// Publishes already serialized messages, used by the outbox relay.
+type IMessagePublisher interface {
+	PublishMessage(ctx context.Context, ch chan PublisherResponse, model *MessageModel)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/pubsub/publisher/message_publisher.go
+
+// Package publisher is a generated GoMock package.
+package publisher
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMessagePublisher is a mock of IMessagePublisher interface.
+type MockIMessagePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMessagePublisherMockRecorder
+}
+
+// MockIMessagePublisherMockRecorder is the mock recorder for MockIMessagePublisher.
+type MockIMessagePublisherMockRecorder struct {
+	mock *MockIMessagePublisher
+}
+
+// NewMockIMessagePublisher creates a new mock instance.
+func NewMockIMessagePublisher(ctrl *gomock.Controller) *MockIMessagePublisher {
+	mock := &MockIMessagePublisher{ctrl: ctrl}
+	mock.recorder = &MockIMessagePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMessagePublisher) EXPECT() *MockIMessagePublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishMessage mocks base method.
+func (m *MockIMessagePublisher) PublishMessage(ctx context.Context, ch chan PublisherResponse, model *MessageModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishMessage", ctx, ch, model)
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockIMessagePublisherMockRecorder) PublishMessage(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockIMessagePublisher)(nil).PublishMessage), ctx, ch, model)
+}
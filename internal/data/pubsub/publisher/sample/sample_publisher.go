@@ -18,7 +18,8 @@ import (
 )
 
 type ISamplePublisher interface {
-	Publish(ch chan publisher.PublisherResponse, message *SamplePublisherModel, attributeOverrides map[string]string)
+	Publish(ctx context.Context, ch chan publisher.PublisherResponse, message *SamplePublisherModel, attributeOverrides map[string]string)
+	PublishMessage(ctx context.Context, ch chan publisher.PublisherResponse, model *publisher.MessageModel)
 }
 
 type SamplePublisher struct {
@@ -55,7 +56,7 @@ func NewSamplePublisher(environment env.IEnvironment, loggr logger.ILogger, vali
 	return &publisher
 }
 
-func (p *SamplePublisher) Publish(ch chan publisher.PublisherResponse, model *SamplePublisherModel, attributeOverrides map[string]string) {
+func (p *SamplePublisher) Publish(ctx context.Context, ch chan publisher.PublisherResponse, model *SamplePublisherModel, attributeOverrides map[string]string) {
 	err := p.validatr.ValidateStruct(model)
 	if err != nil {
 		p.loggr.Error(err.Error())
@@ -63,46 +64,62 @@ func (p *SamplePublisher) Publish(ch chan publisher.PublisherResponse, model *Sa
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
-	defer cancel()
-
-	saJson, err := base64.StdEncoding.DecodeString(p.environment.Get(env.SamplePublisherSaJson))
-	if err != nil {
-		p.loggr.Panic("Panicked while decoding SamplePublisherSaJson base64.")
-	}
-
-	client, err := pubsub.NewClient(ctx, p.projectId, option.WithCredentialsJSON(saJson))
+	bytes, err := json.Marshal(model)
 	if err != nil {
 		p.loggr.Error(err.Error())
 		ch <- publisher.PublisherResponse{Error: err}
 		return
 	}
-	defer client.Close()
 
-	bytes, err := json.Marshal(model)
+	ch <- p.publish(ctx, &publisher.MessageModel{Data: bytes, Attributes: attributeOverrides})
+}
+
+// PublishMessage
+// Publishes a message serialized by the outbox with default attributes overridden by its attributes.
+// Messages with an ordering key are delivered in order to subscriptions with message ordering enabled.
+func (p *SamplePublisher) PublishMessage(ctx context.Context, ch chan publisher.PublisherResponse, model *publisher.MessageModel) {
+	err := p.validatr.ValidateStruct(model)
 	if err != nil {
 		p.loggr.Error(err.Error())
 		ch <- publisher.PublisherResponse{Error: err}
 		return
 	}
 
-	attributes := p.overrideAttributes(attributeOverrides)
+	ch <- p.publish(ctx, model)
+}
+
+func (p *SamplePublisher) publish(ctx context.Context, model *publisher.MessageModel) publisher.PublisherResponse {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	saJson, err := base64.StdEncoding.DecodeString(p.environment.Get(env.SamplePublisherSaJson))
+	if err != nil {
+		p.loggr.Panic("Panicked while decoding SamplePublisherSaJson base64.")
+	}
+
+	client, err := pubsub.NewClient(ctx, p.projectId, option.WithCredentialsJSON(saJson))
+	if err != nil {
+		p.loggr.Error(err.Error())
+		return publisher.PublisherResponse{Error: err}
+	}
+	defer client.Close()
 
 	topic := client.Topic(p.topicId)
+	topic.EnableMessageOrdering = model.OrderingKey != ""
 	result := topic.Publish(ctx, &pubsub.Message{
-		Data:       bytes,
-		Attributes: attributes,
+		Data:        model.Data,
+		Attributes:  p.overrideAttributes(model.Attributes),
+		OrderingKey: model.OrderingKey,
 	})
 
 	messageId, err := result.Get(ctx)
 	if err != nil {
 		p.loggr.Error(err.Error())
-		ch <- publisher.PublisherResponse{Error: err}
-		return
+		return publisher.PublisherResponse{Error: err}
 	}
 
 	p.loggr.Info(messageId+" ID message is published successfully.", zap.String("messageId", messageId))
-	ch <- publisher.PublisherResponse{MessageId: &messageId}
+	return publisher.PublisherResponse{MessageId: &messageId}
 }
 
 func (p *SamplePublisher) overrideAttributes(overrides map[string]string) map[string]string {
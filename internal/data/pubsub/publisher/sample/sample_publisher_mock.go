@@ -5,6 +5,7 @@
 package sample
 
 import (
+	context "context"
 	publisher "go-clean-architecture/internal/data/pubsub/publisher"
 	reflect "reflect"
 
@@ -35,13 +36,25 @@ func (m *MockISamplePublisher) EXPECT() *MockISamplePublisherMockRecorder {
 }
 
 // Publish mocks base method.
-func (m *MockISamplePublisher) Publish(ch chan publisher.PublisherResponse, message *SamplePublisherModel, attributeOverrides map[string]string) {
+func (m *MockISamplePublisher) Publish(ctx context.Context, ch chan publisher.PublisherResponse, message *SamplePublisherModel, attributeOverrides map[string]string) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Publish", ch, message, attributeOverrides)
+	m.ctrl.Call(m, "Publish", ctx, ch, message, attributeOverrides)
 }
 
 // Publish indicates an expected call of Publish.
-func (mr *MockISamplePublisherMockRecorder) Publish(ch, message, attributeOverrides interface{}) *gomock.Call {
+func (mr *MockISamplePublisherMockRecorder) Publish(ctx, ch, message, attributeOverrides interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockISamplePublisher)(nil).Publish), ch, message, attributeOverrides)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockISamplePublisher)(nil).Publish), ctx, ch, message, attributeOverrides)
+}
+
+// PublishMessage mocks base method.
+func (m *MockISamplePublisher) PublishMessage(ctx context.Context, ch chan publisher.PublisherResponse, model *publisher.MessageModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishMessage", ctx, ch, model)
+}
+
+// PublishMessage indicates an expected call of PublishMessage.
+func (mr *MockISamplePublisherMockRecorder) PublishMessage(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockISamplePublisher)(nil).PublishMessage), ctx, ch, model)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	receiver2 "go-clean-architecture/internal/data/pubsub/receiver"
 	"strconv"
+	"sync"
 	"time"
 
 	"google.golang.org/api/option"
@@ -12,6 +13,7 @@ import (
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/requestcontext"
 	"go-clean-architecture/internal/util/validator"
 
 	"cloud.google.com/go/pubsub"
@@ -20,6 +22,7 @@ import (
 
 type ISampleReceiver interface {
 	InitReceivers(count int)
+	Shutdown(ctx context.Context) error
 }
 
 type SampleReceiver struct {
@@ -34,6 +37,9 @@ type SampleReceiver struct {
 	messageCacheKeyPrefix string
 	handler               ISampleReceiverHandler
 	receiverName          string
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	waitGroup             sync.WaitGroup
 }
 
 // NewSampleReceiver
@@ -42,6 +48,7 @@ func NewSampleReceiver(environment env.IEnvironment, loggr logger.ILogger, valid
 	projectId := environment.Get(env.SampleReceiverProjectId)
 	subscriptionId := environment.Get(env.SampleReceiverSubscriptionId)
 	receiverName := "SampleReceiver"
+	ctx, cancel := context.WithCancel(context.Background())
 
 	receiver := SampleReceiver{
 		environment: environment,
@@ -58,6 +65,8 @@ func NewSampleReceiver(environment env.IEnvironment, loggr logger.ILogger, valid
 		defaultAttributes:     map[string]string{receiver2.DummyAttribute: "dummy_attribute_value"},
 		messageCacheKeyPrefix: "sample-receiver-message-id",
 		receiverName:          receiverName,
+		ctx:                   ctx,
+		cancel:                cancel,
 	}
 
 	if handler != nil {
@@ -73,16 +82,47 @@ func NewSampleReceiver(environment env.IEnvironment, loggr logger.ILogger, valid
 // Initializes multiple receivers that listen given topic for new messages.
 func (r *SampleReceiver) InitReceivers(count int) {
 	r.loggr.Info(r.receiverName + " Initializing receivers.")
+	r.waitGroup.Add(count)
 	for i := 0; i < count; i++ {
-		go r.receive()
+		go func() {
+			defer r.waitGroup.Done()
+			r.receive()
+		}()
 	}
 	r.loggr.Info(r.receiverName + " Initialized " + strconv.Itoa(count) + " receivers.")
 }
 
+// Shutdown
+// Stops receiving new messages and waits until messages in progress are processed or ctx is done.
+// Messages which are not processed are redelivered by pub sub.
+func (r *SampleReceiver) Shutdown(ctx context.Context) error {
+	r.loggr.Info(r.receiverName + " Shutting down receivers.")
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.waitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		r.loggr.Info(r.receiverName + " Receivers are shut down.")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (r *SampleReceiver) receive() {
-	// Register another receiver if one of them fails.
+	// Register another receiver if one of them fails, unless receivers are shutting down.
 	defer func() {
 		if rec := recover(); rec != nil {
+			if r.ctx.Err() != nil {
+				r.loggr.Error(r.receiverName+" Recovered the panic while shutting down.", zap.Any("panic", rec))
+				return
+			}
+
 			r.loggr.Error(r.receiverName+" Recovered the panic. Trying to receive again.", zap.Any("panic", rec))
 			r.receive()
 		}
@@ -93,21 +133,27 @@ func (r *SampleReceiver) receive() {
 		r.loggr.Panic(r.receiverName + " Panicked while SampleReceiverSaJson decoding base64.")
 	}
 
-	ctx := context.Background()
-	client, err := pubsub.NewClient(ctx, r.projectId, option.WithCredentialsJSON(saJson))
+	client, err := pubsub.NewClient(r.ctx, r.projectId, option.WithCredentialsJSON(saJson))
 	if err != nil {
 		r.loggr.Panic(r.receiverName + " Panicked while creating pub sub client.")
 	}
 	defer client.Close()
 
 	subscription := client.Subscription(r.subscriptionId)
-	err = subscription.Receive(ctx, r.eventHandler)
+	// Receive returns after ctx is cancelled and handlers of outstanding messages return.
+	err = subscription.Receive(r.ctx, r.eventHandler)
 	if err != nil {
 		r.loggr.Panic(r.receiverName + " Panicked while receiving messages from subscription.")
 	}
 }
 
-func (r *SampleReceiver) eventHandler(ctx context.Context, msg *pubsub.Message) {
+// eventHandler
+// Processes a message with a context carrying the message id as request id. The context is not derived from the one
+// of Receive, which is cancelled on shutdown, so messages in progress are processed before receivers stop.
+func (r *SampleReceiver) eventHandler(_ context.Context, msg *pubsub.Message) {
+	ctx, cancel := context.WithTimeout(requestcontext.WithRequestId(context.Background(), msg.ID), r.timeout)
+	defer cancel()
+
 	defer func() {
 		if rec := recover(); rec != nil {
 			r.loggr.Error(r.receiverName+" "+msg.ID+" ID message is panicked during execution in event handler.",
@@ -120,7 +166,16 @@ func (r *SampleReceiver) eventHandler(ctx context.Context, msg *pubsub.Message)
 	}()
 
 	// Cache message id for two days to prevent duplication.
-	existingMessageId := r.cachr.Get(r.getMessageCacheKey(msg.ID))
+	existingMessageId, err := r.cachr.Get(ctx, r.getMessageCacheKey(msg.ID))
+	if err != nil {
+		// Message is not acked, so it is redelivered once the cache is available.
+		r.loggr.Error(r.receiverName+" "+msg.ID+" ID message is failed to check for duplication.",
+			zap.String("messageId", msg.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
 	if existingMessageId != nil {
 		r.loggr.Error(r.receiverName+" "+msg.ID+" ID message is duplicate.",
 			zap.String("messageId", msg.ID),
@@ -134,13 +189,13 @@ func (r *SampleReceiver) eventHandler(ctx context.Context, msg *pubsub.Message)
 
 	ch := make(chan error)
 	defer close(ch)
-	go r.handler.Handle(ch, &receiver2.ReceiverHandlerModel{
+	go r.handler.Handle(ctx, ch, &receiver2.ReceiverHandlerModel{
 		MessageId:  msg.ID,
 		Data:       msg.Data,
 		Attributes: msg.Attributes,
 	})
 
-	err := <-ch
+	err = <-ch
 	if err != nil {
 		r.loggr.Error(r.receiverName+" "+msg.ID+" ID message is failed to process.",
 			zap.String("messageId", msg.ID),
@@ -153,7 +208,14 @@ func (r *SampleReceiver) eventHandler(ctx context.Context, msg *pubsub.Message)
 
 	// Success
 	msg.Ack()
-	r.cachr.Set(r.getMessageCacheKey(msg.ID), "", time.Hour*24*2)
+	err = r.cachr.Set(ctx, r.getMessageCacheKey(msg.ID), "", time.Hour*24*2)
+	if err != nil {
+		// Message is processed already, it may only be processed again if it is redelivered.
+		r.loggr.Error(r.receiverName+" "+msg.ID+" ID message is failed to cache.",
+			zap.String("messageId", msg.ID),
+			zap.Error(err),
+		)
+	}
 	r.loggr.Info(r.receiverName+" "+msg.ID+" ID message is processed successfully.",
 		zap.String("messageId", msg.ID),
 		zap.String("data", string(msg.Data)),
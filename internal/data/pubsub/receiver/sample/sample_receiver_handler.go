@@ -1,7 +1,9 @@
 package sample
 
 import (
+	"context"
 	"encoding/json"
+	"go-clean-architecture/internal/data/pubsub/publisher"
 	"go-clean-architecture/internal/data/pubsub/receiver"
 	"go-clean-architecture/internal/service/sample"
 
@@ -12,7 +14,7 @@ import (
 )
 
 type ISampleReceiverHandler interface {
-	Handle(ch chan error, model *receiver.ReceiverHandlerModel)
+	Handle(ctx context.Context, ch chan error, model *receiver.ReceiverHandlerModel)
 }
 
 type SampleReceiverHandler struct {
@@ -36,7 +38,7 @@ func NewSampleReceiverHandler(environment env.IEnvironment, loggr logger.ILogger
 	if sampleService != nil {
 		handler.sampleService = sampleService
 	} else {
-		handler.sampleService = sample.NewSampleService(environment, loggr, validatr, cachr, nil, nil, nil, nil)
+		handler.sampleService = sample.NewSampleService(environment, loggr, validatr, cachr, nil, nil, nil, nil, nil)
 	}
 
 	return &handler
@@ -44,13 +46,19 @@ func NewSampleReceiverHandler(environment env.IEnvironment, loggr logger.ILogger
 
 // Handle
 // Process events & messages and handle necessary logic/business actions.
-func (h *SampleReceiverHandler) Handle(ch chan error, model *receiver.ReceiverHandlerModel) {
+// Sample events published by the outbox share the topic, they are acknowledged without updating the sample again.
+func (h *SampleReceiverHandler) Handle(ctx context.Context, ch chan error, model *receiver.ReceiverHandlerModel) {
 	err := h.validatr.ValidateStruct(model)
 	if err != nil {
 		ch <- err
 		return
 	}
 
+	if _, isEvent := model.Attributes[publisher.EventType]; isEvent {
+		ch <- nil
+		return
+	}
+
 	var handlerModel SampleReceiverHandlerModel
 	err = json.Unmarshal(model.Data, &handlerModel)
 	if err != nil {
@@ -64,9 +72,9 @@ func (h *SampleReceiverHandler) Handle(ch chan error, model *receiver.ReceiverHa
 		return
 	}
 
-	sampleCh := make(chan *sample.UpdateSampleServiceResponse)
+	sampleCh := make(chan *sample.SampleServiceResponse)
 	defer close(sampleCh)
-	go h.sampleService.UpdateSample(sampleCh, &sample.UpdateSampleServiceModel{
+	go h.sampleService.UpdateSample(ctx, sampleCh, &sample.UpdateSampleServiceModel{
 		SampleId:     handlerModel.SampleId,
 		SampleStatus: handlerModel.SampleStatus,
 		ModifiedBy:   handlerModel.ModifiedBy,
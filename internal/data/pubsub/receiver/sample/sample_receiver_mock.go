@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/pubsub/receiver/sample/sample_receiver.go
+
+// Package sample is a generated GoMock package.
+package sample
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISampleReceiver is a mock of ISampleReceiver interface.
+type MockISampleReceiver struct {
+	ctrl     *gomock.Controller
+	recorder *MockISampleReceiverMockRecorder
+}
+
+// MockISampleReceiverMockRecorder is the mock recorder for MockISampleReceiver.
+type MockISampleReceiverMockRecorder struct {
+	mock *MockISampleReceiver
+}
+
+// NewMockISampleReceiver creates a new mock instance.
+func NewMockISampleReceiver(ctrl *gomock.Controller) *MockISampleReceiver {
+	mock := &MockISampleReceiver{ctrl: ctrl}
+	mock.recorder = &MockISampleReceiverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISampleReceiver) EXPECT() *MockISampleReceiverMockRecorder {
+	return m.recorder
+}
+
+// InitReceivers mocks base method.
+func (m *MockISampleReceiver) InitReceivers(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InitReceivers", count)
+}
+
+// InitReceivers indicates an expected call of InitReceivers.
+func (mr *MockISampleReceiverMockRecorder) InitReceivers(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitReceivers", reflect.TypeOf((*MockISampleReceiver)(nil).InitReceivers), count)
+}
+
+// Shutdown mocks base method.
+func (m *MockISampleReceiver) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockISampleReceiverMockRecorder) Shutdown(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockISampleReceiver)(nil).Shutdown), ctx)
+}
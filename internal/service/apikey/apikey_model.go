@@ -0,0 +1,24 @@
+package apikey
+
+import "time"
+
+type AddApiKeyServiceModel struct {
+	UserId     int64    `validate:"required"`
+	Name       string   `validate:"required,max=100"`
+	Scopes     []string `validate:"required,min=1,dive,required"`
+	ExpiryDate *time.Time
+	CreatedBy  int64 `validate:"required"`
+}
+
+type GetApiKeysServiceModel struct {
+	UserId int64
+}
+
+type RevokeApiKeyServiceModel struct {
+	Id        int64 `validate:"required"`
+	RevokedBy int64 `validate:"required"`
+}
+
+type AuthenticateServiceModel struct {
+	ApiKey string `validate:"required"`
+}
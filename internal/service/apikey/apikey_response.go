@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"go-clean-architecture/internal/data/database/apikey"
+)
+
+type AddApiKeyServiceResponse struct {
+	Error  error `json:"-"`
+	Id     int64
+	ApiKey string
+	Prefix string
+}
+
+type GetApiKeysServiceResponse struct {
+	Error   error `json:"-"`
+	ApiKeys []apikey.ApiKey
+}
+
+type RevokeApiKeyServiceResponse struct {
+	Error     error `json:"-"`
+	IsRevoked bool
+}
+
+type AuthenticateServiceResponse struct {
+	Error       error `json:"-"`
+	ApiKeyId    int64
+	UserId      int64
+	UserName    string
+	Email       string
+	Permissions []string
+}
@@ -0,0 +1,338 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"go-clean-architecture/internal/data/database/apikey"
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// Api keys are formatted as <keyPrefix>_<prefix>_<secret>.
+// Prefix is stored in plain text to find the key and to tell keys apart in listings and logs.
+const keyPrefix = "gca"
+
+var errInvalidApiKey = customerror.New(errors.New("api key is invalid"), customerror.LogLevelInfo)
+
+type IApiKeyService interface {
+	AddApiKey(ctx context.Context, ch chan *AddApiKeyServiceResponse, model *AddApiKeyServiceModel)
+	GetApiKeys(ctx context.Context, ch chan *GetApiKeysServiceResponse, model *GetApiKeysServiceModel)
+	RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyServiceResponse, model *RevokeApiKeyServiceModel)
+	Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel)
+}
+
+type ApiKeyService struct {
+	environment        env.IEnvironment
+	loggr              logger.ILogger
+	validatr           validator.IValidator
+	cachr              cacher.ICacher
+	apiKeyDb           apikey.IApiKeyDb
+	authDb             auth.IAuthDb
+	prefixByteLength   int
+	secretByteLength   int
+	lastUsedResolution time.Duration
+}
+
+// NewApiKeyService
+// Returns a new ApiKeyService.
+func NewApiKeyService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	apiKeyDb apikey.IApiKeyDb,
+	authDb auth.IAuthDb,
+) IApiKeyService {
+	service := ApiKeyService{
+		environment:        environment,
+		loggr:              loggr,
+		validatr:           validatr,
+		cachr:              cachr,
+		prefixByteLength:   6,
+		secretByteLength:   32,
+		lastUsedResolution: time.Minute,
+	}
+
+	if apiKeyDb != nil {
+		service.apiKeyDb = apiKeyDb
+	} else {
+		service.apiKeyDb = apikey.NewApiKeyDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if authDb != nil {
+		service.authDb = authDb
+	} else {
+		service.authDb = auth.NewAuthDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	return &service
+}
+
+// AddApiKey
+// Issues an api key for a programmatic user. Plain key is only returned here, only its hash is stored.
+// Returns an error if user is not an active programmatic user or scopes are not granted to the user.
+func (s *ApiKeyService) AddApiKey(ctx context.Context, ch chan *AddApiKeyServiceResponse, model *AddApiKeyServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AddApiKeyServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	if model.ExpiryDate != nil && !model.ExpiryDate.After(time.Now()) {
+		ch <- &AddApiKeyServiceResponse{Error: customerror.New(errors.New("expiry date must be in the future"), customerror.LogLevelInfo)}
+		return
+	}
+
+	permissions, err := s.getPermissions(ctx, model.UserId)
+	if err != nil {
+		ch <- &AddApiKeyServiceResponse{Error: err}
+		return
+	}
+
+	for _, scope := range model.Scopes {
+		if !helper.Contains(permissions, scope) {
+			ch <- &AddApiKeyServiceResponse{Error: customerror.New(errors.New("scope is not granted to the user: "+scope), customerror.LogLevelInfo)}
+			return
+		}
+	}
+
+	key, prefix, err := s.generateKey()
+	if err != nil {
+		ch <- &AddApiKeyServiceResponse{Error: err}
+		return
+	}
+
+	chAddApiKeyResponse := make(chan *apikey.AddApiKeyResponse)
+	defer close(chAddApiKeyResponse)
+
+	go s.apiKeyDb.AddApiKey(
+		ctx, chAddApiKeyResponse, &apikey.AddApiKeyModel{
+			UserId:     model.UserId,
+			Name:       model.Name,
+			Prefix:     prefix,
+			KeyHash:    hasher.HashToken(key),
+			Scopes:     model.Scopes,
+			ExpiryDate: model.ExpiryDate,
+			CreatedBy:  model.CreatedBy,
+		},
+	)
+
+	addApiKeyResponse := <-chAddApiKeyResponse
+	if errors.Is(addApiKeyResponse.Error, sql.ErrNoRows) {
+		ch <- &AddApiKeyServiceResponse{Error: customerror.New(errors.New("programmatic user is not found"), customerror.LogLevelInfo)}
+		return
+	}
+	if addApiKeyResponse.Error != nil {
+		ch <- &AddApiKeyServiceResponse{Error: addApiKeyResponse.Error}
+		return
+	}
+
+	s.loggr.Info("Api key is issued.",
+		zap.Int64("apiKeyId", addApiKeyResponse.Id),
+		zap.String("prefix", prefix),
+		zap.Int64("userId", model.UserId),
+		zap.Int64("createdBy", model.CreatedBy),
+	)
+
+	ch <- &AddApiKeyServiceResponse{
+		Id:     addApiKeyResponse.Id,
+		ApiKey: key,
+		Prefix: prefix,
+	}
+}
+
+// GetApiKeys
+// Gets api keys, optionally filtered by user.
+func (s *ApiKeyService) GetApiKeys(ctx context.Context, ch chan *GetApiKeysServiceResponse, model *GetApiKeysServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetApiKeysServiceResponse{Error: modelErr}
+		return
+	}
+
+	chGetApiKeysResponse := make(chan *apikey.GetApiKeysResponse)
+	defer close(chGetApiKeysResponse)
+
+	go s.apiKeyDb.GetApiKeys(ctx, chGetApiKeysResponse, &apikey.GetApiKeysModel{UserId: model.UserId})
+
+	getApiKeysResponse := <-chGetApiKeysResponse
+	if getApiKeysResponse.Error != nil {
+		ch <- &GetApiKeysServiceResponse{Error: getApiKeysResponse.Error}
+		return
+	}
+
+	ch <- &GetApiKeysServiceResponse{ApiKeys: getApiKeysResponse.ApiKeys}
+}
+
+// RevokeApiKey
+// Revokes an api key, requests with it are rejected immediately.
+// Returns an error if key is not found or already revoked.
+func (s *ApiKeyService) RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyServiceResponse, model *RevokeApiKeyServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeApiKeyServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRevokeApiKeyResponse := make(chan *apikey.RevokeApiKeyResponse)
+	defer close(chRevokeApiKeyResponse)
+
+	go s.apiKeyDb.RevokeApiKey(
+		ctx, chRevokeApiKeyResponse, &apikey.RevokeApiKeyModel{
+			Id:        model.Id,
+			RevokedBy: model.RevokedBy,
+		},
+	)
+
+	revokeApiKeyResponse := <-chRevokeApiKeyResponse
+	if errors.Is(revokeApiKeyResponse.Error, sql.ErrNoRows) {
+		ch <- &RevokeApiKeyServiceResponse{Error: customerror.New(errors.New("api key is not found"), customerror.LogLevelInfo)}
+		return
+	}
+	if revokeApiKeyResponse.Error != nil {
+		ch <- &RevokeApiKeyServiceResponse{Error: revokeApiKeyResponse.Error}
+		return
+	}
+
+	s.loggr.Info("Api key is revoked.", zap.Int64("apiKeyId", model.Id), zap.Int64("revokedBy", model.RevokedBy))
+
+	ch <- &RevokeApiKeyServiceResponse{IsRevoked: true}
+}
+
+// Authenticate
+// Verifies an api key and returns its user with the permissions it is scoped to.
+// Scopes are intersected with current permissions of the user, so a key never outlives a revoked permission.
+func (s *ApiKeyService) Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AuthenticateServiceResponse{Error: errInvalidApiKey}
+		return
+	}
+
+	prefix, ok := parsePrefix(model.ApiKey)
+	if !ok {
+		ch <- &AuthenticateServiceResponse{Error: errInvalidApiKey}
+		return
+	}
+
+	chGetApiKeyByPrefixResponse := make(chan *apikey.GetApiKeyByPrefixResponse)
+	defer close(chGetApiKeyByPrefixResponse)
+
+	go s.apiKeyDb.GetApiKeyByPrefix(ctx, chGetApiKeyByPrefixResponse, &apikey.GetApiKeyByPrefixModel{Prefix: prefix})
+
+	storedKey := <-chGetApiKeyByPrefixResponse
+	if errors.Is(storedKey.Error, sql.ErrNoRows) {
+		ch <- &AuthenticateServiceResponse{Error: errInvalidApiKey}
+		return
+	}
+	if storedKey.Error != nil {
+		ch <- &AuthenticateServiceResponse{Error: storedKey.Error}
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hasher.HashToken(model.ApiKey)), []byte(storedKey.KeyHash)) != 1 {
+		ch <- &AuthenticateServiceResponse{Error: errInvalidApiKey}
+		return
+	}
+
+	userPermissions, err := s.getPermissions(ctx, storedKey.UserId)
+	if err != nil {
+		ch <- &AuthenticateServiceResponse{Error: err}
+		return
+	}
+
+	permissions := []string{}
+	for _, scope := range storedKey.Scopes {
+		if helper.Contains(userPermissions, scope) {
+			permissions = append(permissions, scope)
+		}
+	}
+
+	if storedKey.LastUsedDate == nil || time.Since(*storedKey.LastUsedDate) > s.lastUsedResolution {
+		s.updateLastUsedDate(ctx, storedKey.Id)
+	}
+
+	ch <- &AuthenticateServiceResponse{
+		ApiKeyId:    storedKey.Id,
+		UserId:      storedKey.UserId,
+		UserName:    storedKey.UserName,
+		Email:       storedKey.Email,
+		Permissions: permissions,
+	}
+}
+
+// getPermissions
+// Gets current permission names of the user.
+func (s *ApiKeyService) getPermissions(ctx context.Context, userId int64) ([]string, error) {
+	chGetUserPermissionsResponse := make(chan *auth.GetUserPermissionsResponse)
+	defer close(chGetUserPermissionsResponse)
+
+	go s.authDb.GetUserPermissions(ctx, chGetUserPermissionsResponse, &auth.GetUserPermissionsModel{UserId: userId})
+
+	getUserPermissionsResponse := <-chGetUserPermissionsResponse
+	if getUserPermissionsResponse.Error != nil {
+		return nil, getUserPermissionsResponse.Error
+	}
+
+	return getUserPermissionsResponse.Permissions, nil
+}
+
+// updateLastUsedDate
+// Tracks usage of the api key. Failures are only logged since the request is already authenticated.
+func (s *ApiKeyService) updateLastUsedDate(ctx context.Context, id int64) {
+	chUpdateLastUsedDateResponse := make(chan *apikey.UpdateLastUsedDateResponse)
+	defer close(chUpdateLastUsedDateResponse)
+
+	go s.apiKeyDb.UpdateLastUsedDate(ctx, chUpdateLastUsedDateResponse, &apikey.UpdateLastUsedDateModel{Id: id})
+
+	updateLastUsedDateResponse := <-chUpdateLastUsedDateResponse
+	if updateLastUsedDateResponse.Error != nil {
+		s.loggr.Error("Api key last used date could not be updated.", zap.Int64("apiKeyId", id), zap.Error(updateLastUsedDateResponse.Error))
+	}
+}
+
+// generateKey
+// Returns a new random api key and its prefix.
+func (s *ApiKeyService) generateKey() (string, string, error) {
+	prefixBytes := make([]byte, s.prefixByteLength)
+	_, err := rand.Read(prefixBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	secretBytes := make([]byte, s.secretByteLength)
+	_, err = rand.Read(secretBytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	return keyPrefix + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes), prefix, nil
+}
+
+// parsePrefix
+// Returns prefix part of an api key.
+func parsePrefix(key string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/apikey/apikey_service.go
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIApiKeyService is a mock of IApiKeyService interface.
+type MockIApiKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIApiKeyServiceMockRecorder
+}
+
+// MockIApiKeyServiceMockRecorder is the mock recorder for MockIApiKeyService.
+type MockIApiKeyServiceMockRecorder struct {
+	mock *MockIApiKeyService
+}
+
+// NewMockIApiKeyService creates a new mock instance.
+func NewMockIApiKeyService(ctrl *gomock.Controller) *MockIApiKeyService {
+	mock := &MockIApiKeyService{ctrl: ctrl}
+	mock.recorder = &MockIApiKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIApiKeyService) EXPECT() *MockIApiKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// AddApiKey mocks base method.
+func (m *MockIApiKeyService) AddApiKey(ctx context.Context, ch chan *AddApiKeyServiceResponse, model *AddApiKeyServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddApiKey", ctx, ch, model)
+}
+
+// AddApiKey indicates an expected call of AddApiKey.
+func (mr *MockIApiKeyServiceMockRecorder) AddApiKey(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddApiKey", reflect.TypeOf((*MockIApiKeyService)(nil).AddApiKey), ctx, ch, model)
+}
+
+// Authenticate mocks base method.
+func (m *MockIApiKeyService) Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Authenticate", ctx, ch, model)
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIApiKeyServiceMockRecorder) Authenticate(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIApiKeyService)(nil).Authenticate), ctx, ch, model)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockIApiKeyService) GetApiKeys(ctx context.Context, ch chan *GetApiKeysServiceResponse, model *GetApiKeysServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetApiKeys", ctx, ch, model)
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockIApiKeyServiceMockRecorder) GetApiKeys(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockIApiKeyService)(nil).GetApiKeys), ctx, ch, model)
+}
+
+// RevokeApiKey mocks base method.
+func (m *MockIApiKeyService) RevokeApiKey(ctx context.Context, ch chan *RevokeApiKeyServiceResponse, model *RevokeApiKeyServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeApiKey", ctx, ch, model)
+}
+
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockIApiKeyServiceMockRecorder) RevokeApiKey(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockIApiKeyService)(nil).RevokeApiKey), ctx, ch, model)
+}
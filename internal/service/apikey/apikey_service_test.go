@@ -0,0 +1,199 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/apikey"
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testApiKey = "gca_0123456789ab_c2VjcmV0"
+
+type ApiKeyServiceTestSuite struct {
+	suite.Suite
+	apiKeyService   IApiKeyService
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockApiKeyDb    *apikey.MockIApiKeyDb
+	mockAuthDb      *auth.MockIAuthDb
+}
+
+// Run suite.
+func TestApiKeyService(t *testing.T) {
+	suite.Run(t, new(ApiKeyServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *ApiKeyServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockApiKeyDb = apikey.NewMockIApiKeyDb(ctrl)
+	s.mockAuthDb = auth.NewMockIAuthDb(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.apiKeyService = NewApiKeyService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockApiKeyDb, s.mockAuthDb)
+}
+
+func (s *ApiKeyServiceTestSuite) expectUserPermissions(permissions ...string) {
+	s.mockAuthDb.
+		EXPECT().
+		GetUserPermissions(gomock.Any(), gomock.Any(), gomock.Eq(&auth.GetUserPermissionsModel{UserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.GetUserPermissionsResponse, model *auth.GetUserPermissionsModel) {
+			ch <- &auth.GetUserPermissionsResponse{Permissions: permissions}
+		})
+}
+
+func (s *ApiKeyServiceTestSuite) expectStoredKey(keyHash string, lastUsedDate *time.Time) {
+	s.mockApiKeyDb.
+		EXPECT().
+		GetApiKeyByPrefix(gomock.Any(), gomock.Any(), gomock.Eq(&apikey.GetApiKeyByPrefixModel{Prefix: "0123456789ab"})).
+		DoAndReturn(func(ctx context.Context, ch chan *apikey.GetApiKeyByPrefixResponse, model *apikey.GetApiKeyByPrefixModel) {
+			ch <- &apikey.GetApiKeyByPrefixResponse{
+				Id:           7,
+				KeyHash:      keyHash,
+				Scopes:       []string{"samples:read", "samples:write"},
+				LastUsedDate: lastUsedDate,
+				UserId:       1,
+				UserName:     "robot",
+			}
+		})
+}
+
+func (s *ApiKeyServiceTestSuite) authenticate(key string) *AuthenticateServiceResponse {
+	ch := make(chan *AuthenticateServiceResponse)
+	defer close(ch)
+	go s.apiKeyService.Authenticate(context.Background(), ch, &AuthenticateServiceModel{ApiKey: key})
+	return <-ch
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_ValidKey_ReturnsScopesGrantedToUser() {
+	// Given
+	lastUsedDate := time.Now()
+	s.expectStoredKey(hasher.HashToken(testApiKey), &lastUsedDate)
+	s.expectUserPermissions("samples:read")
+
+	// When
+	response := s.authenticate(testApiKey)
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(7), response.ApiKeyId)
+	s.Equal(int64(1), response.UserId)
+	s.Equal("robot", response.UserName)
+	s.Equal([]string{"samples:read"}, response.Permissions)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_StaleLastUsedDate_IsUpdated() {
+	// Given
+	s.expectStoredKey(hasher.HashToken(testApiKey), nil)
+	s.expectUserPermissions("samples:read")
+	s.mockApiKeyDb.
+		EXPECT().
+		UpdateLastUsedDate(gomock.Any(), gomock.Any(), gomock.Eq(&apikey.UpdateLastUsedDateModel{Id: 7})).
+		DoAndReturn(func(ctx context.Context, ch chan *apikey.UpdateLastUsedDateResponse, model *apikey.UpdateLastUsedDateModel) {
+			ch <- &apikey.UpdateLastUsedDateResponse{}
+		})
+
+	// When
+	response := s.authenticate(testApiKey)
+
+	// Then
+	s.NoError(response.Error)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_WrongSecret_ReturnsError() {
+	// Given
+	s.expectStoredKey(hasher.HashToken("gca_0123456789ab_b3RoZXI"), nil)
+
+	// When
+	response := s.authenticate(testApiKey)
+
+	// Then
+	s.EqualError(response.Error, "api key is invalid")
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_UnknownPrefix_ReturnsError() {
+	// Given
+	s.mockApiKeyDb.
+		EXPECT().
+		GetApiKeyByPrefix(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *apikey.GetApiKeyByPrefixResponse, model *apikey.GetApiKeyByPrefixModel) {
+			ch <- &apikey.GetApiKeyByPrefixResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	response := s.authenticate(testApiKey)
+
+	// Then
+	s.EqualError(response.Error, "api key is invalid")
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_MalformedKey_ReturnsError() {
+	// When
+	response := s.authenticate("not-an-api-key")
+
+	// Then
+	s.EqualError(response.Error, "api key is invalid")
+}
+
+func (s *ApiKeyServiceTestSuite) TestAddApiKey_UngrantedScope_ReturnsError() {
+	// Given
+	s.expectUserPermissions("samples:read")
+
+	// When
+	ch := make(chan *AddApiKeyServiceResponse)
+	defer close(ch)
+	go s.apiKeyService.AddApiKey(context.Background(), ch, &AddApiKeyServiceModel{UserId: 1, Name: "ci", Scopes: []string{"samples:write"}, CreatedBy: 2})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "scope is not granted to the user: samples:write")
+}
+
+func (s *ApiKeyServiceTestSuite) TestAddApiKey_ValidScopes_ReturnsKeyAndStoresItsHash() {
+	// Given
+	s.expectUserPermissions("samples:read")
+	var stored *apikey.AddApiKeyModel
+	s.mockApiKeyDb.
+		EXPECT().
+		AddApiKey(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *apikey.AddApiKeyResponse, model *apikey.AddApiKeyModel) {
+			stored = model
+			ch <- &apikey.AddApiKeyResponse{Id: 7}
+		})
+
+	// When
+	ch := make(chan *AddApiKeyServiceResponse)
+	defer close(ch)
+	go s.apiKeyService.AddApiKey(context.Background(), ch, &AddApiKeyServiceModel{UserId: 1, Name: "ci", Scopes: []string{"samples:read"}, CreatedBy: 2})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(7), response.Id)
+	prefix, ok := parsePrefix(response.ApiKey)
+	s.True(ok)
+	s.Equal(response.Prefix, prefix)
+	s.Equal(prefix, stored.Prefix)
+	s.Equal(hasher.HashToken(response.ApiKey), stored.KeyHash)
+}
@@ -0,0 +1,20 @@
+package audit
+
+import "time"
+
+type RecordServiceModel struct {
+	EventType string `validate:"required,max=40"`
+	UserId    int64
+	UserName  string
+	ClientIp  string
+	UserAgent string
+	Details   map[string]interface{}
+}
+
+type GetEventsServiceModel struct {
+	UserId    int64
+	EventType string
+	From      *time.Time
+	To        *time.Time
+	Limit     int `validate:"required,gte=1,lte=500"`
+}
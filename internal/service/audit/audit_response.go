@@ -0,0 +1,8 @@
+package audit
+
+import "go-clean-architecture/internal/data/database/audit"
+
+type GetEventsServiceResponse struct {
+	Error  error `json:"-"`
+	Events []audit.AuditEvent
+}
@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-clean-architecture/internal/data/database/audit"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// Event types
+const (
+	EventLoginSucceeded          = "login.succeeded"
+	EventLoginFailed             = "login.failed"
+	EventLoginLockedOut          = "login.locked_out"
+	EventMfaChallenged           = "login.mfa_challenged"
+	EventTokenRefreshed          = "token.refreshed"
+	EventTokenRefreshFailed      = "token.refresh_failed"
+	EventRefreshTokenReused      = "token.reused"
+	EventProgrammaticTokenIssued = "token.programmatic_issued"
+	EventLogout                  = "logout"
+	EventLogoutAll               = "logout.all"
+)
+
+// Writer settings
+const (
+	bufferSize    = 10000
+	batchSize     = 100
+	flushInterval = time.Second
+)
+
+// Lengths of auth_audit_events columns, longer values are truncated.
+const (
+	maxUserNameLength  = 30
+	maxClientIpLength  = 45
+	maxUserAgentLength = 500
+)
+
+type IAuditService interface {
+	Record(model *RecordServiceModel)
+	GetEvents(ctx context.Context, ch chan *GetEventsServiceResponse, model *GetEventsServiceModel)
+	Close(ctx context.Context) error
+}
+
+type AuditService struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	auditDb     audit.IAuditDb
+	writer      *bufferedWriter
+}
+
+// NewAuditService
+// Returns a new AuditService with its own running writer, which must be closed to write queued events.
+// A process creates a single AuditService and passes it to the services which record events.
+func NewAuditService(environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher, auditDb audit.IAuditDb) IAuditService {
+	service := AuditService{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if auditDb != nil {
+		service.auditDb = auditDb
+	} else {
+		service.auditDb = audit.NewAuditDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	service.writer = newBufferedWriter(service.auditDb, loggr, bufferSize, batchSize, flushInterval)
+
+	return &service
+}
+
+// Record
+// Queues an audit event to be written asynchronously and returns immediately.
+// Events are dropped with a warning if they are invalid or the buffer is full.
+func (s *AuditService) Record(model *RecordServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		s.loggr.Warn("Audit event is invalid.", zap.String("eventType", model.EventType), zap.Error(modelErr))
+		return
+	}
+
+	event := audit.AuditEvent{
+		EventType:   model.EventType,
+		UserId:      model.UserId,
+		UserName:    helper.Truncate(model.UserName, maxUserNameLength),
+		ClientIp:    helper.Truncate(model.ClientIp, maxClientIpLength),
+		UserAgent:   helper.Truncate(model.UserAgent, maxUserAgentLength),
+		CreatedDate: time.Now(),
+	}
+
+	if len(model.Details) > 0 {
+		details, err := json.Marshal(model.Details)
+		if err != nil {
+			s.loggr.Warn("Audit event details could not be marshalled.", zap.String("eventType", model.EventType), zap.Error(err))
+		} else {
+			event.Details = details
+		}
+	}
+
+	if !s.writer.write(event) {
+		s.loggr.Warn("Audit event is dropped.", zap.String("eventType", model.EventType), zap.Int64("userId", model.UserId))
+	}
+}
+
+// GetEvents
+// Gets latest audit events, optionally filtered by user, event type and time range.
+func (s *AuditService) GetEvents(ctx context.Context, ch chan *GetEventsServiceResponse, model *GetEventsServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetEventsServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chGetAuditEventsResponse := make(chan *audit.GetAuditEventsResponse)
+	defer close(chGetAuditEventsResponse)
+
+	go s.auditDb.GetAuditEvents(
+		ctx, chGetAuditEventsResponse, &audit.GetAuditEventsModel{
+			UserId:    model.UserId,
+			EventType: model.EventType,
+			From:      model.From,
+			To:        model.To,
+			Limit:     model.Limit,
+		},
+	)
+
+	getAuditEventsResponse := <-chGetAuditEventsResponse
+	if getAuditEventsResponse.Error != nil {
+		ch <- &GetEventsServiceResponse{Error: getAuditEventsResponse.Error}
+		return
+	}
+
+	ch <- &GetEventsServiceResponse{Events: getAuditEventsResponse.Events}
+}
+
+// Close
+// Writes queued events and stops the writer. Events recorded afterwards are dropped.
+// Returns ctx error if queued events are not written before ctx is done.
+func (s *AuditService) Close(ctx context.Context) error {
+	return s.writer.close(ctx)
+}
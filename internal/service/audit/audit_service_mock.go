@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/audit/audit_service.go
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAuditService is a mock of IAuditService interface.
+type MockIAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditServiceMockRecorder
+}
+
+// MockIAuditServiceMockRecorder is the mock recorder for MockIAuditService.
+type MockIAuditServiceMockRecorder struct {
+	mock *MockIAuditService
+}
+
+// NewMockIAuditService creates a new mock instance.
+func NewMockIAuditService(ctrl *gomock.Controller) *MockIAuditService {
+	mock := &MockIAuditService{ctrl: ctrl}
+	mock.recorder = &MockIAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditService) EXPECT() *MockIAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIAuditService) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIAuditServiceMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIAuditService)(nil).Close), ctx)
+}
+
+// GetEvents mocks base method.
+func (m *MockIAuditService) GetEvents(ctx context.Context, ch chan *GetEventsServiceResponse, model *GetEventsServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetEvents", ctx, ch, model)
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockIAuditServiceMockRecorder) GetEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockIAuditService)(nil).GetEvents), ctx, ch, model)
+}
+
+// Record mocks base method.
+func (m *MockIAuditService) Record(model *RecordServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", model)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockIAuditServiceMockRecorder) Record(model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockIAuditService)(nil).Record), model)
+}
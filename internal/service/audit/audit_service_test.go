@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/audit"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditServiceTestSuite struct {
+	suite.Suite
+	auditService    IAuditService
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockAuditDb     *audit.MockIAuditDb
+}
+
+// Run suite.
+func TestAuditService(t *testing.T) {
+	suite.Run(t, new(AuditServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *AuditServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockAuditDb = audit.NewMockIAuditDb(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+
+	s.auditService = NewAuditService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockAuditDb)
+}
+
+func (s *AuditServiceTestSuite) TestRecord_Close_WritesTruncatedEvent() {
+	// Given
+	var events []audit.AuditEvent
+	s.mockAuditDb.
+		EXPECT().
+		AddAuditEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *audit.AddAuditEventsResponse, model *audit.AddAuditEventsModel) {
+			events = model.Events
+			ch <- &audit.AddAuditEventsResponse{}
+		})
+
+	// When
+	s.auditService.Record(&RecordServiceModel{
+		EventType: EventLoginFailed,
+		UserId:    2,
+		UserName:  "john",
+		ClientIp:  "10.0.0.1",
+		UserAgent: strings.Repeat("a", 600),
+		Details:   map[string]interface{}{"reason": "user is not found"},
+	})
+	s.Require().NoError(s.auditService.Close(context.Background()))
+
+	// Then
+	s.Len(events, 1)
+	s.Equal(EventLoginFailed, events[0].EventType)
+	s.Equal(int64(2), events[0].UserId)
+	s.Len(events[0].UserAgent, maxUserAgentLength)
+	s.JSONEq(`{"reason":"user is not found"}`, string(events[0].Details))
+}
+
+func (s *AuditServiceTestSuite) TestRecord_AfterClose_DropsEvent() {
+	// Given
+	s.Require().NoError(s.auditService.Close(context.Background()))
+	s.mockLogger.EXPECT().Warn("Audit event is dropped.", gomock.Any())
+
+	// When
+	s.auditService.Record(&RecordServiceModel{EventType: EventLogout, UserId: 2})
+}
+
+func (s *AuditServiceTestSuite) TestRecord_DbError_LogsError() {
+	// Given
+	s.mockAuditDb.
+		EXPECT().
+		AddAuditEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *audit.AddAuditEventsResponse, model *audit.AddAuditEventsModel) {
+			ch <- &audit.AddAuditEventsResponse{Error: errors.New("connection refused")}
+		})
+	s.mockLogger.EXPECT().Error("Audit events could not be written.", gomock.Any())
+
+	// When
+	s.auditService.Record(&RecordServiceModel{EventType: EventLogout, UserId: 2})
+	s.Require().NoError(s.auditService.Close(context.Background()))
+}
+
+func (s *AuditServiceTestSuite) TestWriter_BatchSizeReached_WritesWithoutWaitingForInterval() {
+	// Given
+	written := make(chan int, 1)
+	s.mockAuditDb.
+		EXPECT().
+		AddAuditEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *audit.AddAuditEventsResponse, model *audit.AddAuditEventsModel) {
+			written <- len(model.Events)
+			ch <- &audit.AddAuditEventsResponse{}
+		})
+	writer := newBufferedWriter(s.mockAuditDb, s.mockLogger, 10, 2, time.Hour)
+	defer writer.close(context.Background())
+
+	// When
+	writer.write(audit.AuditEvent{EventType: EventLogout})
+	writer.write(audit.AuditEvent{EventType: EventLogoutAll})
+
+	// Then
+	select {
+	case count := <-written:
+		s.Equal(2, count)
+	case <-time.After(time.Second):
+		s.Fail("batch is not written")
+	}
+}
+
+func (s *AuditServiceTestSuite) TestClose_ContextDoneBeforeWritten_ReturnsContextError() {
+	// Given
+	release := make(chan struct{})
+	s.mockAuditDb.
+		EXPECT().
+		AddAuditEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *audit.AddAuditEventsResponse, model *audit.AddAuditEventsModel) {
+			<-release
+			ch <- &audit.AddAuditEventsResponse{}
+		})
+	s.auditService.Record(&RecordServiceModel{EventType: EventLogout, UserId: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	// When
+	err := s.auditService.Close(ctx)
+	close(release)
+
+	// Then
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.NoError(s.auditService.Close(context.Background()))
+}
+
+func (s *AuditServiceTestSuite) TestGetEvents_WithFilters_QueriesDb() {
+	// Given
+	from := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.mockAuditDb.
+		EXPECT().
+		GetAuditEvents(gomock.Any(), gomock.Any(), gomock.Eq(&audit.GetAuditEventsModel{UserId: 2, EventType: EventLoginFailed, From: &from, Limit: 50})).
+		DoAndReturn(func(ctx context.Context, ch chan *audit.GetAuditEventsResponse, model *audit.GetAuditEventsModel) {
+			ch <- &audit.GetAuditEventsResponse{Events: []audit.AuditEvent{{Id: 1, EventType: EventLoginFailed, UserId: 2}}}
+		})
+
+	// When
+	ch := make(chan *GetEventsServiceResponse)
+	defer close(ch)
+	go s.auditService.GetEvents(context.Background(), ch, &GetEventsServiceModel{UserId: 2, EventType: EventLoginFailed, From: &from, Limit: 50})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Len(response.Events, 1)
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-clean-architecture/internal/data/database/audit"
+	"go-clean-architecture/internal/util/logger"
+
+	"go.uber.org/zap"
+)
+
+// bufferedWriter
+// Writes audit events to db in batches from a single goroutine, so recording never waits for db.
+// Events are dropped when the buffer is full, since authentication must not be blocked by auditing.
+type bufferedWriter struct {
+	auditDb       audit.IAuditDb
+	loggr         logger.ILogger
+	events        chan audit.AuditEvent
+	batchSize     int
+	flushInterval time.Duration
+	mutex         sync.RWMutex
+	isClosed      bool
+	done          chan struct{}
+}
+
+// newBufferedWriter
+// Returns a new bufferedWriter which is already running.
+func newBufferedWriter(auditDb audit.IAuditDb, loggr logger.ILogger, bufferSize int, batchSize int, flushInterval time.Duration) *bufferedWriter {
+	writer := bufferedWriter{
+		auditDb:       auditDb,
+		loggr:         loggr,
+		events:        make(chan audit.AuditEvent, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+
+	go writer.run()
+
+	return &writer
+}
+
+// write
+// Queues an event without blocking. Returns false if the event is dropped.
+func (w *bufferedWriter) write(event audit.AuditEvent) bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if w.isClosed {
+		return false
+	}
+
+	select {
+	case w.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// close
+// Stops accepting events and waits until queued events are written or ctx is done.
+func (w *bufferedWriter) close(ctx context.Context) error {
+	w.mutex.Lock()
+	if !w.isClosed {
+		w.isClosed = true
+		close(w.events)
+	}
+	w.mutex.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *bufferedWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]audit.AuditEvent, 0, w.batchSize)
+	for {
+		select {
+		case event, ok := <-w.events:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+
+			batch = append(batch, event)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = make([]audit.AuditEvent, 0, w.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.flush(batch)
+				batch = make([]audit.AuditEvent, 0, w.batchSize)
+			}
+		}
+	}
+}
+
+// flush
+// Writes a batch to db. Failed batches are logged and dropped, so an unavailable db does not exhaust memory.
+func (w *bufferedWriter) flush(batch []audit.AuditEvent) {
+	if len(batch) == 0 {
+		return
+	}
+
+	chAddAuditEventsResponse := make(chan *audit.AddAuditEventsResponse)
+	defer close(chAddAuditEventsResponse)
+
+	// Batches hold events of many requests, so they are not bound to any request context.
+	go w.auditDb.AddAuditEvents(context.Background(), chAddAuditEventsResponse, &audit.AddAuditEventsModel{Events: batch})
+
+	addAuditEventsResponse := <-chAddAuditEventsResponse
+	if addAuditEventsResponse.Error != nil {
+		w.loggr.Error("Audit events could not be written.", zap.Int("count", len(batch)), zap.Error(addAuditEventsResponse.Error))
+	}
+}
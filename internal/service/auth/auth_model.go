@@ -1,16 +1,96 @@
 package auth
 
 type LoginServiceModel struct {
-	UserName string `validate:"required"`
-	Password string `validate:"required"`
+	UserName  string `validate:"required"`
+	Password  string `validate:"required"`
+	ClientIp  string `validate:"required"`
+	UserAgent string
 }
 
 type GetAccessTokenServiceModel struct {
 	RefreshToken string `validate:"required"`
+	ClientIp     string
+	UserAgent    string
 }
 
 type GetProgrammaticAccessTokenServiceModel struct {
 	UserName   string `validate:"required"`
 	Password   string `validate:"required"`
-	ExpiryDays int32  `validate:"required"`
+	ExpiryDays int32  `validate:"required,min=1,max=365"`
+	ClientIp   string `validate:"required"`
+	UserAgent  string
+}
+
+type RegisterServiceModel struct {
+	UserName string `validate:"required,min=3,max=30,alphanum"`
+	Email    string `validate:"required,email,max=100"`
+	Password string `validate:"required,min=8,max=128"`
+}
+
+type ActivateServiceModel struct {
+	ActivationToken string `validate:"required,uuid"`
+}
+
+type LogoutServiceModel struct {
+	UserId       int64 `validate:"required"`
+	UserName     string
+	RefreshToken string `validate:"required"`
+	ClientIp     string
+	UserAgent    string
+}
+
+type LogoutAllServiceModel struct {
+	UserId    int64 `validate:"required"`
+	UserName  string
+	ClientIp  string
+	UserAgent string
+}
+
+type VerifyMfaServiceModel struct {
+	ChallengeToken string `validate:"required"`
+	Code           string
+	RecoveryCode   string
+	ClientIp       string
+	UserAgent      string
+}
+
+type ForgotPasswordServiceModel struct {
+	Email    string `validate:"required,email"`
+	ClientIp string `validate:"required"`
+}
+
+type ResetPasswordServiceModel struct {
+	ResetToken  string `validate:"required,uuid"`
+	NewPassword string `validate:"required,min=8,max=128"`
+	ClientIp    string `validate:"required"`
+}
+
+type ChangePasswordServiceModel struct {
+	UserId          int64  `validate:"required"`
+	UserName        string `validate:"required"`
+	CurrentPassword string `validate:"required"`
+	NewPassword     string `validate:"required,min=8,max=128,nefield=CurrentPassword"`
+}
+
+type GetOidcLoginUrlServiceModel struct {
+}
+
+type LoginWithOidcServiceModel struct {
+	Code      string `validate:"required"`
+	State     string `validate:"required"`
+	ClientIp  string
+	UserAgent string
+}
+
+type IntrospectServiceModel struct {
+	Token string `validate:"required"`
+}
+
+type GetSessionsServiceModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type RevokeSessionServiceModel struct {
+	UserId    int64  `validate:"required"`
+	SessionId string `validate:"required,uuid"`
 }
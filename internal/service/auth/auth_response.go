@@ -1,9 +1,13 @@
 package auth
 
+import "go-clean-architecture/internal/data/database/auth"
+
 type LoginServiceResponse struct {
-	Error        error `json:"-"`
-	JwtToken     string
-	RefreshToken string
+	Error             error `json:"-"`
+	JwtToken          string
+	RefreshToken      string
+	MfaRequired       bool
+	MfaChallengeToken string
 }
 
 type GetProgrammaticAccessTokenServiceResponse struct {
@@ -11,3 +15,63 @@ type GetProgrammaticAccessTokenServiceResponse struct {
 	JwtToken     string
 	RefreshToken string
 }
+
+type RegisterServiceResponse struct {
+	Error  error `json:"-"`
+	UserId int64
+}
+
+type ActivateServiceResponse struct {
+	Error        error `json:"-"`
+	IsSuccessful bool
+}
+
+type LogoutServiceResponse struct {
+	Error        error `json:"-"`
+	RevokedCount int64
+}
+
+type ForgotPasswordServiceResponse struct {
+	Error error `json:"-"`
+}
+
+type PasswordServiceResponse struct {
+	Error        error `json:"-"`
+	IsChanged    bool
+	RevokedCount int64
+}
+
+type GetOidcLoginUrlServiceResponse struct {
+	Error error `json:"-"`
+	Url   string
+	State string
+}
+
+// IntrospectServiceResponse
+// Token introspection response as described in RFC 7662. Inactive tokens only have active field.
+type IntrospectServiceResponse struct {
+	Error     error    `json:"-"`
+	Active    bool     `json:"active"`
+	TokenType string   `json:"token_type,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	TokenId   string   `json:"jti,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+}
+
+type GetSessionsServiceResponse struct {
+	Error    error `json:"-"`
+	Sessions []auth.Session
+}
+
+type RevokeSessionServiceResponse struct {
+	Error        error `json:"-"`
+	IsRevoked    bool
+	RevokedCount int64
+}
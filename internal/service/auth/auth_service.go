@@ -1,41 +1,115 @@
 package auth
 
 import (
-	"crypto/sha256"
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"go-clean-architecture/internal/data/database"
 	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/data/notification"
+	"go-clean-architecture/internal/service/audit"
+	"go-clean-architecture/internal/service/lockout"
+	"go-clean-architecture/internal/service/mfa"
+	"go-clean-architecture/internal/service/oidc"
+	"go-clean-architecture/internal/service/revocation"
 	"go-clean-architecture/internal/util/customerror"
+	"math"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go-clean-architecture/internal/util"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/keyring"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/requestcontext"
 	"go-clean-architecture/internal/util/validator"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type IAuthService interface {
-	Login(ch chan *LoginServiceResponse, model *LoginServiceModel)
-	GetAccessToken(ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel)
+	Login(ctx context.Context, ch chan *LoginServiceResponse, model *LoginServiceModel)
+	GetAccessToken(ctx context.Context, ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel)
 	GetProgrammaticAccessToken(
+		ctx context.Context,
 		ch chan *GetProgrammaticAccessTokenServiceResponse,
 		model *GetProgrammaticAccessTokenServiceModel,
 	)
+	Register(ctx context.Context, ch chan *RegisterServiceResponse, model *RegisterServiceModel)
+	Activate(ctx context.Context, ch chan *ActivateServiceResponse, model *ActivateServiceModel)
+	Logout(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutServiceModel)
+	LogoutAll(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutAllServiceModel)
+	VerifyMfa(ctx context.Context, ch chan *LoginServiceResponse, model *VerifyMfaServiceModel)
+	ForgotPassword(ctx context.Context, ch chan *ForgotPasswordServiceResponse, model *ForgotPasswordServiceModel)
+	ResetPassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ResetPasswordServiceModel)
+	ChangePassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ChangePasswordServiceModel)
+	GetOidcLoginUrl(ctx context.Context, ch chan *GetOidcLoginUrlServiceResponse, model *GetOidcLoginUrlServiceModel)
+	LoginWithOidc(ctx context.Context, ch chan *LoginServiceResponse, model *LoginWithOidcServiceModel)
+	Introspect(ctx context.Context, ch chan *IntrospectServiceResponse, model *IntrospectServiceModel)
+	GetSessions(ctx context.Context, ch chan *GetSessionsServiceResponse, model *GetSessionsServiceModel)
+	RevokeSession(ctx context.Context, ch chan *RevokeSessionServiceResponse, model *RevokeSessionServiceModel)
 }
 
 type AuthService struct {
-	environment env.IEnvironment
-	loggr       logger.ILogger
-	validatr    validator.IValidator
-	cachr       cacher.ICacher
-	authDb      auth.IAuthDb
+	environment        env.IEnvironment
+	loggr              logger.ILogger
+	validatr           validator.IValidator
+	cachr              cacher.ICacher
+	authDb             auth.IAuthDb
+	passwordHasher     hasher.IPasswordHasher
+	notificationSender notification.INotificationSender
+	keyrng             keyring.IKeyring
+	lockoutService     lockout.ILockoutService
+	mfaService         mfa.IMfaService
+	oidcService        oidc.IOidcService
+	revocationService  revocation.IRevocationService
+	auditService       audit.IAuditService
+	unitOfWork         database.IUnitOfWork
+	dummyHashOnce      sync.Once
+	dummyHash          string
 }
 
+var errInvalidCredentials = customerror.New(errors.New("user is not found"), customerror.LogLevelInfo)
+
+// errInactiveUser
+// Reason of failed logins by inactive users or users logging in with the wrong method, only recorded in the audit log.
+var errInactiveUser = errors.New("user is inactive or cannot log in with this method")
+
+// Login methods recorded in the audit log
+const (
+	loginMethodPassword     = "password"
+	loginMethodMfa          = "mfa"
+	loginMethodOidc         = "oidc"
+	loginMethodProgrammatic = "programmatic"
+)
+
+// maxUserAgentLength
+// User agents are kept with refresh tokens to describe sessions, longer ones are truncated.
+const maxUserAgentLength = 500
+
+// rateLimit
+// Maximum number of requests allowed within a window.
+type rateLimit struct {
+	limit  int64
+	window time.Duration
+}
+
+// Rate limits of password endpoints.
+var (
+	forgotPasswordEmailRateLimit = rateLimit{limit: 3, window: time.Hour}
+	forgotPasswordIpRateLimit    = rateLimit{limit: 10, window: time.Hour}
+	resetPasswordIpRateLimit     = rateLimit{limit: 10, window: time.Minute * 15}
+	changePasswordUserRateLimit  = rateLimit{limit: 5, window: time.Minute * 15}
+)
+
 // NewAuthService
 // Returns a new AuthService.
 func NewAuthService(
@@ -44,6 +118,15 @@ func NewAuthService(
 	validatr validator.IValidator,
 	cachr cacher.ICacher,
 	authDb auth.IAuthDb,
+	passwordHasher hasher.IPasswordHasher,
+	notificationSender notification.INotificationSender,
+	keyrng keyring.IKeyring,
+	lockoutService lockout.ILockoutService,
+	mfaService mfa.IMfaService,
+	oidcService oidc.IOidcService,
+	revocationService revocation.IRevocationService,
+	auditService audit.IAuditService,
+	unitOfWork database.IUnitOfWork,
 ) IAuthService {
 	service := AuthService{
 		environment: environment,
@@ -55,7 +138,61 @@ func NewAuthService(
 	if authDb != nil {
 		service.authDb = authDb
 	} else {
-		service.authDb = auth.NewAuthDb(environment, loggr, validatr, cachr)
+		service.authDb = auth.NewAuthDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if passwordHasher != nil {
+		service.passwordHasher = passwordHasher
+	} else {
+		service.passwordHasher = hasher.New(environment)
+	}
+
+	if notificationSender != nil {
+		service.notificationSender = notificationSender
+	} else {
+		service.notificationSender = notification.NewLogNotificationSender(environment, loggr, validatr, cachr)
+	}
+
+	if keyrng != nil {
+		service.keyrng = keyrng
+	} else {
+		service.keyrng = keyring.New(environment)
+	}
+
+	if lockoutService != nil {
+		service.lockoutService = lockoutService
+	} else {
+		service.lockoutService = lockout.NewLockoutService(environment, loggr, validatr, cachr, nil)
+	}
+
+	if mfaService != nil {
+		service.mfaService = mfaService
+	} else {
+		service.mfaService = mfa.NewMfaService(environment, loggr, validatr, cachr, nil, nil)
+	}
+
+	if oidcService != nil {
+		service.oidcService = oidcService
+	} else {
+		service.oidcService = oidc.NewOidcService(environment, loggr, validatr, cachr, nil, nil)
+	}
+
+	if revocationService != nil {
+		service.revocationService = revocationService
+	} else {
+		service.revocationService = revocation.NewRevocationService(environment, loggr, validatr, cachr, nil)
+	}
+
+	if auditService != nil {
+		service.auditService = auditService
+	} else {
+		service.auditService = audit.NewAuditService(environment, loggr, validatr, cachr, nil)
+	}
+
+	if unitOfWork != nil {
+		service.unitOfWork = unitOfWork
+	} else {
+		service.unitOfWork = database.NewUnitOfWork(environment, nil)
 	}
 
 	return &service
@@ -64,121 +201,142 @@ func NewAuthService(
 // Login
 // Logs in user with given username and password.
 // Returns an error if user is not found.
-func (s *AuthService) Login(ch chan *LoginServiceResponse, model *LoginServiceModel) {
+func (s *AuthService) Login(ctx context.Context, ch chan *LoginServiceResponse, model *LoginServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &LoginServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
 		return
 	}
 
-	passwordHashBytes := sha256.Sum256([]byte(model.Password))
-	passwordHash := fmt.Sprintf("%x", passwordHashBytes[:]) // TODO: check sprintf usage
-
-	chGetUserByPasswordResponse := make(chan *auth.GetUserByPasswordResponse)
-	defer close(chGetUserByPasswordResponse)
-
-	go s.authDb.GetUserByPassword(
-		chGetUserByPasswordResponse, &auth.GetUserByPasswordModel{
-			UserName:     model.UserName,
-			PasswordHash: passwordHash,
-		},
-	)
-
-	getUserByPasswordResponse := <-chGetUserByPasswordResponse
-	if getUserByPasswordResponse.Error != nil {
-		ch <- &LoginServiceResponse{Error: getUserByPasswordResponse.Error}
+	user, err := s.authenticate(ctx, model.UserName, model.Password, model.ClientIp)
+	if err != nil {
+		s.recordLogin(loginMethodPassword, 0, model.UserName, model.ClientIp, model.UserAgent, err, nil)
+		ch <- &LoginServiceResponse{Error: err}
 		return
 	}
 
-	if !getUserByPasswordResponse.IsActive || getUserByPasswordResponse.IsProgrammatic {
+	if !user.IsActive || user.IsProgrammatic {
+		s.recordLogin(loginMethodPassword, user.Id, user.UserName, model.ClientIp, model.UserAgent, errInactiveUser, nil)
 		ch <- &LoginServiceResponse{Error: errors.New("user is not found")}
 		return
 	}
 
-	chAddRefreshTokenResponse := make(chan *auth.AddRefreshTokenResponse)
-	defer close(chAddRefreshTokenResponse)
+	chGetStatusResponse := make(chan *mfa.GetStatusServiceResponse)
+	defer close(chGetStatusResponse)
 
-	refreshToken := uuid.NewString()
-	go s.authDb.AddRefreshToken(
-		chAddRefreshTokenResponse, &auth.AddRefreshTokenModel{
-			UserId:       int(getUserByPasswordResponse.Id),
-			RefreshToken: refreshToken,
-		},
-	)
+	go s.mfaService.GetStatus(ctx, chGetStatusResponse, &mfa.GetStatusServiceModel{UserId: user.Id})
 
-	addRefreshTokenResponse := <-chAddRefreshTokenResponse
-	if addRefreshTokenResponse.Error != nil {
-		ch <- &LoginServiceResponse{Error: addRefreshTokenResponse.Error}
+	getStatusResponse := <-chGetStatusResponse
+	if getStatusResponse.Error != nil {
+		ch <- &LoginServiceResponse{Error: getStatusResponse.Error}
 		return
 	}
 
-	tokenString, err := s.generateJwt(getUserByPasswordResponse.Id, getUserByPasswordResponse.UserName, getUserByPasswordResponse.Email, 0)
+	if getStatusResponse.IsEnabled {
+		chCreateChallengeResponse := make(chan *mfa.CreateChallengeServiceResponse)
+		defer close(chCreateChallengeResponse)
 
-	if err != nil {
-		ch <- &LoginServiceResponse{Error: err}
+		go s.mfaService.CreateChallenge(
+			ctx, chCreateChallengeResponse, &mfa.CreateChallengeServiceModel{
+				UserId:   user.Id,
+				UserName: user.UserName,
+			},
+		)
+
+		createChallengeResponse := <-chCreateChallengeResponse
+		if createChallengeResponse.Error != nil {
+			ch <- &LoginServiceResponse{Error: createChallengeResponse.Error}
+			return
+		}
+
+		s.auditService.Record(&audit.RecordServiceModel{
+			EventType: audit.EventMfaChallenged,
+			UserId:    user.Id,
+			UserName:  user.UserName,
+			ClientIp:  model.ClientIp,
+			UserAgent: model.UserAgent,
+		})
+
+		ch <- &LoginServiceResponse{
+			MfaRequired:       true,
+			MfaChallengeToken: createChallengeResponse.ChallengeToken,
+		}
 		return
 	}
 
-	ch <- &LoginServiceResponse{
-		JwtToken:     tokenString,
-		RefreshToken: refreshToken,
-	}
+	s.registerSuccess(ctx, user.UserName)
+
+	response := s.issueTokens(ctx, user, model.ClientIp, model.UserAgent)
+	s.recordLogin(loginMethodPassword, user.Id, user.UserName, model.ClientIp, model.UserAgent, response.Error, nil)
+
+	ch <- response
 }
 
 // GetAccessToken
 // Logs in user with given refresh token
 // Returns an error if user is not found.
-func (s *AuthService) GetAccessToken(ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel) {
+func (s *AuthService) GetAccessToken(ctx context.Context, ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &LoginServiceResponse{Error: modelErr}
 		return
 	}
 
-	chGetUserByRefreshTokenResponse := make(chan *auth.GetUserByRefreshTokenResponse)
-	defer close(chGetUserByRefreshTokenResponse)
+	chRotateRefreshTokenResponse := make(chan *auth.RotateRefreshTokenResponse)
+	defer close(chRotateRefreshTokenResponse)
 
-	go s.authDb.GetUserByRefreshToken(
-		chGetUserByRefreshTokenResponse, &auth.GetUserByRefreshTokenModel{
-			RefreshToken: model.RefreshToken,
+	refreshToken := uuid.NewString()
+	go s.authDb.RotateRefreshToken(
+		ctx, chRotateRefreshTokenResponse, &auth.RotateRefreshTokenModel{
+			TokenHash:    hasher.HashToken(model.RefreshToken),
+			NewTokenHash: hasher.HashToken(refreshToken),
+			UserAgent:    helper.Truncate(model.UserAgent, maxUserAgentLength),
+			ClientIp:     model.ClientIp,
 		},
 	)
 
-	getUserByRefreshTokenResponse := <-chGetUserByRefreshTokenResponse
-	if getUserByRefreshTokenResponse.Error != nil {
-		ch <- &LoginServiceResponse{Error: getUserByRefreshTokenResponse.Error}
+	rotateRefreshTokenResponse := <-chRotateRefreshTokenResponse
+	if errors.Is(rotateRefreshTokenResponse.Error, auth.ErrRefreshTokenReused) {
+		s.loggr.Warn("Revoked refresh token is reused, token family is revoked.",
+			zap.Int64("userId", rotateRefreshTokenResponse.Id),
+			zap.String("familyId", rotateRefreshTokenResponse.FamilyId),
+		)
+		s.auditService.Record(&audit.RecordServiceModel{
+			EventType: audit.EventRefreshTokenReused,
+			UserId:    rotateRefreshTokenResponse.Id,
+			ClientIp:  model.ClientIp,
+			UserAgent: model.UserAgent,
+			Details:   map[string]interface{}{"familyId": rotateRefreshTokenResponse.FamilyId},
+		})
+		ch <- &LoginServiceResponse{Error: customerror.New(errors.New("refresh token is revoked"), customerror.LogLevelWarn)}
 		return
 	}
-
-	if !getUserByRefreshTokenResponse.IsActive || getUserByRefreshTokenResponse.IsProgrammatic {
-		ch <- &LoginServiceResponse{Error: errors.New("user is not found")}
+	if errors.Is(rotateRefreshTokenResponse.Error, sql.ErrNoRows) {
+		err := customerror.New(errors.New("refresh token is invalid or expired"), customerror.LogLevelInfo)
+		s.recordRefresh(0, "", model.ClientIp, model.UserAgent, err)
+		ch <- &LoginServiceResponse{Error: err}
+		return
+	}
+	if rotateRefreshTokenResponse.Error != nil {
+		ch <- &LoginServiceResponse{Error: rotateRefreshTokenResponse.Error}
 		return
 	}
 
-	chAddRefreshTokenResponse := make(chan *auth.AddRefreshTokenResponse)
-	defer close(chAddRefreshTokenResponse)
-
-	refreshToken := uuid.NewString()
-	go s.authDb.AddRefreshToken(
-		chAddRefreshTokenResponse, &auth.AddRefreshTokenModel{
-			UserId:       int(getUserByRefreshTokenResponse.Id),
-			RefreshToken: refreshToken,
-		},
-	)
-
-	addRefreshTokenResponse := <-chAddRefreshTokenResponse
-	if addRefreshTokenResponse.Error != nil {
-		ch <- &LoginServiceResponse{Error: addRefreshTokenResponse.Error}
+	if !rotateRefreshTokenResponse.IsActive || rotateRefreshTokenResponse.IsProgrammatic {
+		s.recordRefresh(rotateRefreshTokenResponse.Id, rotateRefreshTokenResponse.UserName, model.ClientIp, model.UserAgent, errInactiveUser)
+		ch <- &LoginServiceResponse{Error: errors.New("user is not found")}
 		return
 	}
 
-	tokenString, err := s.generateJwt(getUserByRefreshTokenResponse.Id, getUserByRefreshTokenResponse.UserName, getUserByRefreshTokenResponse.Email, 0)
+	tokenString, err := s.generateJwt(ctx, rotateRefreshTokenResponse.Id, rotateRefreshTokenResponse.UserName, rotateRefreshTokenResponse.Email, 0, nil)
 
 	if err != nil {
 		ch <- &LoginServiceResponse{Error: err}
 		return
 	}
 
+	s.recordRefresh(rotateRefreshTokenResponse.Id, rotateRefreshTokenResponse.UserName, model.ClientIp, model.UserAgent, nil)
+
 	ch <- &LoginServiceResponse{
 		JwtToken:     tokenString,
 		RefreshToken: refreshToken,
@@ -189,6 +347,7 @@ func (s *AuthService) GetAccessToken(ch chan *LoginServiceResponse, model *GetAc
 // Logs in user with given username and password.
 // Returns an error if user is not found.
 func (s *AuthService) GetProgrammaticAccessToken(
+	ctx context.Context,
 	ch chan *GetProgrammaticAccessTokenServiceResponse,
 	model *GetProgrammaticAccessTokenServiceModel,
 ) {
@@ -198,63 +357,1098 @@ func (s *AuthService) GetProgrammaticAccessToken(
 		return
 	}
 
-	passwordHashBytes := sha256.Sum256([]byte(model.Password))
-	passwordHash := fmt.Sprintf("%x", passwordHashBytes[:]) // TODO: check sprintf usage
-
-	chGetUserByPasswordResponse := make(chan *auth.GetUserByPasswordResponse)
-	defer close(chGetUserByPasswordResponse)
+	details := map[string]interface{}{"expiryDays": model.ExpiryDays}
 
-	go s.authDb.GetUserByPassword(
-		chGetUserByPasswordResponse, &auth.GetUserByPasswordModel{
-			UserName:     model.UserName,
-			PasswordHash: passwordHash,
-		},
-	)
-
-	dataResponse := <-chGetUserByPasswordResponse
-	if dataResponse.Error != nil {
-		ch <- &GetProgrammaticAccessTokenServiceResponse{Error: dataResponse.Error}
+	dataResponse, err := s.authenticate(ctx, model.UserName, model.Password, model.ClientIp)
+	if err != nil {
+		s.recordLogin(loginMethodProgrammatic, 0, model.UserName, model.ClientIp, model.UserAgent, err, details)
+		ch <- &GetProgrammaticAccessTokenServiceResponse{Error: err}
 		return
 	}
 
 	if !dataResponse.IsActive || !dataResponse.IsProgrammatic {
+		s.recordLogin(loginMethodProgrammatic, dataResponse.Id, dataResponse.UserName, model.ClientIp, model.UserAgent, errInactiveUser, details)
 		ch <- &GetProgrammaticAccessTokenServiceResponse{Error: errors.New("programmatic user is not found")}
 		return
 	}
 
-	tokenString, err := s.generateJwt(dataResponse.Id, dataResponse.UserName, dataResponse.Email, model.ExpiryDays)
+	s.registerSuccess(ctx, dataResponse.UserName)
+
+	tokenString, err := s.generateJwt(ctx, dataResponse.Id, dataResponse.UserName, dataResponse.Email, model.ExpiryDays, nil)
 
 	if err != nil {
 		ch <- &GetProgrammaticAccessTokenServiceResponse{Error: err}
 		return
 	}
 
+	s.auditService.Record(&audit.RecordServiceModel{
+		EventType: audit.EventProgrammaticTokenIssued,
+		UserId:    dataResponse.Id,
+		UserName:  dataResponse.UserName,
+		ClientIp:  model.ClientIp,
+		UserAgent: model.UserAgent,
+		Details:   details,
+	})
+
 	ch <- &GetProgrammaticAccessTokenServiceResponse{
 		JwtToken: tokenString,
 	}
 }
 
-func (s *AuthService) generateJwt(id int64, username string, email string, expiryDays int32) (string, error) {
-	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Minute * 30))
-	if expiryDays != 0 {
-		expiresAt = jwt.NewNumericDate(time.Now().AddDate(0, 0, int(expiryDays)))
+// Register
+// Creates an inactive user and sends an activation link to its email.
+// Returns an error if username or email is already taken.
+func (s *AuthService) Register(ctx context.Context, ch chan *RegisterServiceResponse, model *RegisterServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RegisterServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
 	}
 
-	// TODO: if we implement permission feature, also fetch permissions from db and attach them to the jwt claims
-	claims := util.CustomClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "Delivery Hero",
-			Subject:   strconv.FormatInt(id, 10),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			ExpiresAt: expiresAt,
+	chCheckUserExistsResponse := make(chan *auth.CheckUserExistsResponse)
+	defer close(chCheckUserExistsResponse)
+
+	go s.authDb.CheckUserExists(
+		ctx, chCheckUserExistsResponse, &auth.CheckUserExistsModel{
+			UserName: model.UserName,
+			Email:    model.Email,
+		},
+	)
+
+	checkUserExistsResponse := <-chCheckUserExistsResponse
+	if checkUserExistsResponse.Error != nil {
+		ch <- &RegisterServiceResponse{Error: checkUserExistsResponse.Error}
+		return
+	}
+
+	if checkUserExistsResponse.UserNameExists {
+		ch <- &RegisterServiceResponse{Error: customerror.New(errors.New("username is already taken"), customerror.LogLevelInfo)}
+		return
+	}
+
+	if checkUserExistsResponse.EmailExists {
+		ch <- &RegisterServiceResponse{Error: customerror.New(errors.New("email is already taken"), customerror.LogLevelInfo)}
+		return
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(model.Password)
+	if err != nil {
+		ch <- &RegisterServiceResponse{Error: err}
+		return
+	}
+
+	// User and its activation token are added together, so a user is never left without a way to activate it.
+	var userId int64
+	activationToken := uuid.NewString()
+	err = s.unitOfWork.Run(ctx, func(tx database.ITransaction) error {
+		chAddUserResponse := make(chan *auth.AddUserResponse)
+		defer close(chAddUserResponse)
+
+		go s.authDb.AddUser(
+			ctx, chAddUserResponse, &auth.AddUserModel{
+				UserName:     model.UserName,
+				Email:        model.Email,
+				PasswordHash: passwordHash,
+				Transaction:  tx,
+			},
+		)
+
+		addUserResponse := <-chAddUserResponse
+		if addUserResponse.Error != nil {
+			return addUserResponse.Error
+		}
+
+		chAddActivationTokenResponse := make(chan *auth.AddActivationTokenResponse)
+		defer close(chAddActivationTokenResponse)
+
+		go s.authDb.AddActivationToken(
+			ctx, chAddActivationTokenResponse, &auth.AddActivationTokenModel{
+				UserId:      addUserResponse.Id,
+				TokenHash:   hasher.HashToken(activationToken),
+				Transaction: tx,
+			},
+		)
+
+		addActivationTokenResponse := <-chAddActivationTokenResponse
+		if addActivationTokenResponse.Error != nil {
+			return addActivationTokenResponse.Error
+		}
+
+		userId = addUserResponse.Id
+		return nil
+	})
+	if err != nil {
+		ch <- &RegisterServiceResponse{Error: err}
+		return
+	}
+
+	chSendNotificationResponse := make(chan *notification.SendNotificationResponse)
+	defer close(chSendNotificationResponse)
+
+	go s.notificationSender.Send(
+		ctx, chSendNotificationResponse, &notification.SendNotificationModel{
+			Recipient: model.Email,
+			Subject:   "Activate your account",
+			Body:      "Please use the link below to activate your account.\n" + helper.GetTokenLink(s.environment, s.loggr, env.AuthActivationUrl, activationToken),
+		},
+	)
+
+	// User is already created, a delivery failure should not fail the registration.
+	sendNotificationResponse := <-chSendNotificationResponse
+	if sendNotificationResponse.Error != nil {
+		s.loggr.Error("Activation link could not be sent.", zap.Int64("userId", userId), zap.Error(sendNotificationResponse.Error))
+	}
+
+	ch <- &RegisterServiceResponse{UserId: userId}
+}
+
+// Activate
+// Activates user with given single use activation token.
+// Returns an error if token is not found, expired or already used.
+func (s *AuthService) Activate(ctx context.Context, ch chan *ActivateServiceResponse, model *ActivateServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ActivateServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chActivateUserResponse := make(chan *auth.ActivateUserResponse)
+	defer close(chActivateUserResponse)
+
+	go s.authDb.ActivateUser(
+		ctx, chActivateUserResponse, &auth.ActivateUserModel{
+			TokenHash: hasher.HashToken(model.ActivationToken),
+		},
+	)
+
+	activateUserResponse := <-chActivateUserResponse
+	if errors.Is(activateUserResponse.Error, sql.ErrNoRows) {
+		ch <- &ActivateServiceResponse{Error: customerror.New(errors.New("activation token is invalid or expired"), customerror.LogLevelInfo)}
+		return
+	}
+	if activateUserResponse.Error != nil {
+		ch <- &ActivateServiceResponse{Error: activateUserResponse.Error}
+		return
+	}
+
+	ch <- &ActivateServiceResponse{IsSuccessful: true}
+}
+
+// Logout
+// Revokes given refresh token with every token rotated from the same login.
+func (s *AuthService) Logout(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &LogoutServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRevokeRefreshTokenResponse := make(chan *auth.RevokeRefreshTokenResponse)
+	defer close(chRevokeRefreshTokenResponse)
+
+	go s.authDb.RevokeRefreshToken(
+		ctx, chRevokeRefreshTokenResponse, &auth.RevokeRefreshTokenModel{
+			UserId:    model.UserId,
+			TokenHash: hasher.HashToken(model.RefreshToken),
+		},
+	)
+
+	revokeRefreshTokenResponse := <-chRevokeRefreshTokenResponse
+	if revokeRefreshTokenResponse.Error != nil {
+		ch <- &LogoutServiceResponse{Error: revokeRefreshTokenResponse.Error}
+		return
+	}
+
+	s.auditService.Record(&audit.RecordServiceModel{
+		EventType: audit.EventLogout,
+		UserId:    model.UserId,
+		UserName:  model.UserName,
+		ClientIp:  model.ClientIp,
+		UserAgent: model.UserAgent,
+		Details:   map[string]interface{}{"revokedCount": revokeRefreshTokenResponse.RevokedCount},
+	})
+
+	ch <- &LogoutServiceResponse{RevokedCount: revokeRefreshTokenResponse.RevokedCount}
+}
+
+// LogoutAll
+// Revokes every refresh and access token of the user, logging it out from all devices.
+func (s *AuthService) LogoutAll(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutAllServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &LogoutServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRevokeUserResponse := make(chan *revocation.RevokeUserServiceResponse)
+	defer close(chRevokeUserResponse)
+
+	go s.revocationService.RevokeUser(
+		ctx, chRevokeUserResponse, &revocation.RevokeUserServiceModel{
+			UserId:    model.UserId,
+			RevokedBy: model.UserId,
+		},
+	)
+
+	revokeUserResponse := <-chRevokeUserResponse
+	if revokeUserResponse.Error != nil {
+		ch <- &LogoutServiceResponse{Error: revokeUserResponse.Error}
+		return
+	}
+
+	s.auditService.Record(&audit.RecordServiceModel{
+		EventType: audit.EventLogoutAll,
+		UserId:    model.UserId,
+		UserName:  model.UserName,
+		ClientIp:  model.ClientIp,
+		UserAgent: model.UserAgent,
+		Details:   map[string]interface{}{"revokedCount": revokeUserResponse.RevokedRefreshCount},
+	})
+
+	ch <- &LogoutServiceResponse{RevokedCount: revokeUserResponse.RevokedRefreshCount}
+}
+
+// VerifyMfa
+// Completes a login which requires MFA by exchanging the challenge token and a TOTP or recovery code for tokens.
+// Returns an error if challenge is invalid or expired, or code is invalid.
+func (s *AuthService) VerifyMfa(ctx context.Context, ch chan *LoginServiceResponse, model *VerifyMfaServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &LoginServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chVerifyChallengeResponse := make(chan *mfa.VerifyChallengeServiceResponse)
+	defer close(chVerifyChallengeResponse)
+
+	go s.mfaService.VerifyChallenge(
+		ctx, chVerifyChallengeResponse, &mfa.VerifyChallengeServiceModel{
+			ChallengeToken: model.ChallengeToken,
+			Code:           model.Code,
+			RecoveryCode:   model.RecoveryCode,
+		},
+	)
+
+	verifyChallengeResponse := <-chVerifyChallengeResponse
+	err := verifyChallengeResponse.Error
+
+	// Invalid codes count as failed logins of the user, otherwise each new challenge would allow more guesses.
+	// Lockout is checked after the code, so a correct guess of a locked user is rejected the same way.
+	if verifyChallengeResponse.UserName != "" {
+		if errors.Is(err, mfa.ErrInvalidCode) {
+			err = s.registerMfaFailure(ctx, verifyChallengeResponse.UserName, model.ClientIp, err)
+		} else if err == nil {
+			err = s.checkLockout(ctx, verifyChallengeResponse.UserName, model.ClientIp)
+		}
+	}
+
+	if err != nil {
+		s.recordLogin(loginMethodMfa, verifyChallengeResponse.UserId, verifyChallengeResponse.UserName, model.ClientIp, model.UserAgent, err, nil)
+		ch <- &LoginServiceResponse{Error: err}
+		return
+	}
+
+	chGetUserByUserNameResponse := make(chan *auth.GetUserByUserNameResponse)
+	defer close(chGetUserByUserNameResponse)
+
+	go s.authDb.GetUserByUserName(
+		ctx, chGetUserByUserNameResponse, &auth.GetUserByUserNameModel{
+			UserName: verifyChallengeResponse.UserName,
+		},
+	)
+
+	// User may be deactivated or renamed while the challenge is pending.
+	user := <-chGetUserByUserNameResponse
+	if errors.Is(user.Error, sql.ErrNoRows) || (user.Error == nil && (user.Id != verifyChallengeResponse.UserId || !user.IsActive)) {
+		s.recordLogin(loginMethodMfa, verifyChallengeResponse.UserId, verifyChallengeResponse.UserName, model.ClientIp, model.UserAgent, errInactiveUser, nil)
+		ch <- &LoginServiceResponse{Error: errInvalidCredentials}
+		return
+	}
+	if user.Error != nil {
+		ch <- &LoginServiceResponse{Error: user.Error}
+		return
+	}
+
+	s.registerSuccess(ctx, user.UserName)
+
+	response := s.issueTokens(ctx, user, model.ClientIp, model.UserAgent)
+	s.recordLogin(loginMethodMfa, user.Id, user.UserName, model.ClientIp, model.UserAgent, response.Error, nil)
+
+	ch <- response
+}
+
+// ForgotPassword
+// Responds success once the rate limits pass, whether the email belongs to a user or not. The reset link is sent
+// in the background afterwards, so neither the response nor its timing reveals which emails are registered.
+func (s *AuthService) ForgotPassword(ctx context.Context, ch chan *ForgotPasswordServiceResponse, model *ForgotPasswordServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ForgotPasswordServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	err := s.limitRate(ctx, "forgot-password:ip", model.ClientIp, forgotPasswordIpRateLimit)
+	if err == nil {
+		err = s.limitRate(ctx, "forgot-password:email", strings.ToLower(model.Email), forgotPasswordEmailRateLimit)
+	}
+	if err != nil {
+		ch <- &ForgotPasswordServiceResponse{Error: err}
+		return
+	}
+
+	ch <- &ForgotPasswordServiceResponse{}
+
+	err = s.sendPasswordResetLink(requestcontext.Detach(ctx), model.Email)
+	if err != nil {
+		s.loggr.Error("Password reset link could not be sent.", zap.Error(err))
+	}
+}
+
+// sendPasswordResetLink
+// Adds a password reset token for the active user with a password and sends its link to the email.
+// Does nothing if email does not belong to such a user.
+func (s *AuthService) sendPasswordResetLink(ctx context.Context, email string) error {
+	chGetUserByEmailResponse := make(chan *auth.GetUserByEmailResponse)
+	defer close(chGetUserByEmailResponse)
+
+	go s.authDb.GetUserByEmail(
+		ctx, chGetUserByEmailResponse, &auth.GetUserByEmailModel{
+			Email: email,
+		},
+	)
+
+	user := <-chGetUserByEmailResponse
+	if errors.Is(user.Error, sql.ErrNoRows) {
+		return nil
+	}
+	if user.Error != nil {
+		return user.Error
+	}
+
+	if !user.IsActive || user.IsProgrammatic || !user.HasPassword {
+		s.loggr.Info("Password reset is requested for an inactive, programmatic or external user.", zap.Int64("userId", user.Id))
+		return nil
+	}
+
+	chAddPasswordResetTokenResponse := make(chan *auth.AddPasswordResetTokenResponse)
+	defer close(chAddPasswordResetTokenResponse)
+
+	resetToken := uuid.NewString()
+	go s.authDb.AddPasswordResetToken(
+		ctx, chAddPasswordResetTokenResponse, &auth.AddPasswordResetTokenModel{
+			UserId:    user.Id,
+			TokenHash: hasher.HashToken(resetToken),
+		},
+	)
+
+	addPasswordResetTokenResponse := <-chAddPasswordResetTokenResponse
+	if addPasswordResetTokenResponse.Error != nil {
+		return addPasswordResetTokenResponse.Error
+	}
+
+	chSendNotificationResponse := make(chan *notification.SendNotificationResponse)
+	defer close(chSendNotificationResponse)
+
+	go s.notificationSender.Send(
+		ctx, chSendNotificationResponse, &notification.SendNotificationModel{
+			Recipient: user.Email,
+			Subject:   "Reset your password",
+			Body:      "Please use the link below to reset your password. The link expires in an hour.\n" + helper.GetTokenLink(s.environment, s.loggr, env.AuthPasswordResetUrl, resetToken),
+		},
+	)
+
+	return (<-chSendNotificationResponse).Error
+}
+
+// ResetPassword
+// Sets a new password with a password reset token and revokes every refresh token of the user.
+// Returns an error if token is unknown, expired or already used.
+func (s *AuthService) ResetPassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ResetPasswordServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &PasswordServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	err := s.limitRate(ctx, "reset-password:ip", model.ClientIp, resetPasswordIpRateLimit)
+	if err != nil {
+		ch <- &PasswordServiceResponse{Error: err}
+		return
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(model.NewPassword)
+	if err != nil {
+		ch <- &PasswordServiceResponse{Error: err}
+		return
+	}
+
+	chResetPasswordResponse := make(chan *auth.ResetPasswordResponse)
+	defer close(chResetPasswordResponse)
+
+	go s.authDb.ResetPassword(
+		ctx, chResetPasswordResponse, &auth.ResetPasswordModel{
+			TokenHash:    hasher.HashToken(model.ResetToken),
+			PasswordHash: passwordHash,
 		},
-		Username: username,
-		Email:    email,
+	)
+
+	resetPasswordResponse := <-chResetPasswordResponse
+	if errors.Is(resetPasswordResponse.Error, sql.ErrNoRows) {
+		ch <- &PasswordServiceResponse{Error: customerror.New(errors.New("reset token is invalid or expired"), customerror.LogLevelInfo)}
+		return
+	}
+	if resetPasswordResponse.Error != nil {
+		ch <- &PasswordServiceResponse{Error: resetPasswordResponse.Error}
+		return
+	}
+
+	s.loggr.Info("Password is reset.", zap.Int64("userId", resetPasswordResponse.UserId))
+
+	ch <- s.revokeSessions(ctx, resetPasswordResponse.UserId)
+}
+
+// ChangePassword
+// Replaces password of the authenticated user after verifying the current one and revokes every refresh token of the user.
+// Returns an error if current password is wrong.
+func (s *AuthService) ChangePassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ChangePasswordServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &PasswordServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	err := s.limitRate(ctx, "change-password:user", strconv.FormatInt(model.UserId, 10), changePasswordUserRateLimit)
+	if err != nil {
+		ch <- &PasswordServiceResponse{Error: err}
+		return
+	}
+
+	chGetUserByUserNameResponse := make(chan *auth.GetUserByUserNameResponse)
+	defer close(chGetUserByUserNameResponse)
+
+	go s.authDb.GetUserByUserName(
+		ctx, chGetUserByUserNameResponse, &auth.GetUserByUserNameModel{
+			UserName: model.UserName,
+		},
+	)
+
+	user := <-chGetUserByUserNameResponse
+	if errors.Is(user.Error, sql.ErrNoRows) || (user.Error == nil && user.Id != model.UserId) {
+		ch <- &PasswordServiceResponse{Error: errInvalidCredentials}
+		return
+	}
+	if user.Error != nil {
+		ch <- &PasswordServiceResponse{Error: user.Error}
+		return
+	}
+
+	// Users provisioned from an identity provider have no local password.
+	if user.PasswordHash == "" {
+		ch <- &PasswordServiceResponse{Error: customerror.New(errors.New("password is managed by the identity provider"), customerror.LogLevelInfo)}
+		return
+	}
+
+	isVerified, err := s.passwordHasher.Verify(model.CurrentPassword, user.PasswordHash)
+	if err != nil {
+		ch <- &PasswordServiceResponse{Error: err}
+		return
+	}
+
+	if !isVerified {
+		ch <- &PasswordServiceResponse{Error: customerror.New(errors.New("current password is wrong"), customerror.LogLevelInfo)}
+		return
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(model.NewPassword)
+	if err != nil {
+		ch <- &PasswordServiceResponse{Error: err}
+		return
+	}
+
+	chUpdatePasswordHashResponse := make(chan *auth.UpdatePasswordHashResponse)
+	defer close(chUpdatePasswordHashResponse)
+
+	go s.authDb.UpdatePasswordHash(
+		ctx, chUpdatePasswordHashResponse, &auth.UpdatePasswordHashModel{
+			UserId:          user.Id,
+			PasswordHash:    passwordHash,
+			OldPasswordHash: user.PasswordHash,
+		},
+	)
+
+	updatePasswordHashResponse := <-chUpdatePasswordHashResponse
+	if updatePasswordHashResponse.Error != nil {
+		ch <- &PasswordServiceResponse{Error: updatePasswordHashResponse.Error}
+		return
+	}
+
+	// Password is changed by another request after it is verified.
+	if !updatePasswordHashResponse.IsUpdated {
+		ch <- &PasswordServiceResponse{Error: customerror.New(errors.New("password is changed concurrently"), customerror.LogLevelWarn)}
+		return
+	}
+
+	s.loggr.Info("Password is changed.", zap.Int64("userId", user.Id))
+
+	ch <- s.revokeSessions(ctx, user.Id)
+}
+
+// GetOidcLoginUrl
+// Returns the url of the identity provider to redirect the user for login.
+func (s *AuthService) GetOidcLoginUrl(ctx context.Context, ch chan *GetOidcLoginUrlServiceResponse, model *GetOidcLoginUrlServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetOidcLoginUrlServiceResponse{Error: modelErr}
+		return
+	}
+
+	chGetLoginUrlResponse := make(chan *oidc.GetLoginUrlServiceResponse)
+	defer close(chGetLoginUrlResponse)
+
+	go s.oidcService.GetLoginUrl(ctx, chGetLoginUrlResponse, &oidc.GetLoginUrlServiceModel{})
+
+	getLoginUrlResponse := <-chGetLoginUrlResponse
+	if getLoginUrlResponse.Error != nil {
+		ch <- &GetOidcLoginUrlServiceResponse{Error: getLoginUrlResponse.Error}
+		return
+	}
+
+	ch <- &GetOidcLoginUrlServiceResponse{Url: getLoginUrlResponse.Url, State: getLoginUrlResponse.State}
+}
+
+// LoginWithOidc
+// Completes a login via the identity provider and issues our own tokens for the linked user.
+// User is provisioned on first login. MFA is left to the identity provider.
+func (s *AuthService) LoginWithOidc(ctx context.Context, ch chan *LoginServiceResponse, model *LoginWithOidcServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &LoginServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chAuthenticateResponse := make(chan *oidc.AuthenticateServiceResponse)
+	defer close(chAuthenticateResponse)
+
+	go s.oidcService.Authenticate(
+		ctx, chAuthenticateResponse, &oidc.AuthenticateServiceModel{
+			Code:  model.Code,
+			State: model.State,
+		},
+	)
+
+	authenticateResponse := <-chAuthenticateResponse
+	if authenticateResponse.Error != nil {
+		s.recordLogin(loginMethodOidc, 0, "", model.ClientIp, model.UserAgent, authenticateResponse.Error, nil)
+		ch <- &LoginServiceResponse{Error: authenticateResponse.Error}
+		return
+	}
+
+	response := s.issueTokens(ctx, &auth.GetUserByUserNameResponse{
+		Id:       authenticateResponse.UserId,
+		UserName: authenticateResponse.UserName,
+		Email:    authenticateResponse.Email,
+		IsActive: true,
+	}, model.ClientIp, model.UserAgent)
+	s.recordLogin(loginMethodOidc, authenticateResponse.UserId, authenticateResponse.UserName, model.ClientIp, model.UserAgent, response.Error, nil)
+
+	ch <- response
+}
+
+// Introspect
+// Returns claims of an access token issued by us if it is active, as described in RFC 7662.
+// Invalid, expired and revoked tokens are reported as inactive instead of an error.
+func (s *AuthService) Introspect(ctx context.Context, ch chan *IntrospectServiceResponse, model *IntrospectServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &IntrospectServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	claims, err := util.ParseClaims(model.Token, s.keyrng)
+	if err != nil {
+		ch <- &IntrospectServiceResponse{Active: false}
+		return
+	}
+
+	userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || claims.IssuedAt == nil {
+		ch <- &IntrospectServiceResponse{Active: false}
+		return
+	}
+
+	chIsRevokedResponse := make(chan *revocation.IsRevokedServiceResponse)
+	defer close(chIsRevokedResponse)
+
+	go s.revocationService.IsRevoked(
+		ctx, chIsRevokedResponse, &revocation.IsRevokedServiceModel{
+			Jti:      claims.ID,
+			UserId:   userId,
+			IssuedAt: claims.IssuedAt.Time,
+		},
+	)
+
+	isRevokedResponse := <-chIsRevokedResponse
+	if isRevokedResponse.Error != nil {
+		ch <- &IntrospectServiceResponse{Error: isRevokedResponse.Error}
+		return
+	}
+
+	if isRevokedResponse.IsRevoked {
+		ch <- &IntrospectServiceResponse{Active: false}
+		return
+	}
+
+	response := IntrospectServiceResponse{
+		Active:    true,
+		TokenType: "Bearer",
+		Scope:     strings.Join(claims.Permissions, " "),
+		Username:  claims.Username,
+		Email:     claims.Email,
+		Roles:     claims.Roles,
+		Subject:   claims.Subject,
+		Issuer:    claims.Issuer,
+		TokenId:   claims.ID,
+		IssuedAt:  claims.IssuedAt.Unix(),
+	}
+	if claims.NotBefore != nil {
+		response.NotBefore = claims.NotBefore.Unix()
+	}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+
+	ch <- &response
+}
+
+// GetSessions
+// Gets active sessions of the user with their device, ip and dates.
+func (s *AuthService) GetSessions(ctx context.Context, ch chan *GetSessionsServiceResponse, model *GetSessionsServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetSessionsServiceResponse{Error: modelErr}
+		return
+	}
+
+	chGetSessionsResponse := make(chan *auth.GetSessionsResponse)
+	defer close(chGetSessionsResponse)
+
+	go s.authDb.GetSessions(ctx, chGetSessionsResponse, &auth.GetSessionsModel{UserId: model.UserId})
+
+	getSessionsResponse := <-chGetSessionsResponse
+	if getSessionsResponse.Error != nil {
+		ch <- &GetSessionsServiceResponse{Error: getSessionsResponse.Error}
+		return
+	}
+
+	ch <- &GetSessionsServiceResponse{Sessions: getSessionsResponse.Sessions}
+}
+
+// RevokeSession
+// Revokes a single session of the user, so its refresh token can no longer be used.
+// Returns an error if the session is not found or already revoked.
+func (s *AuthService) RevokeSession(ctx context.Context, ch chan *RevokeSessionServiceResponse, model *RevokeSessionServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeSessionServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRevokeSessionResponse := make(chan *auth.RevokeRefreshTokenResponse)
+	defer close(chRevokeSessionResponse)
+
+	go s.authDb.RevokeSession(
+		ctx, chRevokeSessionResponse, &auth.RevokeSessionModel{
+			UserId:    model.UserId,
+			SessionId: model.SessionId,
+		},
+	)
+
+	revokeSessionResponse := <-chRevokeSessionResponse
+	if errors.Is(revokeSessionResponse.Error, sql.ErrNoRows) {
+		ch <- &RevokeSessionServiceResponse{Error: customerror.New(errors.New("session is not found"), customerror.LogLevelInfo)}
+		return
+	}
+	if revokeSessionResponse.Error != nil {
+		ch <- &RevokeSessionServiceResponse{Error: revokeSessionResponse.Error}
+		return
+	}
+
+	ch <- &RevokeSessionServiceResponse{IsRevoked: true, RevokedCount: revokeSessionResponse.RevokedCount}
+}
+
+// revokeSessions
+// Revokes every refresh and access token of the user after a password change.
+func (s *AuthService) revokeSessions(ctx context.Context, userId int64) *PasswordServiceResponse {
+	chRevokeUserResponse := make(chan *revocation.RevokeUserServiceResponse)
+	defer close(chRevokeUserResponse)
+
+	go s.revocationService.RevokeUser(
+		ctx, chRevokeUserResponse, &revocation.RevokeUserServiceModel{
+			UserId:    userId,
+			RevokedBy: userId,
+		},
+	)
+
+	revokeUserResponse := <-chRevokeUserResponse
+	if revokeUserResponse.Error != nil {
+		return &PasswordServiceResponse{Error: revokeUserResponse.Error}
+	}
+
+	return &PasswordServiceResponse{IsChanged: true, RevokedCount: revokeUserResponse.RevokedRefreshCount}
+}
+
+// limitRate
+// Counts a request for the key within the window of given rate limit.
+// Returns a rate limited error once the limit is exceeded. Requests are allowed when the cache is unavailable.
+func (s *AuthService) limitRate(ctx context.Context, name string, key string, rateLimit rateLimit) error {
+	cacheKey := "auth-rate-limit:" + name + ":" + key
+
+	count, err := s.cachr.Increment(ctx, cacheKey, rateLimit.window)
+	if err != nil {
+		s.loggr.Error("Rate limit could not be checked.", zap.String("name", name), zap.Error(err))
+		return nil
+	}
+
+	if count <= rateLimit.limit {
+		return nil
+	}
+
+	retryAfter, err := s.cachr.TimeToLive(ctx, cacheKey)
+	if err != nil {
+		retryAfter = rateLimit.window
+	}
+
+	return retryAfterError(customerror.ErrRateLimited, retryAfter)
+}
+
+// authenticate
+// Gets user by username and verifies given password against stored hash.
+// Rehashes the password with the preferred algorithm if stored hash is outdated.
+// Failed attempts are only reset by the caller once every login step passed, so MFA guesses keep counting.
+// Returns a lockout error if username or client ip has too many failed attempts.
+func (s *AuthService) authenticate(ctx context.Context, userName string, password string, clientIp string) (*auth.GetUserByUserNameResponse, error) {
+	err := s.checkLockout(ctx, userName, clientIp)
+	if err != nil {
+		return nil, err
+	}
+
+	chGetUserByUserNameResponse := make(chan *auth.GetUserByUserNameResponse)
+	defer close(chGetUserByUserNameResponse)
+
+	go s.authDb.GetUserByUserName(
+		ctx, chGetUserByUserNameResponse, &auth.GetUserByUserNameModel{
+			UserName: userName,
+		},
+	)
+
+	user := <-chGetUserByUserNameResponse
+	if errors.Is(user.Error, sql.ErrNoRows) {
+		s.verifyDummyHash(password)
+		return nil, s.registerFailure(ctx, userName, clientIp)
+	}
+	if user.Error != nil {
+		return nil, user.Error
+	}
+
+	// Users provisioned from an identity provider have no local password and can only log in via OIDC.
+	if user.PasswordHash == "" {
+		s.verifyDummyHash(password)
+		return nil, s.registerFailure(ctx, userName, clientIp)
+	}
+
+	isVerified, err := s.passwordHasher.Verify(password, user.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isVerified {
+		return nil, s.registerFailure(ctx, userName, clientIp)
+	}
+
+	if s.passwordHasher.NeedsRehash(user.PasswordHash) {
+		s.rehash(ctx, user, password)
+	}
+
+	return user, nil
+}
+
+// verifyDummyHash
+// Verifies password against a hash of a random password made with the preferred algorithm, so logins of unknown users
+// and users without a password take as long as logins with a wrong password and usernames cannot be enumerated.
+func (s *AuthService) verifyDummyHash(password string) {
+	s.dummyHashOnce.Do(func() {
+		dummyHash, err := s.passwordHasher.Hash(uuid.NewString())
+		if err != nil {
+			s.loggr.Error("Dummy password hash could not be created.", zap.Error(err))
+			return
+		}
+
+		s.dummyHash = dummyHash
+	})
+
+	if s.dummyHash != "" {
+		_, _ = s.passwordHasher.Verify(password, s.dummyHash)
+	}
+}
+
+// checkLockout
+// Returns a lockout error if username or client ip has too many failed attempts.
+func (s *AuthService) checkLockout(ctx context.Context, userName string, clientIp string) error {
+	chCheckLockoutResponse := make(chan *lockout.CheckLockoutServiceResponse)
+	defer close(chCheckLockoutResponse)
+
+	go s.lockoutService.Check(
+		ctx, chCheckLockoutResponse, &lockout.CheckLockoutServiceModel{
+			UserName: userName,
+			ClientIp: clientIp,
+		},
+	)
+
+	// Lockout is a protection layer, logins are not blocked when its store is unavailable.
+	checkLockoutResponse := <-chCheckLockoutResponse
+	if checkLockoutResponse.Error != nil {
+		s.loggr.Error("Lockout could not be checked.", zap.String("userName", userName), zap.Error(checkLockoutResponse.Error))
+		return nil
+	}
+
+	if checkLockoutResponse.IsLocked {
+		return retryAfterError(customerror.ErrLockedOut, checkLockoutResponse.RetryAfter)
+	}
+
+	return nil
+}
+
+// registerFailure
+// Counts a failed login attempt.
+// Returns a lockout error if the attempt triggered a lockout, otherwise an invalid credentials error.
+func (s *AuthService) registerFailure(ctx context.Context, userName string, clientIp string) error {
+	chRegisterFailureResponse := make(chan *lockout.CheckLockoutServiceResponse)
+	defer close(chRegisterFailureResponse)
+
+	go s.lockoutService.RegisterFailure(
+		ctx, chRegisterFailureResponse, &lockout.RegisterFailureServiceModel{
+			UserName: userName,
+			ClientIp: clientIp,
+		},
+	)
+
+	registerFailureResponse := <-chRegisterFailureResponse
+	if registerFailureResponse.Error != nil {
+		s.loggr.Error("Failed login attempt could not be registered.", zap.String("userName", userName), zap.Error(registerFailureResponse.Error))
+		return errInvalidCredentials
+	}
+
+	if registerFailureResponse.IsLocked {
+		return retryAfterError(customerror.ErrLockedOut, registerFailureResponse.RetryAfter)
+	}
+
+	return errInvalidCredentials
+}
+
+// registerMfaFailure
+// Counts an invalid MFA code as a failed login attempt of the user.
+// Returns a lockout error if the attempt triggered a lockout, otherwise given error.
+func (s *AuthService) registerMfaFailure(ctx context.Context, userName string, clientIp string, err error) error {
+	failureErr := s.registerFailure(ctx, userName, clientIp)
+	if errors.Is(failureErr, customerror.ErrLockedOut) {
+		return failureErr
+	}
+
+	return err
+}
+
+// registerSuccess
+// Resets failed login attempts of the username. Failures are only logged since user is already authenticated.
+func (s *AuthService) registerSuccess(ctx context.Context, userName string) {
+	chRegisterSuccessResponse := make(chan error)
+	defer close(chRegisterSuccessResponse)
+
+	go s.lockoutService.RegisterSuccess(
+		ctx, chRegisterSuccessResponse, &lockout.RegisterSuccessServiceModel{
+			UserName: userName,
+		},
+	)
+
+	err := <-chRegisterSuccessResponse
+	if err != nil {
+		s.loggr.Error("Failed login attempts could not be reset.", zap.String("userName", userName), zap.Error(err))
+	}
+}
+
+// recordLogin
+// Records a login attempt in the audit log. Attempts rejected by lockout are recorded as lockouts.
+func (s *AuthService) recordLogin(method string, userId int64, userName string, clientIp string, userAgent string, err error, details map[string]interface{}) {
+	eventDetails := map[string]interface{}{"method": method}
+	for key, value := range details {
+		eventDetails[key] = value
+	}
+
+	eventType := audit.EventLoginSucceeded
+	if errors.Is(err, customerror.ErrLockedOut) {
+		eventType = audit.EventLoginLockedOut
+	} else if err != nil {
+		eventType = audit.EventLoginFailed
+		eventDetails["reason"] = err.Error()
+	}
+
+	s.auditService.Record(&audit.RecordServiceModel{
+		EventType: eventType,
+		UserId:    userId,
+		UserName:  userName,
+		ClientIp:  clientIp,
+		UserAgent: userAgent,
+		Details:   eventDetails,
+	})
+}
+
+// recordRefresh
+// Records a refresh token rotation in the audit log.
+func (s *AuthService) recordRefresh(userId int64, userName string, clientIp string, userAgent string, err error) {
+	model := audit.RecordServiceModel{
+		EventType: audit.EventTokenRefreshed,
+		UserId:    userId,
+		UserName:  userName,
+		ClientIp:  clientIp,
+		UserAgent: userAgent,
+	}
+
+	if err != nil {
+		model.EventType = audit.EventTokenRefreshFailed
+		model.Details = map[string]interface{}{"reason": err.Error()}
+	}
+
+	s.auditService.Record(&model)
+}
+
+// retryAfterError
+// Wraps a sentinel error with the time left until requests are allowed again.
+func retryAfterError(err error, retryAfter time.Duration) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	return customerror.New(fmt.Errorf("%w, retry after %d seconds", err, seconds), customerror.LogLevelWarn)
+}
+
+// rehash
+// Upgrades stored password hash of the user. Failures are only logged since user is already authenticated.
+func (s *AuthService) rehash(ctx context.Context, user *auth.GetUserByUserNameResponse, password string) {
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		s.loggr.Error("Password could not be rehashed.", zap.Int64("userId", user.Id), zap.Error(err))
+		return
+	}
+
+	chUpdatePasswordHashResponse := make(chan *auth.UpdatePasswordHashResponse)
+	defer close(chUpdatePasswordHashResponse)
+
+	go s.authDb.UpdatePasswordHash(
+		ctx, chUpdatePasswordHashResponse, &auth.UpdatePasswordHashModel{
+			UserId:          user.Id,
+			PasswordHash:    passwordHash,
+			OldPasswordHash: user.PasswordHash,
+		},
+	)
+
+	updatePasswordHashResponse := <-chUpdatePasswordHashResponse
+	if updatePasswordHashResponse.Error != nil {
+		s.loggr.Error("Password hash could not be updated.", zap.Int64("userId", user.Id), zap.Error(updatePasswordHashResponse.Error))
+		return
+	}
+
+	user.PasswordHash = passwordHash
+}
+
+// issueTokens
+// Adds a refresh token starting a new token family and generates a JWT for an authenticated user.
+// Client ip and user agent are kept with the refresh token to describe the session.
+// Both are done in a unit of work, so no refresh token is left behind if the JWT cannot be generated.
+func (s *AuthService) issueTokens(ctx context.Context, user *auth.GetUserByUserNameResponse, clientIp string, userAgent string) *LoginServiceResponse {
+	refreshToken := uuid.NewString()
+
+	var tokenString string
+	err := s.unitOfWork.Run(ctx, func(tx database.ITransaction) error {
+		chAddRefreshTokenResponse := make(chan *auth.AddRefreshTokenResponse)
+		defer close(chAddRefreshTokenResponse)
+
+		go s.authDb.AddRefreshToken(
+			ctx, chAddRefreshTokenResponse, &auth.AddRefreshTokenModel{
+				UserId:      int(user.Id),
+				TokenHash:   hasher.HashToken(refreshToken),
+				FamilyId:    uuid.NewString(),
+				UserAgent:   helper.Truncate(userAgent, maxUserAgentLength),
+				ClientIp:    clientIp,
+				Transaction: tx,
+			},
+		)
+
+		addRefreshTokenResponse := <-chAddRefreshTokenResponse
+		if addRefreshTokenResponse.Error != nil {
+			return addRefreshTokenResponse.Error
+		}
+
+		var err error
+		tokenString, err = s.generateJwt(ctx, user.Id, user.UserName, user.Email, 0, tx)
+		return err
+	})
+	if err != nil {
+		return &LoginServiceResponse{Error: err}
+	}
+
+	return &LoginServiceResponse{
+		JwtToken:     tokenString,
+		RefreshToken: refreshToken,
+	}
+}
+
+// generateJwt
+// Generates a signed JWT with roles and permissions of the user attached to its claims.
+// Each token gets a unique jti so it can be revoked before it expires.
+// Permissions are read inside transaction if it is given.
+func (s *AuthService) generateJwt(ctx context.Context, id int64, username string, email string, expiryDays int32, transaction database.ITransaction) (string, error) {
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Minute * 30))
+	if expiryDays != 0 {
+		expiresAt = jwt.NewNumericDate(time.Now().AddDate(0, 0, int(expiryDays)))
+	}
+
+	chGetUserPermissionsResponse := make(chan *auth.GetUserPermissionsResponse)
+	defer close(chGetUserPermissionsResponse)
+
+	go s.authDb.GetUserPermissions(
+		ctx, chGetUserPermissionsResponse, &auth.GetUserPermissionsModel{
+			UserId:      id,
+			Transaction: transaction,
+		},
+	)
+
+	getUserPermissionsResponse := <-chGetUserPermissionsResponse
+	if getUserPermissionsResponse.Error != nil {
+		return "", getUserPermissionsResponse.Error
+	}
+
+	claims := util.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    "Delivery Hero",
+			Subject:   strconv.FormatInt(id, 10),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: expiresAt,
+		},
+		Username:    username,
+		Email:       email,
+		Roles:       getUserPermissionsResponse.Roles,
+		Permissions: getUserPermissionsResponse.Permissions,
+	}
+
+	key, err := s.keyrng.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.Id != "" {
+		token.Header["kid"] = key.Id
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.environment.Get(env.JwtSecret)))
+	tokenString, err := token.SignedString(key.Private)
 
 	if err != nil {
 		return "", err
@@ -5,6 +5,7 @@
 package auth
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,38 +34,194 @@ func (m *MockIAuthService) EXPECT() *MockIAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// Activate mocks base method.
+func (m *MockIAuthService) Activate(ctx context.Context, ch chan *ActivateServiceResponse, model *ActivateServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Activate", ctx, ch, model)
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockIAuthServiceMockRecorder) Activate(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockIAuthService)(nil).Activate), ctx, ch, model)
+}
+
+// ChangePassword mocks base method.
+func (m *MockIAuthService) ChangePassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ChangePasswordServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ChangePassword", ctx, ch, model)
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIAuthServiceMockRecorder) ChangePassword(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIAuthService)(nil).ChangePassword), ctx, ch, model)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockIAuthService) ForgotPassword(ctx context.Context, ch chan *ForgotPasswordServiceResponse, model *ForgotPasswordServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgotPassword", ctx, ch, model)
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockIAuthServiceMockRecorder) ForgotPassword(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockIAuthService)(nil).ForgotPassword), ctx, ch, model)
+}
+
 // GetAccessToken mocks base method.
-func (m *MockIAuthService) GetAccessToken(ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel) {
+func (m *MockIAuthService) GetAccessToken(ctx context.Context, ch chan *LoginServiceResponse, model *GetAccessTokenServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetAccessToken", ch, model)
+	m.ctrl.Call(m, "GetAccessToken", ctx, ch, model)
 }
 
 // GetAccessToken indicates an expected call of GetAccessToken.
-func (mr *MockIAuthServiceMockRecorder) GetAccessToken(ch, model interface{}) *gomock.Call {
+func (mr *MockIAuthServiceMockRecorder) GetAccessToken(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessToken", reflect.TypeOf((*MockIAuthService)(nil).GetAccessToken), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessToken", reflect.TypeOf((*MockIAuthService)(nil).GetAccessToken), ctx, ch, model)
+}
+
+// GetOidcLoginUrl mocks base method.
+func (m *MockIAuthService) GetOidcLoginUrl(ctx context.Context, ch chan *GetOidcLoginUrlServiceResponse, model *GetOidcLoginUrlServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetOidcLoginUrl", ctx, ch, model)
+}
+
+// GetOidcLoginUrl indicates an expected call of GetOidcLoginUrl.
+func (mr *MockIAuthServiceMockRecorder) GetOidcLoginUrl(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOidcLoginUrl", reflect.TypeOf((*MockIAuthService)(nil).GetOidcLoginUrl), ctx, ch, model)
 }
 
 // GetProgrammaticAccessToken mocks base method.
-func (m *MockIAuthService) GetProgrammaticAccessToken(ch chan *GetProgrammaticAccessTokenServiceResponse, model *GetProgrammaticAccessTokenServiceModel) {
+func (m *MockIAuthService) GetProgrammaticAccessToken(ctx context.Context, ch chan *GetProgrammaticAccessTokenServiceResponse, model *GetProgrammaticAccessTokenServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetProgrammaticAccessToken", ch, model)
+	m.ctrl.Call(m, "GetProgrammaticAccessToken", ctx, ch, model)
 }
 
 // GetProgrammaticAccessToken indicates an expected call of GetProgrammaticAccessToken.
-func (mr *MockIAuthServiceMockRecorder) GetProgrammaticAccessToken(ch, model interface{}) *gomock.Call {
+func (mr *MockIAuthServiceMockRecorder) GetProgrammaticAccessToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgrammaticAccessToken", reflect.TypeOf((*MockIAuthService)(nil).GetProgrammaticAccessToken), ctx, ch, model)
+}
+
+// GetSessions mocks base method.
+func (m *MockIAuthService) GetSessions(ctx context.Context, ch chan *GetSessionsServiceResponse, model *GetSessionsServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSessions", ctx, ch, model)
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockIAuthServiceMockRecorder) GetSessions(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgrammaticAccessToken", reflect.TypeOf((*MockIAuthService)(nil).GetProgrammaticAccessToken), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockIAuthService)(nil).GetSessions), ctx, ch, model)
+}
+
+// Introspect mocks base method.
+func (m *MockIAuthService) Introspect(ctx context.Context, ch chan *IntrospectServiceResponse, model *IntrospectServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Introspect", ctx, ch, model)
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockIAuthServiceMockRecorder) Introspect(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockIAuthService)(nil).Introspect), ctx, ch, model)
 }
 
 // Login mocks base method.
-func (m *MockIAuthService) Login(ch chan *LoginServiceResponse, model *LoginServiceModel) {
+func (m *MockIAuthService) Login(ctx context.Context, ch chan *LoginServiceResponse, model *LoginServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Login", ch, model)
+	m.ctrl.Call(m, "Login", ctx, ch, model)
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockIAuthServiceMockRecorder) Login(ch, model interface{}) *gomock.Call {
+func (mr *MockIAuthServiceMockRecorder) Login(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAuthService)(nil).Login), ctx, ch, model)
+}
+
+// LoginWithOidc mocks base method.
+func (m *MockIAuthService) LoginWithOidc(ctx context.Context, ch chan *LoginServiceResponse, model *LoginWithOidcServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LoginWithOidc", ctx, ch, model)
+}
+
+// LoginWithOidc indicates an expected call of LoginWithOidc.
+func (mr *MockIAuthServiceMockRecorder) LoginWithOidc(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithOidc", reflect.TypeOf((*MockIAuthService)(nil).LoginWithOidc), ctx, ch, model)
+}
+
+// Logout mocks base method.
+func (m *MockIAuthService) Logout(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Logout", ctx, ch, model)
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIAuthServiceMockRecorder) Logout(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIAuthService)(nil).Logout), ctx, ch, model)
+}
+
+// LogoutAll mocks base method.
+func (m *MockIAuthService) LogoutAll(ctx context.Context, ch chan *LogoutServiceResponse, model *LogoutAllServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogoutAll", ctx, ch, model)
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockIAuthServiceMockRecorder) LogoutAll(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockIAuthService)(nil).LogoutAll), ctx, ch, model)
+}
+
+// Register mocks base method.
+func (m *MockIAuthService) Register(ctx context.Context, ch chan *RegisterServiceResponse, model *RegisterServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", ctx, ch, model)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockIAuthServiceMockRecorder) Register(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthService)(nil).Register), ctx, ch, model)
+}
+
+// ResetPassword mocks base method.
+func (m *MockIAuthService) ResetPassword(ctx context.Context, ch chan *PasswordServiceResponse, model *ResetPasswordServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetPassword", ctx, ch, model)
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIAuthServiceMockRecorder) ResetPassword(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIAuthService)(nil).ResetPassword), ctx, ch, model)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIAuthService) RevokeSession(ctx context.Context, ch chan *RevokeSessionServiceResponse, model *RevokeSessionServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeSession", ctx, ch, model)
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIAuthServiceMockRecorder) RevokeSession(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIAuthService)(nil).RevokeSession), ctx, ch, model)
+}
+
+// VerifyMfa mocks base method.
+func (m *MockIAuthService) VerifyMfa(ctx context.Context, ch chan *LoginServiceResponse, model *VerifyMfaServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "VerifyMfa", ctx, ch, model)
+}
+
+// VerifyMfa indicates an expected call of VerifyMfa.
+func (mr *MockIAuthServiceMockRecorder) VerifyMfa(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAuthService)(nil).Login), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMfa", reflect.TypeOf((*MockIAuthService)(nil).VerifyMfa), ctx, ch, model)
 }
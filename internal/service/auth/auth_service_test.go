@@ -0,0 +1,984 @@
+package auth
+
+import (
+	"context"
+	"go-clean-architecture/internal/data/database"
+	authDb "go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/data/notification"
+	"go-clean-architecture/internal/service/audit"
+	"go-clean-architecture/internal/service/lockout"
+	"go-clean-architecture/internal/service/mfa"
+	"go-clean-architecture/internal/service/oidc"
+	"go-clean-architecture/internal/service/revocation"
+	"go-clean-architecture/internal/util"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/keyring"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type AuthServiceTestSuite struct {
+	suite.Suite
+	authService        IAuthService
+	mockEnvironment    *env.MockIEnvironment
+	mockLogger         *logger.MockILogger
+	mockValidator      *validator.MockIValidator
+	mockCacher         *cacher.MockICacher
+	mockAuthDb         *authDb.MockIAuthDb
+	mockPasswordHasher *hasher.MockIPasswordHasher
+	mockNotification   *notification.MockINotificationSender
+	mockKeyring        *keyring.MockIKeyring
+	mockLockout        *lockout.MockILockoutService
+	mockMfa            *mfa.MockIMfaService
+	mockOidc           *oidc.MockIOidcService
+	mockRevocation     *revocation.MockIRevocationService
+	mockAudit          *audit.MockIAuditService
+	auditEvents        []*audit.RecordServiceModel
+	unitOfWork         *database.FakeUnitOfWork
+}
+
+// Run suite.
+func TestAuthService(t *testing.T) {
+	suite.Run(t, new(AuthServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *AuthServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockAuthDb = authDb.NewMockIAuthDb(ctrl)
+	s.mockPasswordHasher = hasher.NewMockIPasswordHasher(ctrl)
+	s.mockNotification = notification.NewMockINotificationSender(ctrl)
+	s.mockKeyring = keyring.NewMockIKeyring(ctrl)
+	s.mockLockout = lockout.NewMockILockoutService(ctrl)
+	s.mockMfa = mfa.NewMockIMfaService(ctrl)
+	s.mockOidc = oidc.NewMockIOidcService(ctrl)
+	s.mockRevocation = revocation.NewMockIRevocationService(ctrl)
+	s.mockAudit = audit.NewMockIAuditService(ctrl)
+	s.auditEvents = nil
+	s.unitOfWork = database.NewFakeUnitOfWork()
+
+	s.mockKeyring.EXPECT().SigningKey().Return(&keyring.Key{Method: jwt.SigningMethodHS256, Private: []byte("test_secret")}, nil).AnyTimes()
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockAuthDb.
+		EXPECT().
+		GetUserPermissions(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserPermissionsResponse, model *authDb.GetUserPermissionsModel) {
+			ch <- &authDb.GetUserPermissionsResponse{Roles: []string{"admin"}, Permissions: []string{"sample:publish"}}
+		}).
+		AnyTimes()
+	s.mockAudit.
+		EXPECT().
+		Record(gomock.Any()).
+		Do(func(model *audit.RecordServiceModel) {
+			s.auditEvents = append(s.auditEvents, model)
+		}).
+		AnyTimes()
+
+	s.authService = NewAuthService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockAuthDb, s.mockPasswordHasher, s.mockNotification, s.mockKeyring, s.mockLockout, s.mockMfa, s.mockOidc, s.mockRevocation, s.mockAudit, s.unitOfWork)
+}
+
+func (s *AuthServiceTestSuite) expectGetUserByUserName(passwordHash string) {
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByUserName(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.GetUserByUserNameModel{UserName: "john"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByUserNameResponse, model *authDb.GetUserByUserNameModel) {
+			ch <- &authDb.GetUserByUserNameResponse{Id: 1, UserName: "john", PasswordHash: passwordHash, IsActive: true}
+		})
+}
+
+func (s *AuthServiceTestSuite) expectLockoutCheck(response *lockout.CheckLockoutServiceResponse) {
+	s.mockLockout.
+		EXPECT().
+		Check(gomock.Any(), gomock.Any(), gomock.Eq(&lockout.CheckLockoutServiceModel{UserName: "john", ClientIp: "10.0.0.1"})).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.CheckLockoutServiceModel) {
+			ch <- response
+		})
+}
+
+func (s *AuthServiceTestSuite) expectRegisterSuccess() {
+	s.mockLockout.
+		EXPECT().
+		RegisterSuccess(gomock.Any(), gomock.Any(), gomock.Eq(&lockout.RegisterSuccessServiceModel{UserName: "john"})).
+		DoAndReturn(func(ctx context.Context, ch chan error, model *lockout.RegisterSuccessServiceModel) {
+			ch <- nil
+		})
+}
+
+func (s *AuthServiceTestSuite) expectRegisterFailure(response *lockout.CheckLockoutServiceResponse) {
+	s.mockLockout.
+		EXPECT().
+		RegisterFailure(gomock.Any(), gomock.Any(), gomock.Eq(&lockout.RegisterFailureServiceModel{UserName: "john", ClientIp: "10.0.0.1"})).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.RegisterFailureServiceModel) {
+			ch <- response
+		})
+}
+
+func (s *AuthServiceTestSuite) expectMfaStatus(isEnabled bool) {
+	s.mockMfa.
+		EXPECT().
+		GetStatus(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.GetStatusServiceModel{UserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.GetStatusServiceResponse, model *mfa.GetStatusServiceModel) {
+			ch <- &mfa.GetStatusServiceResponse{IsEnabled: isEnabled}
+		})
+}
+
+func (s *AuthServiceTestSuite) expectAddRefreshToken() {
+	s.mockAuthDb.
+		EXPECT().
+		AddRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddRefreshTokenResponse, model *authDb.AddRefreshTokenModel) {
+			ch <- &authDb.AddRefreshTokenResponse{}
+		})
+}
+
+func (s *AuthServiceTestSuite) TestLogin_WrongPassword_ReturnsError() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("stored_hash")
+	s.mockPasswordHasher.EXPECT().Verify("wrong", "stored_hash").Return(false, nil)
+	s.expectRegisterFailure(&lockout.CheckLockoutServiceResponse{})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "wrong", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "user is not found")
+	s.Empty(response.JwtToken)
+	s.Len(s.auditEvents, 1)
+	s.Equal(audit.EventLoginFailed, s.auditEvents[0].EventType)
+	s.Equal("10.0.0.1", s.auditEvents[0].ClientIp)
+	s.Equal("user is not found", s.auditEvents[0].Details["reason"])
+}
+
+func (s *AuthServiceTestSuite) TestLogin_UserWithoutPassword_VerifiesDummyHashAndReturnsError() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("")
+	s.mockPasswordHasher.EXPECT().Hash(gomock.Any()).Return("dummy_hash", nil)
+	s.mockPasswordHasher.EXPECT().Verify("any", "dummy_hash").Return(false, nil)
+	s.expectRegisterFailure(&lockout.CheckLockoutServiceResponse{})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "any", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "user is not found")
+}
+
+func (s *AuthServiceTestSuite) TestLogin_UnknownUser_VerifiesSameDummyHashEachTime() {
+	// Given
+	s.mockLockout.
+		EXPECT().
+		Check(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.CheckLockoutServiceModel) {
+			ch <- &lockout.CheckLockoutServiceResponse{}
+		}).
+		Times(2)
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByUserName(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.GetUserByUserNameModel{UserName: "john"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByUserNameResponse, model *authDb.GetUserByUserNameModel) {
+			ch <- &authDb.GetUserByUserNameResponse{Error: sql.ErrNoRows}
+		}).
+		Times(2)
+	s.mockPasswordHasher.EXPECT().Hash(gomock.Any()).Return("dummy_hash", nil).Times(1)
+	s.mockPasswordHasher.EXPECT().Verify("any", "dummy_hash").Return(false, nil).Times(2)
+	s.mockLockout.
+		EXPECT().
+		RegisterFailure(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.RegisterFailureServiceModel) {
+			ch <- &lockout.CheckLockoutServiceResponse{}
+		}).
+		Times(2)
+
+	// When
+	var responses []*LoginServiceResponse
+	for i := 0; i < 2; i++ {
+		ch := make(chan *LoginServiceResponse)
+		go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "any", ClientIp: "10.0.0.1"})
+		responses = append(responses, <-ch)
+		close(ch)
+	}
+
+	// Then
+	for _, response := range responses {
+		s.EqualError(response.Error, "user is not found")
+	}
+}
+
+func (s *AuthServiceTestSuite) TestLogin_LockedOut_ReturnsLockedOutError() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{IsLocked: true, RetryAfter: time.Second * 90})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrLockedOut)
+	s.EqualError(response.Error, "too many failed attempts, retry after 90 seconds")
+	s.Empty(response.JwtToken)
+}
+
+func (s *AuthServiceTestSuite) TestLogin_FailureReachesLimit_ReturnsLockedOutError() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("stored_hash")
+	s.mockPasswordHasher.EXPECT().Verify("wrong", "stored_hash").Return(false, nil)
+	s.expectRegisterFailure(&lockout.CheckLockoutServiceResponse{IsLocked: true, RetryAfter: time.Minute})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "wrong", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrLockedOut)
+	s.Len(s.auditEvents, 1)
+	s.Equal(audit.EventLoginLockedOut, s.auditEvents[0].EventType)
+}
+
+func (s *AuthServiceTestSuite) TestLogin_CurrentHash_DoesNotRehash() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("current_hash")
+	s.mockPasswordHasher.EXPECT().Verify("secret", "current_hash").Return(true, nil)
+	s.expectRegisterSuccess()
+	s.mockPasswordHasher.EXPECT().NeedsRehash("current_hash").Return(false)
+	s.expectMfaStatus(false)
+	s.expectAddRefreshToken()
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.NotEmpty(response.JwtToken)
+	s.NotEmpty(response.RefreshToken)
+	s.Len(s.auditEvents, 1)
+	s.Equal(audit.EventLoginSucceeded, s.auditEvents[0].EventType)
+	s.Equal("password", s.auditEvents[0].Details["method"])
+}
+
+func (s *AuthServiceTestSuite) TestLogin_SerializationFailure_RetriesIssuingTokens() {
+	// Given
+	s.unitOfWork.SerializationFailures = 1
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("current_hash")
+	s.mockPasswordHasher.EXPECT().Verify("secret", "current_hash").Return(true, nil)
+	s.expectRegisterSuccess()
+	s.mockPasswordHasher.EXPECT().NeedsRehash("current_hash").Return(false)
+	s.expectMfaStatus(false)
+	s.expectAddRefreshToken()
+	s.expectAddRefreshToken()
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.NotEmpty(response.JwtToken)
+	s.Equal(2, s.unitOfWork.Attempts)
+	s.Equal(1, s.unitOfWork.Commits)
+}
+
+func (s *AuthServiceTestSuite) TestLogin_OutdatedHash_RehashesPassword() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("legacy_hash")
+	s.mockPasswordHasher.EXPECT().Verify("secret", "legacy_hash").Return(true, nil)
+	s.expectRegisterSuccess()
+	s.mockPasswordHasher.EXPECT().NeedsRehash("legacy_hash").Return(true)
+	s.mockPasswordHasher.EXPECT().Hash("secret").Return("new_hash", nil)
+	s.mockAuthDb.
+		EXPECT().
+		UpdatePasswordHash(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.UpdatePasswordHashModel{UserId: 1, PasswordHash: "new_hash", OldPasswordHash: "legacy_hash"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.UpdatePasswordHashResponse, model *authDb.UpdatePasswordHashModel) {
+			ch <- &authDb.UpdatePasswordHashResponse{}
+		})
+	s.expectMfaStatus(false)
+	s.expectAddRefreshToken()
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.NotEmpty(response.JwtToken)
+}
+
+func (s *AuthServiceTestSuite) TestLogin_MfaEnabled_ReturnsChallengeInsteadOfTokens() {
+	// Given
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("current_hash")
+	s.mockPasswordHasher.EXPECT().Verify("secret", "current_hash").Return(true, nil)
+	s.mockPasswordHasher.EXPECT().NeedsRehash("current_hash").Return(false)
+	s.expectMfaStatus(true)
+	s.mockMfa.
+		EXPECT().
+		CreateChallenge(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.CreateChallengeServiceModel{UserId: 1, UserName: "john"})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.CreateChallengeServiceResponse, model *mfa.CreateChallengeServiceModel) {
+			ch <- &mfa.CreateChallengeServiceResponse{ChallengeToken: "challenge", ExpiresIn: 300}
+		})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.MfaRequired)
+	s.Equal("challenge", response.MfaChallengeToken)
+	s.Empty(response.JwtToken)
+	s.Empty(response.RefreshToken)
+}
+
+func (s *AuthServiceTestSuite) TestVerifyMfa_ValidCode_ReturnsTokens() {
+	// Given
+	s.mockMfa.
+		EXPECT().
+		VerifyChallenge(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "123456"})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.VerifyChallengeServiceResponse, model *mfa.VerifyChallengeServiceModel) {
+			ch <- &mfa.VerifyChallengeServiceResponse{UserId: 1, UserName: "john"}
+		})
+	s.expectLockoutCheck(&lockout.CheckLockoutServiceResponse{})
+	s.expectGetUserByUserName("current_hash")
+	s.expectRegisterSuccess()
+	s.expectAddRefreshToken()
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.VerifyMfa(context.Background(), ch, &VerifyMfaServiceModel{ChallengeToken: "challenge", Code: "123456", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.False(response.MfaRequired)
+	s.NotEmpty(response.JwtToken)
+	s.NotEmpty(response.RefreshToken)
+}
+
+func (s *AuthServiceTestSuite) TestVerifyMfa_InvalidCodesOfNewChallenges_LocksOutUser() {
+	// Given
+	failures := 0
+	s.mockLockout.
+		EXPECT().
+		Check(gomock.Any(), gomock.Any(), gomock.Eq(&lockout.CheckLockoutServiceModel{UserName: "john", ClientIp: "10.0.0.1"})).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.CheckLockoutServiceModel) {
+			ch <- &lockout.CheckLockoutServiceResponse{IsLocked: failures >= 5, RetryAfter: time.Minute}
+		}).
+		AnyTimes()
+	s.mockLockout.
+		EXPECT().
+		RegisterFailure(gomock.Any(), gomock.Any(), gomock.Eq(&lockout.RegisterFailureServiceModel{UserName: "john", ClientIp: "10.0.0.1"})).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.CheckLockoutServiceResponse, model *lockout.RegisterFailureServiceModel) {
+			failures++
+			ch <- &lockout.CheckLockoutServiceResponse{IsLocked: failures >= 5, RetryAfter: time.Minute}
+		}).
+		Times(5)
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByUserName(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.GetUserByUserNameModel{UserName: "john"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByUserNameResponse, model *authDb.GetUserByUserNameModel) {
+			ch <- &authDb.GetUserByUserNameResponse{Id: 1, UserName: "john", PasswordHash: "current_hash", IsActive: true}
+		}).
+		Times(5)
+	s.mockPasswordHasher.EXPECT().Verify("secret", "current_hash").Return(true, nil).Times(5)
+	s.mockPasswordHasher.EXPECT().NeedsRehash("current_hash").Return(false).Times(5)
+	s.mockMfa.
+		EXPECT().
+		GetStatus(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.GetStatusServiceModel{UserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.GetStatusServiceResponse, model *mfa.GetStatusServiceModel) {
+			ch <- &mfa.GetStatusServiceResponse{IsEnabled: true}
+		}).
+		Times(5)
+	s.mockMfa.
+		EXPECT().
+		CreateChallenge(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.CreateChallengeServiceResponse, model *mfa.CreateChallengeServiceModel) {
+			ch <- &mfa.CreateChallengeServiceResponse{ChallengeToken: "challenge", ExpiresIn: 300}
+		}).
+		Times(5)
+	s.mockMfa.
+		EXPECT().
+		VerifyChallenge(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "000000"})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.VerifyChallengeServiceResponse, model *mfa.VerifyChallengeServiceModel) {
+			ch <- &mfa.VerifyChallengeServiceResponse{Error: mfa.ErrInvalidCode, UserId: 1, UserName: "john"}
+		}).
+		Times(5)
+
+	// When
+	var verifyErrors []error
+	for i := 0; i < 5; i++ {
+		chLogin := make(chan *LoginServiceResponse)
+		go s.authService.Login(context.Background(), chLogin, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+		loginResponse := <-chLogin
+		close(chLogin)
+		s.Require().NoError(loginResponse.Error)
+		s.Require().True(loginResponse.MfaRequired)
+
+		chVerify := make(chan *LoginServiceResponse)
+		go s.authService.VerifyMfa(context.Background(), chVerify, &VerifyMfaServiceModel{ChallengeToken: loginResponse.MfaChallengeToken, Code: "000000", ClientIp: "10.0.0.1"})
+		verifyErrors = append(verifyErrors, (<-chVerify).Error)
+		close(chVerify)
+	}
+
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.Login(context.Background(), ch, &LoginServiceModel{UserName: "john", Password: "secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	for _, err := range verifyErrors[:4] {
+		s.ErrorIs(err, mfa.ErrInvalidCode)
+	}
+	s.ErrorIs(verifyErrors[4], customerror.ErrLockedOut)
+	s.ErrorIs(response.Error, customerror.ErrLockedOut)
+	s.Empty(response.MfaChallengeToken)
+}
+
+func (s *AuthServiceTestSuite) TestLoginWithOidc_AuthenticatedIdentity_ReturnsTokensOfLinkedUser() {
+	// Given
+	s.mockOidc.
+		EXPECT().
+		Authenticate(gomock.Any(), gomock.Any(), gomock.Eq(&oidc.AuthenticateServiceModel{Code: "code", State: "state"})).
+		DoAndReturn(func(ctx context.Context, ch chan *oidc.AuthenticateServiceResponse, model *oidc.AuthenticateServiceModel) {
+			ch <- &oidc.AuthenticateServiceResponse{UserId: 1, UserName: "john", Email: "john@example.com", IsCreated: true}
+		})
+	s.expectAddRefreshToken()
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.LoginWithOidc(context.Background(), ch, &LoginWithOidcServiceModel{Code: "code", State: "state"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.NotEmpty(response.JwtToken)
+	s.NotEmpty(response.RefreshToken)
+}
+
+func (s *AuthServiceTestSuite) TestRegister_UserNameTaken_ReturnsError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		CheckUserExists(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.CheckUserExistsModel{UserName: "john", Email: "john@example.com"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.CheckUserExistsResponse, model *authDb.CheckUserExistsModel) {
+			ch <- &authDb.CheckUserExistsResponse{UserNameExists: true}
+		})
+
+	// When
+	ch := make(chan *RegisterServiceResponse)
+	defer close(ch)
+	go s.authService.Register(context.Background(), ch, &RegisterServiceModel{UserName: "john", Email: "john@example.com", Password: "secret123"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "username is already taken")
+}
+
+func (s *AuthServiceTestSuite) TestRegister_HappyPath_SendsActivationLink() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.AuthActivationUrl).Return("http://localhost/activate")
+	s.mockAuthDb.
+		EXPECT().
+		CheckUserExists(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.CheckUserExistsResponse, model *authDb.CheckUserExistsModel) {
+			ch <- &authDb.CheckUserExistsResponse{}
+		})
+	s.mockPasswordHasher.EXPECT().Hash("secret123").Return("hash", nil)
+	s.mockAuthDb.
+		EXPECT().
+		AddUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddUserResponse, model *authDb.AddUserModel) {
+			s.Equal("hash", model.PasswordHash)
+			s.NotNil(model.Transaction)
+			ch <- &authDb.AddUserResponse{Id: 7}
+		})
+
+	var tokenHash string
+	s.mockAuthDb.
+		EXPECT().
+		AddActivationToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddActivationTokenResponse, model *authDb.AddActivationTokenModel) {
+			s.NotNil(model.Transaction)
+			tokenHash = model.TokenHash
+			ch <- &authDb.AddActivationTokenResponse{}
+		})
+
+	var body string
+	s.mockNotification.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *notification.SendNotificationResponse, model *notification.SendNotificationModel) {
+			body = model.Body
+			ch <- &notification.SendNotificationResponse{}
+		})
+
+	// When
+	ch := make(chan *RegisterServiceResponse)
+	defer close(ch)
+	go s.authService.Register(context.Background(), ch, &RegisterServiceModel{UserName: "john", Email: "john@example.com", Password: "secret123"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(7), response.UserId)
+	s.Contains(body, "http://localhost/activate?token=")
+	token := body[strings.Index(body, "token=")+len("token="):]
+	s.Equal(hasher.HashToken(token), tokenHash)
+	s.NotContains(body, tokenHash)
+	s.Equal(1, s.unitOfWork.Commits)
+}
+
+func (s *AuthServiceTestSuite) TestRegister_ActivationTokenFails_RollsBackUser() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		CheckUserExists(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.CheckUserExistsResponse, model *authDb.CheckUserExistsModel) {
+			ch <- &authDb.CheckUserExistsResponse{}
+		})
+	s.mockPasswordHasher.EXPECT().Hash("secret123").Return("hash", nil)
+	s.mockAuthDb.
+		EXPECT().
+		AddUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddUserResponse, model *authDb.AddUserModel) {
+			ch <- &authDb.AddUserResponse{Id: 7}
+		})
+	s.mockAuthDb.
+		EXPECT().
+		AddActivationToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddActivationTokenResponse, model *authDb.AddActivationTokenModel) {
+			ch <- &authDb.AddActivationTokenResponse{Error: sql.ErrConnDone}
+		})
+
+	// When
+	ch := make(chan *RegisterServiceResponse)
+	defer close(ch)
+	go s.authService.Register(context.Background(), ch, &RegisterServiceModel{UserName: "john", Email: "john@example.com", Password: "secret123"})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, sql.ErrConnDone)
+	s.Equal(0, s.unitOfWork.Commits)
+	s.Equal(1, s.unitOfWork.Rollbacks)
+}
+
+func (s *AuthServiceTestSuite) TestGetAccessToken_ReusedRefreshToken_ReturnsError() {
+	// Given
+	s.mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockAuthDb.
+		EXPECT().
+		RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.RotateRefreshTokenResponse, model *authDb.RotateRefreshTokenModel) {
+			ch <- &authDb.RotateRefreshTokenResponse{Error: authDb.ErrRefreshTokenReused, Id: 1, FamilyId: "family"}
+		})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.GetAccessToken(context.Background(), ch, &GetAccessTokenServiceModel{RefreshToken: "old_token"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "refresh token is revoked")
+	s.Empty(response.JwtToken)
+}
+
+func (s *AuthServiceTestSuite) TestGetAccessToken_HappyPath_RotatesRefreshToken() {
+	// Given
+	var rotateModel *authDb.RotateRefreshTokenModel
+	s.mockAuthDb.
+		EXPECT().
+		RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.RotateRefreshTokenResponse, model *authDb.RotateRefreshTokenModel) {
+			rotateModel = model
+			ch <- &authDb.RotateRefreshTokenResponse{Id: 1, UserName: "john", IsActive: true, FamilyId: "family"}
+		})
+
+	// When
+	ch := make(chan *LoginServiceResponse)
+	defer close(ch)
+	go s.authService.GetAccessToken(context.Background(), ch, &GetAccessTokenServiceModel{RefreshToken: "old_token"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.NotEmpty(response.JwtToken)
+	s.NotEqual("old_token", response.RefreshToken)
+	s.Equal(hasher.HashToken("old_token"), rotateModel.TokenHash)
+	s.Equal(hasher.HashToken(response.RefreshToken), rotateModel.NewTokenHash)
+}
+
+func (s *AuthServiceTestSuite) expectRateLimit(key string, count int64) {
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-rate-limit:"+key, gomock.Any()).Return(count, nil)
+}
+
+func (s *AuthServiceTestSuite) expectRevokeUser(revokedCount int64) {
+	s.mockRevocation.
+		EXPECT().
+		RevokeUser(gomock.Any(), gomock.Any(), gomock.Eq(&revocation.RevokeUserServiceModel{UserId: 1, RevokedBy: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *revocation.RevokeUserServiceResponse, model *revocation.RevokeUserServiceModel) {
+			ch <- &revocation.RevokeUserServiceResponse{IsRevoked: true, RevokedRefreshCount: revokedCount}
+		})
+}
+
+func (s *AuthServiceTestSuite) TestForgotPassword_UnknownEmail_Succeeds() {
+	// Given
+	s.expectRateLimit("forgot-password:ip:10.0.0.1", 1)
+	s.expectRateLimit("forgot-password:email:john@example.com", 1)
+	looked := make(chan struct{})
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByEmailResponse, model *authDb.GetUserByEmailModel) {
+			ch <- &authDb.GetUserByEmailResponse{Error: sql.ErrNoRows}
+			close(looked)
+		})
+
+	// When
+	ch := make(chan *ForgotPasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ForgotPassword(context.Background(), ch, &ForgotPasswordServiceModel{Email: "John@example.com", ClientIp: "10.0.0.1"})
+	response := <-ch
+	<-looked
+
+	// Then
+	s.NoError(response.Error)
+}
+
+func (s *AuthServiceTestSuite) TestForgotPassword_SendFailed_LogsErrorAndSucceeds() {
+	// Given
+	s.expectRateLimit("forgot-password:ip:10.0.0.1", 1)
+	s.expectRateLimit("forgot-password:email:john@example.com", 1)
+	s.mockEnvironment.EXPECT().Get(env.AuthPasswordResetUrl).Return("http://localhost/reset-password")
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByEmailResponse, model *authDb.GetUserByEmailModel) {
+			ch <- &authDb.GetUserByEmailResponse{Id: 1, UserName: "john", Email: "john@example.com", IsActive: true, HasPassword: true}
+		})
+	s.mockAuthDb.
+		EXPECT().
+		AddPasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddPasswordResetTokenResponse, model *authDb.AddPasswordResetTokenModel) {
+			ch <- &authDb.AddPasswordResetTokenResponse{}
+		})
+	s.mockNotification.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *notification.SendNotificationResponse, model *notification.SendNotificationModel) {
+			ch <- &notification.SendNotificationResponse{Error: errors.New("smtp error")}
+		})
+	logged := make(chan struct{})
+	s.mockLogger.
+		EXPECT().
+		Error("Password reset link could not be sent.", gomock.Any()).
+		Do(func(message string, fields ...zap.Field) { close(logged) })
+
+	// When
+	ch := make(chan *ForgotPasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ForgotPassword(context.Background(), ch, &ForgotPasswordServiceModel{Email: "john@example.com", ClientIp: "10.0.0.1"})
+	response := <-ch
+	<-logged
+
+	// Then
+	s.NoError(response.Error)
+}
+
+func (s *AuthServiceTestSuite) TestForgotPassword_RateLimitExceeded_ReturnsRateLimitedError() {
+	// Given
+	s.expectRateLimit("forgot-password:ip:10.0.0.1", 11)
+	s.mockCacher.EXPECT().TimeToLive(gomock.Any(), "auth-rate-limit:forgot-password:ip:10.0.0.1").Return(time.Minute, nil)
+
+	// When
+	ch := make(chan *ForgotPasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ForgotPassword(context.Background(), ch, &ForgotPasswordServiceModel{Email: "john@example.com", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrRateLimited)
+}
+
+func (s *AuthServiceTestSuite) TestForgotPassword_ActiveUser_SendsResetLinkWithUnhashedToken() {
+	// Given
+	s.expectRateLimit("forgot-password:ip:10.0.0.1", 1)
+	s.expectRateLimit("forgot-password:email:john@example.com", 1)
+	s.mockEnvironment.EXPECT().Get(env.AuthPasswordResetUrl).Return("http://localhost/reset-password")
+	s.mockAuthDb.
+		EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.GetUserByEmailResponse, model *authDb.GetUserByEmailModel) {
+			ch <- &authDb.GetUserByEmailResponse{Id: 1, UserName: "john", Email: "john@example.com", IsActive: true, HasPassword: true}
+		})
+
+	var tokenHash string
+	s.mockAuthDb.
+		EXPECT().
+		AddPasswordResetToken(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.AddPasswordResetTokenResponse, model *authDb.AddPasswordResetTokenModel) {
+			tokenHash = model.TokenHash
+			ch <- &authDb.AddPasswordResetTokenResponse{}
+		})
+
+	var body string
+	sent := make(chan struct{})
+	s.mockNotification.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *notification.SendNotificationResponse, model *notification.SendNotificationModel) {
+			body = model.Body
+			ch <- &notification.SendNotificationResponse{}
+			close(sent)
+		})
+
+	// When
+	ch := make(chan *ForgotPasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ForgotPassword(context.Background(), ch, &ForgotPasswordServiceModel{Email: "john@example.com", ClientIp: "10.0.0.1"})
+	response := <-ch
+	<-sent
+
+	// Then
+	s.NoError(response.Error)
+	token := body[strings.Index(body, "token=")+len("token="):]
+	s.Equal(hasher.HashToken(token), tokenHash)
+	s.NotContains(body, tokenHash)
+}
+
+func (s *AuthServiceTestSuite) TestResetPassword_ValidToken_RevokesRefreshTokens() {
+	// Given
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any())
+	s.expectRateLimit("reset-password:ip:10.0.0.1", 1)
+	s.mockPasswordHasher.EXPECT().Hash("new-secret").Return("new_hash", nil)
+	s.mockAuthDb.
+		EXPECT().
+		ResetPassword(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.ResetPasswordModel{TokenHash: hasher.HashToken("token"), PasswordHash: "new_hash"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.ResetPasswordResponse, model *authDb.ResetPasswordModel) {
+			ch <- &authDb.ResetPasswordResponse{UserId: 1, UserName: "john"}
+		})
+	s.expectRevokeUser(2)
+
+	// When
+	ch := make(chan *PasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ResetPassword(context.Background(), ch, &ResetPasswordServiceModel{ResetToken: "token", NewPassword: "new-secret", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsChanged)
+	s.Equal(int64(2), response.RevokedCount)
+}
+
+func (s *AuthServiceTestSuite) TestChangePassword_WrongCurrentPassword_ReturnsError() {
+	// Given
+	s.expectRateLimit("change-password:user:1", 1)
+	s.expectGetUserByUserName("stored_hash")
+	s.mockPasswordHasher.EXPECT().Verify("wrong", "stored_hash").Return(false, nil)
+
+	// When
+	ch := make(chan *PasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ChangePassword(context.Background(), ch, &ChangePasswordServiceModel{UserId: 1, UserName: "john", CurrentPassword: "wrong", NewPassword: "new-secret"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "current password is wrong")
+	s.False(response.IsChanged)
+}
+
+func (s *AuthServiceTestSuite) TestChangePassword_HappyPath_RevokesRefreshTokens() {
+	// Given
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any())
+	s.expectRateLimit("change-password:user:1", 1)
+	s.expectGetUserByUserName("stored_hash")
+	s.mockPasswordHasher.EXPECT().Verify("secret", "stored_hash").Return(true, nil)
+	s.mockPasswordHasher.EXPECT().Hash("new-secret").Return("new_hash", nil)
+	s.mockAuthDb.
+		EXPECT().
+		UpdatePasswordHash(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.UpdatePasswordHashModel{UserId: 1, PasswordHash: "new_hash", OldPasswordHash: "stored_hash"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.UpdatePasswordHashResponse, model *authDb.UpdatePasswordHashModel) {
+			ch <- &authDb.UpdatePasswordHashResponse{IsUpdated: true}
+		})
+	s.expectRevokeUser(3)
+
+	// When
+	ch := make(chan *PasswordServiceResponse)
+	defer close(ch)
+	go s.authService.ChangePassword(context.Background(), ch, &ChangePasswordServiceModel{UserId: 1, UserName: "john", CurrentPassword: "secret", NewPassword: "new-secret"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsChanged)
+	s.Equal(int64(3), response.RevokedCount)
+}
+
+func (s *AuthServiceTestSuite) signToken(claims util.CustomClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test_secret"))
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *AuthServiceTestSuite) expectIsRevoked(isRevoked bool) {
+	s.mockRevocation.
+		EXPECT().
+		IsRevoked(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *revocation.IsRevokedServiceResponse, model *revocation.IsRevokedServiceModel) {
+			ch <- &revocation.IsRevokedServiceResponse{IsRevoked: isRevoked}
+		})
+}
+
+func (s *AuthServiceTestSuite) validClaims() util.CustomClaims {
+	return util.CustomClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "token-id",
+			Issuer:    "Delivery Hero",
+			Subject:   "1",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		Username:    "john",
+		Email:       "john@example.com",
+		Roles:       []string{"admin"},
+		Permissions: []string{"sample:publish", "api-keys:manage"},
+	}
+}
+
+func (s *AuthServiceTestSuite) TestIntrospect_ActiveToken_ReturnsClaims() {
+	// Given
+	s.mockKeyring.EXPECT().VerificationKey("").Return(&keyring.Key{Method: jwt.SigningMethodHS256, Public: []byte("test_secret")}, nil)
+	s.expectIsRevoked(false)
+	claims := s.validClaims()
+
+	// When
+	ch := make(chan *IntrospectServiceResponse)
+	defer close(ch)
+	go s.authService.Introspect(context.Background(), ch, &IntrospectServiceModel{Token: s.signToken(claims)})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.Active)
+	s.Equal("Bearer", response.TokenType)
+	s.Equal("sample:publish api-keys:manage", response.Scope)
+	s.Equal("1", response.Subject)
+	s.Equal("token-id", response.TokenId)
+	s.Equal(claims.ExpiresAt.Unix(), response.ExpiresAt)
+}
+
+func (s *AuthServiceTestSuite) TestIntrospect_RevokedToken_ReturnsInactive() {
+	// Given
+	s.mockKeyring.EXPECT().VerificationKey("").Return(&keyring.Key{Method: jwt.SigningMethodHS256, Public: []byte("test_secret")}, nil)
+	s.expectIsRevoked(true)
+
+	// When
+	ch := make(chan *IntrospectServiceResponse)
+	defer close(ch)
+	go s.authService.Introspect(context.Background(), ch, &IntrospectServiceModel{Token: s.signToken(s.validClaims())})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(&IntrospectServiceResponse{Active: false}, response)
+}
+
+func (s *AuthServiceTestSuite) TestIntrospect_ExpiredToken_ReturnsInactive() {
+	// Given
+	s.mockKeyring.EXPECT().VerificationKey("").Return(&keyring.Key{Method: jwt.SigningMethodHS256, Public: []byte("test_secret")}, nil)
+	claims := s.validClaims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	// When
+	ch := make(chan *IntrospectServiceResponse)
+	defer close(ch)
+	go s.authService.Introspect(context.Background(), ch, &IntrospectServiceModel{Token: s.signToken(claims)})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.False(response.Active)
+	s.Empty(response.Subject)
+}
+
+func (s *AuthServiceTestSuite) TestRevokeSession_UnknownSession_ReturnsError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RevokeSession(gomock.Any(), gomock.Any(), gomock.Eq(&authDb.RevokeSessionModel{UserId: 1, SessionId: "6f1c1f5e-4d1b-4a36-9c1e-0d2f0c8e5a11"})).
+		DoAndReturn(func(ctx context.Context, ch chan *authDb.RevokeRefreshTokenResponse, model *authDb.RevokeSessionModel) {
+			ch <- &authDb.RevokeRefreshTokenResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *RevokeSessionServiceResponse)
+	defer close(ch)
+	go s.authService.RevokeSession(context.Background(), ch, &RevokeSessionServiceModel{UserId: 1, SessionId: "6f1c1f5e-4d1b-4a36-9c1e-0d2f0c8e5a11"})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "session is not found")
+	s.False(response.IsRevoked)
+}
+
+func (s *AuthServiceTestSuite) TestLogoutAll_HappyPath_RevokesAccessAndRefreshTokens() {
+	// Given
+	s.expectRevokeUser(4)
+
+	// When
+	ch := make(chan *LogoutServiceResponse)
+	defer close(ch)
+	go s.authService.LogoutAll(context.Background(), ch, &LogoutAllServiceModel{UserId: 1, UserName: "john"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(4), response.RevokedCount)
+	s.Equal(audit.EventLogoutAll, s.auditEvents[len(s.auditEvents)-1].EventType)
+}
@@ -1,5 +1,12 @@
 package health
 
+import (
+	"go-clean-architecture/internal/data/database/health"
+	"go-clean-architecture/internal/service/outbox"
+)
+
 type HealthCheckServiceResponse struct {
-	HealthMessage string
+	HealthMessage string                        `json:"HealthMessage"`
+	DbPool        *health.PoolStatsResponse     `json:"DbPool"`
+	Outbox        *outbox.GetLagServiceResponse `json:"Outbox"`
 }
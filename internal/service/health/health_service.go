@@ -1,10 +1,12 @@
 package health
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"go-clean-architecture/internal/data/database/health"
+	"go-clean-architecture/internal/service/outbox"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/logger"
@@ -12,7 +14,7 @@ import (
 )
 
 type IHealthService interface {
-	HealthCheck(ch chan *HealthCheckServiceResponse)
+	HealthCheck(ctx context.Context, ch chan *HealthCheckServiceResponse)
 }
 
 type HealthService struct {
@@ -21,6 +23,7 @@ type HealthService struct {
 	validatr    validator.IValidator
 	cachr       cacher.ICacher
 	healthDb    health.IHealthDb
+	outboxRelay outbox.IOutboxRelay
 }
 
 // NewHealthService returns new HealthService
@@ -30,6 +33,7 @@ func NewHealthService(
 	validatr validator.IValidator,
 	cachr cacher.ICacher,
 	healthDb health.IHealthDb,
+	outboxRelay outbox.IOutboxRelay,
 ) IHealthService {
 
 	service := HealthService{
@@ -43,19 +47,25 @@ func NewHealthService(
 	if healthDb != nil {
 		service.healthDb = healthDb
 	} else {
-		service.healthDb = health.NewHealthDb(environment)
+		service.healthDb = health.NewHealthDb(environment, nil)
+	}
+
+	if outboxRelay != nil {
+		service.outboxRelay = outboxRelay
+	} else {
+		service.outboxRelay = outbox.NewOutboxRelay(environment, loggr, validatr, cachr, nil, nil)
 	}
 
 	return &service
 }
 
 // UpsertSupplier calls the proxy to add or update supplier
-func (s *HealthService) HealthCheck(ch chan *HealthCheckServiceResponse) {
+func (s *HealthService) HealthCheck(ctx context.Context, ch chan *HealthCheckServiceResponse) {
 
 	var healthResponses []string
 
 	hasError := false
-	err := s.cachr.Ping()
+	err := s.cachr.Ping(ctx)
 
 	if err != nil {
 		healthResponses = append(healthResponses, fmt.Sprintf("Redis connection is unhealthy. Error: %s.", err))
@@ -64,7 +74,7 @@ func (s *HealthService) HealthCheck(ch chan *HealthCheckServiceResponse) {
 		healthResponses = append(healthResponses, "Redis connection is healthy.")
 	}
 
-	err = s.healthDb.Ping()
+	err = s.healthDb.Ping(ctx)
 
 	if err != nil {
 		healthResponses = append(healthResponses, fmt.Sprintf("Db connection is unhealthy. Error: %s.", err))
@@ -73,6 +83,18 @@ func (s *HealthService) HealthCheck(ch chan *HealthCheckServiceResponse) {
 		healthResponses = append(healthResponses, "Db connection is healthy.")
 	}
 
+	chGetLagResponse := make(chan *outbox.GetLagServiceResponse)
+	defer close(chGetLagResponse)
+
+	go s.outboxRelay.GetLag(ctx, chGetLagResponse)
+
+	getLagResponse := <-chGetLagResponse
+	if getLagResponse.Error != nil {
+		healthResponses = append(healthResponses, fmt.Sprintf("Outbox lag could not be read. Error: %s.", getLagResponse.Error))
+		hasError = true
+		getLagResponse = nil
+	}
+
 	healthMessage := strings.Join(healthResponses, " ")
 	if hasError {
 		s.loggr.Error(healthMessage)
@@ -80,5 +102,5 @@ func (s *HealthService) HealthCheck(ch chan *HealthCheckServiceResponse) {
 		s.loggr.Info(healthMessage)
 	}
 
-	ch <- &HealthCheckServiceResponse{healthMessage}
+	ch <- &HealthCheckServiceResponse{HealthMessage: healthMessage, DbPool: s.healthDb.Stats(), Outbox: getLagResponse}
 }
@@ -5,6 +5,7 @@
 package health
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,13 +35,13 @@ func (m *MockIHealthService) EXPECT() *MockIHealthServiceMockRecorder {
 }
 
 // HealthCheck mocks base method.
-func (m *MockIHealthService) HealthCheck(ch chan *HealthCheckServiceResponse) {
+func (m *MockIHealthService) HealthCheck(ctx context.Context, ch chan *HealthCheckServiceResponse) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "HealthCheck", ch)
+	m.ctrl.Call(m, "HealthCheck", ctx, ch)
 }
 
 // HealthCheck indicates an expected call of HealthCheck.
-func (mr *MockIHealthServiceMockRecorder) HealthCheck(ch interface{}) *gomock.Call {
+func (mr *MockIHealthServiceMockRecorder) HealthCheck(ctx, ch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockIHealthService)(nil).HealthCheck), ch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockIHealthService)(nil).HealthCheck), ctx, ch)
 }
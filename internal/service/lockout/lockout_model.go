@@ -0,0 +1,28 @@
+package lockout
+
+type CheckLockoutServiceModel struct {
+	UserName string `validate:"required"`
+	ClientIp string `validate:"required"`
+}
+
+type RegisterFailureServiceModel struct {
+	UserName string `validate:"required"`
+	ClientIp string `validate:"required"`
+}
+
+type RegisterSuccessServiceModel struct {
+	UserName string `validate:"required"`
+}
+
+type GetLockoutsServiceModel struct {
+	UserName   string
+	ClientIp   string
+	OnlyActive bool
+	Limit      int `validate:"required,gte=1,lte=500"`
+}
+
+type ClearLockoutServiceModel struct {
+	Scope     string `validate:"required,oneof=user ip"`
+	Key       string `validate:"required"`
+	ClearedBy int64  `validate:"required"`
+}
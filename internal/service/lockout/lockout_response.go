@@ -0,0 +1,23 @@
+package lockout
+
+import (
+	"time"
+
+	"go-clean-architecture/internal/data/database/lockout"
+)
+
+type CheckLockoutServiceResponse struct {
+	Error      error `json:"-"`
+	IsLocked   bool
+	RetryAfter time.Duration
+}
+
+type GetLockoutsServiceResponse struct {
+	Error  error `json:"-"`
+	Events []lockout.LockoutEvent
+}
+
+type ClearLockoutServiceResponse struct {
+	Error        error `json:"-"`
+	ClearedCount int64
+}
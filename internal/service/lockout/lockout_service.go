@@ -0,0 +1,276 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"go-clean-architecture/internal/data/database/lockout"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// Scopes
+const (
+	ScopeUser = "user"
+	ScopeIp   = "ip"
+)
+
+type ILockoutService interface {
+	Check(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *CheckLockoutServiceModel)
+	RegisterFailure(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *RegisterFailureServiceModel)
+	RegisterSuccess(ctx context.Context, ch chan error, model *RegisterSuccessServiceModel)
+	GetLockouts(ctx context.Context, ch chan *GetLockoutsServiceResponse, model *GetLockoutsServiceModel)
+	ClearLockout(ctx context.Context, ch chan *ClearLockoutServiceResponse, model *ClearLockoutServiceModel)
+}
+
+type LockoutService struct {
+	environment    env.IEnvironment
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	cachr          cacher.ICacher
+	lockoutDb      lockout.ILockoutDb
+	cacheKeyPrefix string
+	maxAttempts    map[string]int64
+	attemptWindow  time.Duration
+	baseLockout    time.Duration
+	maxLockout     time.Duration
+	lockoutHistory time.Duration
+}
+
+// NewLockoutService
+// Returns a new LockoutService.
+func NewLockoutService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	lockoutDb lockout.ILockoutDb,
+) ILockoutService {
+	service := LockoutService{
+		environment:    environment,
+		loggr:          loggr,
+		validatr:       validatr,
+		cachr:          cachr,
+		cacheKeyPrefix: "auth-lockout",
+		maxAttempts:    map[string]int64{ScopeUser: 5, ScopeIp: 20},
+		attemptWindow:  time.Minute * 15,
+		baseLockout:    time.Minute,
+		maxLockout:     time.Hour * 24,
+		lockoutHistory: time.Hour * 24,
+	}
+
+	if lockoutDb != nil {
+		service.lockoutDb = lockoutDb
+	} else {
+		service.lockoutDb = lockout.NewLockoutDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	return &service
+}
+
+// Check
+// Checks if username or client ip is currently locked out.
+func (s *LockoutService) Check(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *CheckLockoutServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &CheckLockoutServiceResponse{Error: modelErr}
+		return
+	}
+
+	var response CheckLockoutServiceResponse
+	for scope, key := range map[string]string{ScopeUser: model.UserName, ScopeIp: model.ClientIp} {
+		retryAfter, err := s.cachr.TimeToLive(ctx, s.getCacheKey("locked", scope, key))
+		if err != nil {
+			ch <- &CheckLockoutServiceResponse{Error: err}
+			return
+		}
+
+		if retryAfter > response.RetryAfter {
+			response.IsLocked = true
+			response.RetryAfter = retryAfter
+		}
+	}
+
+	ch <- &response
+}
+
+// RegisterFailure
+// Counts a failed attempt for both username and client ip, locks out the ones exceeding their limit.
+// Each lockout within lockout history doubles the previous lockout duration.
+func (s *LockoutService) RegisterFailure(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *RegisterFailureServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &CheckLockoutServiceResponse{Error: modelErr}
+		return
+	}
+
+	var response CheckLockoutServiceResponse
+	for scope, key := range map[string]string{ScopeUser: model.UserName, ScopeIp: model.ClientIp} {
+		failedAttempts, err := s.cachr.Increment(ctx, s.getCacheKey("failures", scope, key), s.attemptWindow)
+		if err != nil {
+			ch <- &CheckLockoutServiceResponse{Error: err}
+			return
+		}
+
+		if failedAttempts < s.maxAttempts[scope] {
+			continue
+		}
+
+		duration, err := s.lock(ctx, scope, key, failedAttempts, model)
+		if err != nil {
+			ch <- &CheckLockoutServiceResponse{Error: err}
+			return
+		}
+
+		if duration > response.RetryAfter {
+			response.IsLocked = true
+			response.RetryAfter = duration
+		}
+	}
+
+	ch <- &response
+}
+
+// RegisterSuccess
+// Resets failed attempts of the username.
+func (s *LockoutService) RegisterSuccess(ctx context.Context, ch chan error, model *RegisterSuccessServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- modelErr
+		return
+	}
+
+	ch <- s.cachr.Delete(ctx, s.getCacheKey("failures", ScopeUser, model.UserName))
+}
+
+// GetLockouts
+// Gets persisted lockout events for operators.
+func (s *LockoutService) GetLockouts(ctx context.Context, ch chan *GetLockoutsServiceResponse, model *GetLockoutsServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetLockoutsServiceResponse{Error: modelErr}
+		return
+	}
+
+	getLockoutEventsCh := make(chan *lockout.GetLockoutEventsResponse)
+	defer close(getLockoutEventsCh)
+
+	go s.lockoutDb.GetLockoutEvents(ctx, getLockoutEventsCh, &lockout.GetLockoutEventsModel{
+		UserName:   model.UserName,
+		ClientIp:   model.ClientIp,
+		OnlyActive: model.OnlyActive,
+		Limit:      model.Limit,
+	})
+
+	getLockoutEventsResponse := <-getLockoutEventsCh
+	if getLockoutEventsResponse.Error != nil {
+		ch <- &GetLockoutsServiceResponse{Error: getLockoutEventsResponse.Error}
+		return
+	}
+
+	ch <- &GetLockoutsServiceResponse{Events: getLockoutEventsResponse.Events}
+}
+
+// ClearLockout
+// Lifts the lockout of a username or client ip, resets its counters and marks its events as cleared.
+func (s *LockoutService) ClearLockout(ctx context.Context, ch chan *ClearLockoutServiceResponse, model *ClearLockoutServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ClearLockoutServiceResponse{Error: modelErr}
+		return
+	}
+
+	for _, kind := range []string{"locked", "failures", "count"} {
+		err := s.cachr.Delete(ctx, s.getCacheKey(kind, model.Scope, model.Key))
+		if err != nil {
+			ch <- &ClearLockoutServiceResponse{Error: err}
+			return
+		}
+	}
+
+	clearLockoutEventsCh := make(chan *lockout.ClearLockoutEventsResponse)
+	defer close(clearLockoutEventsCh)
+
+	go s.lockoutDb.ClearLockoutEvents(ctx, clearLockoutEventsCh, &lockout.ClearLockoutEventsModel{
+		Scope:     model.Scope,
+		Key:       model.Key,
+		ClearedBy: model.ClearedBy,
+	})
+
+	clearLockoutEventsResponse := <-clearLockoutEventsCh
+	if clearLockoutEventsResponse.Error != nil {
+		ch <- &ClearLockoutServiceResponse{Error: clearLockoutEventsResponse.Error}
+		return
+	}
+
+	s.loggr.Info("Lockout is cleared.",
+		zap.String("scope", model.Scope),
+		zap.String("key", model.Key),
+		zap.Int64("clearedBy", model.ClearedBy),
+	)
+
+	ch <- &ClearLockoutServiceResponse{ClearedCount: clearLockoutEventsResponse.ClearedCount}
+}
+
+// lock
+// Locks out given scope key and persists the lockout event.
+func (s *LockoutService) lock(ctx context.Context, scope string, key string, failedAttempts int64, model *RegisterFailureServiceModel) (time.Duration, error) {
+	lockoutCount, err := s.cachr.Increment(ctx, s.getCacheKey("count", scope, key), s.lockoutHistory)
+	if err != nil {
+		return 0, err
+	}
+
+	duration := s.baseLockout
+	for i := int64(1); i < lockoutCount && duration < s.maxLockout; i++ {
+		duration *= 2
+	}
+	if duration > s.maxLockout {
+		duration = s.maxLockout
+	}
+
+	err = s.cachr.Set(ctx, s.getCacheKey("locked", scope, key), failedAttempts, duration)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.cachr.Delete(ctx, s.getCacheKey("failures", scope, key))
+	if err != nil {
+		return 0, err
+	}
+
+	s.loggr.Warn("Login is locked out due to failed attempts.",
+		zap.String("scope", scope),
+		zap.String("userName", model.UserName),
+		zap.String("clientIp", model.ClientIp),
+		zap.Int64("failedAttempts", failedAttempts),
+		zap.Duration("duration", duration),
+	)
+
+	addLockoutEventCh := make(chan *lockout.AddLockoutEventResponse)
+	defer close(addLockoutEventCh)
+
+	go s.lockoutDb.AddLockoutEvent(ctx, addLockoutEventCh, &lockout.AddLockoutEventModel{
+		Scope:          scope,
+		UserName:       model.UserName,
+		ClientIp:       model.ClientIp,
+		FailedAttempts: failedAttempts,
+		LockedUntil:    time.Now().Add(duration),
+	})
+
+	// Lockout is already in effect, persisting is only for operators.
+	addLockoutEventResponse := <-addLockoutEventCh
+	if addLockoutEventResponse.Error != nil {
+		s.loggr.Error("Lockout event could not be persisted.", zap.Error(addLockoutEventResponse.Error))
+	}
+
+	return duration, nil
+}
+
+// Returns a cache key for a specific kind, scope and key.
+func (s *LockoutService) getCacheKey(kind string, scope string, key string) string {
+	return s.cacheKeyPrefix + ":" + kind + ":" + scope + ":" + key
+}
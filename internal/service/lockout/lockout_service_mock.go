@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/lockout/lockout_service.go
+
+// Package lockout is a generated GoMock package.
+package lockout
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILockoutService is a mock of ILockoutService interface.
+type MockILockoutService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILockoutServiceMockRecorder
+}
+
+// MockILockoutServiceMockRecorder is the mock recorder for MockILockoutService.
+type MockILockoutServiceMockRecorder struct {
+	mock *MockILockoutService
+}
+
+// NewMockILockoutService creates a new mock instance.
+func NewMockILockoutService(ctrl *gomock.Controller) *MockILockoutService {
+	mock := &MockILockoutService{ctrl: ctrl}
+	mock.recorder = &MockILockoutServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILockoutService) EXPECT() *MockILockoutServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockILockoutService) Check(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *CheckLockoutServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Check", ctx, ch, model)
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockILockoutServiceMockRecorder) Check(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockILockoutService)(nil).Check), ctx, ch, model)
+}
+
+// ClearLockout mocks base method.
+func (m *MockILockoutService) ClearLockout(ctx context.Context, ch chan *ClearLockoutServiceResponse, model *ClearLockoutServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ClearLockout", ctx, ch, model)
+}
+
+// ClearLockout indicates an expected call of ClearLockout.
+func (mr *MockILockoutServiceMockRecorder) ClearLockout(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLockout", reflect.TypeOf((*MockILockoutService)(nil).ClearLockout), ctx, ch, model)
+}
+
+// GetLockouts mocks base method.
+func (m *MockILockoutService) GetLockouts(ctx context.Context, ch chan *GetLockoutsServiceResponse, model *GetLockoutsServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLockouts", ctx, ch, model)
+}
+
+// GetLockouts indicates an expected call of GetLockouts.
+func (mr *MockILockoutServiceMockRecorder) GetLockouts(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockouts", reflect.TypeOf((*MockILockoutService)(nil).GetLockouts), ctx, ch, model)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockILockoutService) RegisterFailure(ctx context.Context, ch chan *CheckLockoutServiceResponse, model *RegisterFailureServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterFailure", ctx, ch, model)
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockILockoutServiceMockRecorder) RegisterFailure(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockILockoutService)(nil).RegisterFailure), ctx, ch, model)
+}
+
+// RegisterSuccess mocks base method.
+func (m *MockILockoutService) RegisterSuccess(ctx context.Context, ch chan error, model *RegisterSuccessServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterSuccess", ctx, ch, model)
+}
+
+// RegisterSuccess indicates an expected call of RegisterSuccess.
+func (mr *MockILockoutServiceMockRecorder) RegisterSuccess(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSuccess", reflect.TypeOf((*MockILockoutService)(nil).RegisterSuccess), ctx, ch, model)
+}
@@ -0,0 +1,136 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/lockout"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LockoutServiceTestSuite struct {
+	suite.Suite
+	lockoutService  ILockoutService
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockLockoutDb   *lockout.MockILockoutDb
+}
+
+// Run suite.
+func TestLockoutService(t *testing.T) {
+	suite.Run(t, new(LockoutServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *LockoutServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockLockoutDb = lockout.NewMockILockoutDb(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.lockoutService = NewLockoutService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockLockoutDb)
+}
+
+func (s *LockoutServiceTestSuite) registerFailure() *CheckLockoutServiceResponse {
+	ch := make(chan *CheckLockoutServiceResponse)
+	defer close(ch)
+	go s.lockoutService.RegisterFailure(context.Background(), ch, &RegisterFailureServiceModel{UserName: "john", ClientIp: "10.0.0.1"})
+	return <-ch
+}
+
+func (s *LockoutServiceTestSuite) TestCheck_LockedUser_ReturnsRetryAfter() {
+	// Given
+	s.mockCacher.EXPECT().TimeToLive(gomock.Any(), "auth-lockout:locked:user:john").Return(time.Minute*2, nil)
+	s.mockCacher.EXPECT().TimeToLive(gomock.Any(), "auth-lockout:locked:ip:10.0.0.1").Return(time.Duration(0), nil)
+
+	// When
+	ch := make(chan *CheckLockoutServiceResponse)
+	defer close(ch)
+	go s.lockoutService.Check(context.Background(), ch, &CheckLockoutServiceModel{UserName: "john", ClientIp: "10.0.0.1"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsLocked)
+	s.Equal(time.Minute*2, response.RetryAfter)
+}
+
+func (s *LockoutServiceTestSuite) TestRegisterFailure_BelowLimit_DoesNotLock() {
+	// Given
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:user:john", time.Minute*15).Return(int64(4), nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:ip:10.0.0.1", time.Minute*15).Return(int64(4), nil)
+
+	// When
+	response := s.registerFailure()
+
+	// Then
+	s.NoError(response.Error)
+	s.False(response.IsLocked)
+}
+
+func (s *LockoutServiceTestSuite) TestRegisterFailure_RepeatedLockout_DoublesDuration() {
+	// Given
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:user:john", time.Minute*15).Return(int64(5), nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:ip:10.0.0.1", time.Minute*15).Return(int64(5), nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:count:user:john", time.Hour*24).Return(int64(3), nil)
+	s.mockCacher.EXPECT().Set(gomock.Any(), "auth-lockout:locked:user:john", int64(5), time.Minute*4)
+	s.mockCacher.EXPECT().Delete(gomock.Any(), "auth-lockout:failures:user:john").Return(nil)
+
+	var event *lockout.AddLockoutEventModel
+	s.mockLockoutDb.
+		EXPECT().
+		AddLockoutEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.AddLockoutEventResponse, model *lockout.AddLockoutEventModel) {
+			event = model
+			ch <- &lockout.AddLockoutEventResponse{}
+		})
+
+	// When
+	response := s.registerFailure()
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsLocked)
+	s.Equal(time.Minute*4, response.RetryAfter)
+	s.Equal("user", event.Scope)
+	s.Equal(int64(5), event.FailedAttempts)
+}
+
+func (s *LockoutServiceTestSuite) TestRegisterFailure_ManyLockouts_CapsDuration() {
+	// Given
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:user:john", gomock.Any()).Return(int64(1), nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:failures:ip:10.0.0.1", gomock.Any()).Return(int64(20), nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-lockout:count:ip:10.0.0.1", gomock.Any()).Return(int64(64), nil)
+	s.mockCacher.EXPECT().Set(gomock.Any(), "auth-lockout:locked:ip:10.0.0.1", int64(20), time.Hour*24)
+	s.mockCacher.EXPECT().Delete(gomock.Any(), "auth-lockout:failures:ip:10.0.0.1").Return(nil)
+	s.mockLockoutDb.
+		EXPECT().
+		AddLockoutEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *lockout.AddLockoutEventResponse, model *lockout.AddLockoutEventModel) {
+			ch <- &lockout.AddLockoutEventResponse{}
+		})
+
+	// When
+	response := s.registerFailure()
+
+	// Then
+	s.True(response.IsLocked)
+	s.Equal(time.Hour*24, response.RetryAfter)
+}
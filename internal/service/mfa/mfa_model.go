@@ -0,0 +1,33 @@
+package mfa
+
+type EnrollServiceModel struct {
+	UserId   int64  `validate:"required"`
+	UserName string `validate:"required"`
+}
+
+type ConfirmServiceModel struct {
+	UserId int64  `validate:"required"`
+	Code   string `validate:"required,numeric"`
+}
+
+type GetStatusServiceModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type CreateChallengeServiceModel struct {
+	UserId   int64  `validate:"required"`
+	UserName string `validate:"required"`
+}
+
+type VerifyChallengeServiceModel struct {
+	ChallengeToken string `validate:"required"`
+	Code           string `validate:"required_without=RecoveryCode,omitempty,numeric"`
+	RecoveryCode   string `validate:"required_without=Code"`
+}
+
+// challenge
+// Cached state of a pending MFA challenge.
+type challenge struct {
+	UserId   int64
+	UserName string
+}
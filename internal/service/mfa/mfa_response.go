@@ -0,0 +1,30 @@
+package mfa
+
+type EnrollServiceResponse struct {
+	Error         error `json:"-"`
+	Secret        string
+	Uri           string
+	RecoveryCodes []string
+}
+
+type ConfirmServiceResponse struct {
+	Error     error `json:"-"`
+	IsEnabled bool
+}
+
+type GetStatusServiceResponse struct {
+	Error     error `json:"-"`
+	IsEnabled bool
+}
+
+type CreateChallengeServiceResponse struct {
+	Error          error `json:"-"`
+	ChallengeToken string
+	ExpiresIn      int64
+}
+
+type VerifyChallengeServiceResponse struct {
+	Error    error `json:"-"`
+	UserId   int64
+	UserName string
+}
@@ -0,0 +1,431 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-clean-architecture/internal/data/database/mfa"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/totp"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IMfaService interface {
+	Enroll(ctx context.Context, ch chan *EnrollServiceResponse, model *EnrollServiceModel)
+	Confirm(ctx context.Context, ch chan *ConfirmServiceResponse, model *ConfirmServiceModel)
+	GetStatus(ctx context.Context, ch chan *GetStatusServiceResponse, model *GetStatusServiceModel)
+	CreateChallenge(ctx context.Context, ch chan *CreateChallengeServiceResponse, model *CreateChallengeServiceModel)
+	VerifyChallenge(ctx context.Context, ch chan *VerifyChallengeServiceResponse, model *VerifyChallengeServiceModel)
+}
+
+type MfaService struct {
+	environment            env.IEnvironment
+	loggr                  logger.ILogger
+	validatr               validator.IValidator
+	cachr                  cacher.ICacher
+	mfaDb                  mfa.IMfaDb
+	totp                   totp.ITotp
+	cacheKeyPrefix         string
+	challengeDuration      time.Duration
+	challengeMaxAttempts   int64
+	recoveryCodeCount      int
+	recoveryCodeByteLength int
+}
+
+var (
+	ErrInvalidCode      = customerror.New(errors.New("mfa code is invalid"), customerror.LogLevelInfo)
+	errInvalidChallenge = customerror.New(errors.New("mfa challenge is invalid or expired"), customerror.LogLevelInfo)
+)
+
+// NewMfaService
+// Returns a new MfaService.
+func NewMfaService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	mfaDb mfa.IMfaDb,
+	otp totp.ITotp,
+) IMfaService {
+	service := MfaService{
+		environment:            environment,
+		loggr:                  loggr,
+		validatr:               validatr,
+		cachr:                  cachr,
+		cacheKeyPrefix:         "auth-mfa-challenge",
+		challengeDuration:      time.Minute * 5,
+		challengeMaxAttempts:   5,
+		recoveryCodeCount:      10,
+		recoveryCodeByteLength: 5,
+	}
+
+	if mfaDb != nil {
+		service.mfaDb = mfaDb
+	} else {
+		service.mfaDb = mfa.NewMfaDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if otp != nil {
+		service.totp = otp
+	} else {
+		service.totp = totp.New()
+	}
+
+	return &service
+}
+
+// Enroll
+// Generates a new secret and recovery codes for the user. MFA is enabled once a code is confirmed.
+// Returns an error if MFA of the user is already enabled.
+func (s *MfaService) Enroll(ctx context.Context, ch chan *EnrollServiceResponse, model *EnrollServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &EnrollServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		ch <- &EnrollServiceResponse{Error: err}
+		return
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		ch <- &EnrollServiceResponse{Error: err}
+		return
+	}
+
+	chAddMfaEnrollmentResponse := make(chan *mfa.AddMfaEnrollmentResponse)
+	defer close(chAddMfaEnrollmentResponse)
+
+	go s.mfaDb.AddMfaEnrollment(
+		ctx, chAddMfaEnrollmentResponse, &mfa.AddMfaEnrollmentModel{
+			UserId:             model.UserId,
+			Secret:             secret,
+			RecoveryCodeHashes: recoveryCodeHashes,
+		},
+	)
+
+	addMfaEnrollmentResponse := <-chAddMfaEnrollmentResponse
+	if errors.Is(addMfaEnrollmentResponse.Error, mfa.ErrMfaAlreadyEnabled) {
+		ch <- &EnrollServiceResponse{Error: customerror.New(addMfaEnrollmentResponse.Error, customerror.LogLevelInfo)}
+		return
+	}
+	if addMfaEnrollmentResponse.Error != nil {
+		ch <- &EnrollServiceResponse{Error: addMfaEnrollmentResponse.Error}
+		return
+	}
+
+	ch <- &EnrollServiceResponse{
+		Secret:        secret,
+		Uri:           s.totp.Uri(s.environment.Get(env.AppName), model.UserName, secret),
+		RecoveryCodes: recoveryCodes,
+	}
+}
+
+// Confirm
+// Enables pending MFA of the user if given code is valid for the enrolled secret.
+func (s *MfaService) Confirm(ctx context.Context, ch chan *ConfirmServiceResponse, model *ConfirmServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &ConfirmServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	userMfa, err := s.getMfa(ctx, model.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		ch <- &ConfirmServiceResponse{Error: customerror.New(errors.New("mfa enrollment is not found"), customerror.LogLevelInfo)}
+		return
+	}
+	if err != nil {
+		ch <- &ConfirmServiceResponse{Error: err}
+		return
+	}
+
+	if userMfa.IsEnabled {
+		ch <- &ConfirmServiceResponse{Error: customerror.New(mfa.ErrMfaAlreadyEnabled, customerror.LogLevelInfo)}
+		return
+	}
+
+	step, isValid, err := s.totp.Validate(userMfa.Secret, model.Code, time.Now())
+	if err != nil {
+		ch <- &ConfirmServiceResponse{Error: err}
+		return
+	}
+
+	if !isValid {
+		ch <- &ConfirmServiceResponse{Error: ErrInvalidCode}
+		return
+	}
+
+	chEnableMfaResponse := make(chan *mfa.EnableMfaResponse)
+	defer close(chEnableMfaResponse)
+
+	go s.mfaDb.EnableMfa(
+		ctx, chEnableMfaResponse, &mfa.EnableMfaModel{
+			UserId: model.UserId,
+			Step:   step,
+		},
+	)
+
+	enableMfaResponse := <-chEnableMfaResponse
+	if errors.Is(enableMfaResponse.Error, sql.ErrNoRows) {
+		ch <- &ConfirmServiceResponse{Error: customerror.New(mfa.ErrMfaAlreadyEnabled, customerror.LogLevelInfo)}
+		return
+	}
+	if enableMfaResponse.Error != nil {
+		ch <- &ConfirmServiceResponse{Error: enableMfaResponse.Error}
+		return
+	}
+
+	s.loggr.Info("MFA is enabled.", zap.Int64("userId", model.UserId))
+
+	ch <- &ConfirmServiceResponse{IsEnabled: true}
+}
+
+// GetStatus
+// Returns whether MFA of the user is enabled.
+func (s *MfaService) GetStatus(ctx context.Context, ch chan *GetStatusServiceResponse, model *GetStatusServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetStatusServiceResponse{Error: modelErr}
+		return
+	}
+
+	userMfa, err := s.getMfa(ctx, model.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		ch <- &GetStatusServiceResponse{}
+		return
+	}
+	if err != nil {
+		ch <- &GetStatusServiceResponse{Error: err}
+		return
+	}
+
+	ch <- &GetStatusServiceResponse{IsEnabled: userMfa.IsEnabled}
+}
+
+// CreateChallenge
+// Creates a short-lived single use challenge token which is exchanged for tokens with a valid code.
+func (s *MfaService) CreateChallenge(ctx context.Context, ch chan *CreateChallengeServiceResponse, model *CreateChallengeServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &CreateChallengeServiceResponse{Error: modelErr}
+		return
+	}
+
+	challengeToken := uuid.NewString()
+	err := s.cachr.Set(ctx, s.getCacheKey("token", challengeToken), &challenge{UserId: model.UserId, UserName: model.UserName}, s.challengeDuration)
+	if err != nil {
+		ch <- &CreateChallengeServiceResponse{Error: err}
+		return
+	}
+
+	ch <- &CreateChallengeServiceResponse{
+		ChallengeToken: challengeToken,
+		ExpiresIn:      int64(s.challengeDuration.Seconds()),
+	}
+}
+
+// VerifyChallenge
+// Verifies a TOTP or recovery code against the user of the challenge and consumes the challenge.
+// Challenge is dropped after too many invalid codes.
+func (s *MfaService) VerifyChallenge(ctx context.Context, ch chan *VerifyChallengeServiceResponse, model *VerifyChallengeServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &VerifyChallengeServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	tokenKey := s.getCacheKey("token", model.ChallengeToken)
+	cached, err := s.cachr.Get(ctx, tokenKey)
+	if err != nil {
+		ch <- &VerifyChallengeServiceResponse{Error: err}
+		return
+	}
+
+	if cached == nil {
+		ch <- &VerifyChallengeServiceResponse{Error: errInvalidChallenge}
+		return
+	}
+
+	var pending challenge
+	err = json.Unmarshal([]byte(*cached), &pending)
+	if err != nil {
+		ch <- &VerifyChallengeServiceResponse{Error: err}
+		return
+	}
+
+	attempts, err := s.cachr.Increment(ctx, s.getCacheKey("attempts", model.ChallengeToken), s.challengeDuration)
+	if err != nil {
+		ch <- &VerifyChallengeServiceResponse{Error: err}
+		return
+	}
+
+	if attempts > s.challengeMaxAttempts {
+		s.dropChallenge(ctx, model.ChallengeToken)
+		s.loggr.Warn("MFA challenge is dropped due to failed attempts.", zap.Int64("userId", pending.UserId))
+		ch <- &VerifyChallengeServiceResponse{Error: customerror.New(customerror.ErrLockedOut, customerror.LogLevelWarn)}
+		return
+	}
+
+	var isVerified bool
+	if model.Code != "" {
+		isVerified, err = s.verifyCode(ctx, pending.UserId, model.Code)
+	} else {
+		isVerified, err = s.verifyRecoveryCode(ctx, pending.UserId, model.RecoveryCode)
+	}
+	if err != nil {
+		ch <- &VerifyChallengeServiceResponse{Error: err}
+		return
+	}
+
+	// User is returned with an invalid code, so the caller can count it as a failed login of the user.
+	if !isVerified {
+		ch <- &VerifyChallengeServiceResponse{
+			Error:    ErrInvalidCode,
+			UserId:   pending.UserId,
+			UserName: pending.UserName,
+		}
+		return
+	}
+
+	s.dropChallenge(ctx, model.ChallengeToken)
+
+	ch <- &VerifyChallengeServiceResponse{
+		UserId:   pending.UserId,
+		UserName: pending.UserName,
+	}
+}
+
+// verifyCode
+// Validates a TOTP code and marks its time step as used, so the same code cannot be replayed.
+func (s *MfaService) verifyCode(ctx context.Context, userId int64, code string) (bool, error) {
+	userMfa, err := s.getMfa(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+
+	if !userMfa.IsEnabled {
+		return false, nil
+	}
+
+	step, isValid, err := s.totp.Validate(userMfa.Secret, code, time.Now())
+	if err != nil || !isValid {
+		return false, err
+	}
+
+	chUseTimeStepResponse := make(chan *mfa.UseTimeStepResponse)
+	defer close(chUseTimeStepResponse)
+
+	go s.mfaDb.UseTimeStep(
+		ctx, chUseTimeStepResponse, &mfa.UseTimeStepModel{
+			UserId: userId,
+			Step:   step,
+		},
+	)
+
+	useTimeStepResponse := <-chUseTimeStepResponse
+	if useTimeStepResponse.Error != nil {
+		return false, useTimeStepResponse.Error
+	}
+
+	return useTimeStepResponse.IsUsed, nil
+}
+
+// verifyRecoveryCode
+// Consumes an unused recovery code of the user. Codes are generated in lower case, so typed codes are normalized.
+func (s *MfaService) verifyRecoveryCode(ctx context.Context, userId int64, recoveryCode string) (bool, error) {
+	recoveryCode = strings.ToLower(strings.TrimSpace(recoveryCode))
+
+	chUseRecoveryCodeResponse := make(chan *mfa.UseRecoveryCodeResponse)
+	defer close(chUseRecoveryCodeResponse)
+
+	go s.mfaDb.UseRecoveryCode(
+		ctx, chUseRecoveryCodeResponse, &mfa.UseRecoveryCodeModel{
+			UserId:   userId,
+			CodeHash: hasher.HashToken(recoveryCode),
+		},
+	)
+
+	useRecoveryCodeResponse := <-chUseRecoveryCodeResponse
+	if useRecoveryCodeResponse.Error != nil {
+		return false, useRecoveryCodeResponse.Error
+	}
+
+	if useRecoveryCodeResponse.IsUsed {
+		s.loggr.Info("MFA recovery code is used.", zap.Int64("userId", userId))
+	}
+
+	return useRecoveryCodeResponse.IsUsed, nil
+}
+
+// getMfa
+// Gets MFA secret and status of the user.
+func (s *MfaService) getMfa(ctx context.Context, userId int64) (*mfa.GetMfaResponse, error) {
+	chGetMfaResponse := make(chan *mfa.GetMfaResponse)
+	defer close(chGetMfaResponse)
+
+	go s.mfaDb.GetMfa(ctx, chGetMfaResponse, &mfa.GetMfaModel{UserId: userId})
+
+	getMfaResponse := <-chGetMfaResponse
+	if getMfaResponse.Error != nil {
+		return nil, getMfaResponse.Error
+	}
+
+	return getMfaResponse, nil
+}
+
+// dropChallenge
+// Deletes challenge token and its attempt counter. Failures are only logged since the challenge expires anyway.
+func (s *MfaService) dropChallenge(ctx context.Context, challengeToken string) {
+	for _, kind := range []string{"token", "attempts"} {
+		err := s.cachr.Delete(ctx, s.getCacheKey(kind, challengeToken))
+		if err != nil {
+			s.loggr.Error("MFA challenge could not be deleted.", zap.Error(err))
+		}
+	}
+}
+
+// generateRecoveryCodes
+// Returns random recovery codes in xxxx-xxxx format and their hashes to store.
+func (s *MfaService) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, s.recoveryCodeCount)
+	hashes := make([]string, 0, s.recoveryCodeCount)
+
+	for i := 0; i < s.recoveryCodeCount; i++ {
+		bytes := make([]byte, s.recoveryCodeByteLength)
+		_, err := rand.Read(bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(bytes))
+		code := fmt.Sprintf("%s-%s", encoded[:len(encoded)/2], encoded[len(encoded)/2:])
+
+		codes = append(codes, code)
+		hashes = append(hashes, hasher.HashToken(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// Returns a cache key for a specific kind and challenge token.
+func (s *MfaService) getCacheKey(kind string, challengeToken string) string {
+	return s.cacheKeyPrefix + ":" + kind + ":" + challengeToken
+}
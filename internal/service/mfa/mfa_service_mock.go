@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/mfa/mfa_service.go
+
+// Package mfa is a generated GoMock package.
+package mfa
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMfaService is a mock of IMfaService interface.
+type MockIMfaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMfaServiceMockRecorder
+}
+
+// MockIMfaServiceMockRecorder is the mock recorder for MockIMfaService.
+type MockIMfaServiceMockRecorder struct {
+	mock *MockIMfaService
+}
+
+// NewMockIMfaService creates a new mock instance.
+func NewMockIMfaService(ctrl *gomock.Controller) *MockIMfaService {
+	mock := &MockIMfaService{ctrl: ctrl}
+	mock.recorder = &MockIMfaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMfaService) EXPECT() *MockIMfaServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockIMfaService) Confirm(ctx context.Context, ch chan *ConfirmServiceResponse, model *ConfirmServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Confirm", ctx, ch, model)
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockIMfaServiceMockRecorder) Confirm(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockIMfaService)(nil).Confirm), ctx, ch, model)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockIMfaService) CreateChallenge(ctx context.Context, ch chan *CreateChallengeServiceResponse, model *CreateChallengeServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateChallenge", ctx, ch, model)
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockIMfaServiceMockRecorder) CreateChallenge(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockIMfaService)(nil).CreateChallenge), ctx, ch, model)
+}
+
+// Enroll mocks base method.
+func (m *MockIMfaService) Enroll(ctx context.Context, ch chan *EnrollServiceResponse, model *EnrollServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enroll", ctx, ch, model)
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockIMfaServiceMockRecorder) Enroll(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockIMfaService)(nil).Enroll), ctx, ch, model)
+}
+
+// GetStatus mocks base method.
+func (m *MockIMfaService) GetStatus(ctx context.Context, ch chan *GetStatusServiceResponse, model *GetStatusServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetStatus", ctx, ch, model)
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockIMfaServiceMockRecorder) GetStatus(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockIMfaService)(nil).GetStatus), ctx, ch, model)
+}
+
+// VerifyChallenge mocks base method.
+func (m *MockIMfaService) VerifyChallenge(ctx context.Context, ch chan *VerifyChallengeServiceResponse, model *VerifyChallengeServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "VerifyChallenge", ctx, ch, model)
+}
+
+// VerifyChallenge indicates an expected call of VerifyChallenge.
+func (mr *MockIMfaServiceMockRecorder) VerifyChallenge(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChallenge", reflect.TypeOf((*MockIMfaService)(nil).VerifyChallenge), ctx, ch, model)
+}
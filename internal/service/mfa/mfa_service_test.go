@@ -0,0 +1,193 @@
+package mfa
+
+import (
+	"context"
+	"testing"
+
+	"go-clean-architecture/internal/data/database/mfa"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/totp"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MfaServiceTestSuite struct {
+	suite.Suite
+	mfaService      IMfaService
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockMfaDb       *mfa.MockIMfaDb
+	mockTotp        *totp.MockITotp
+}
+
+// Run suite.
+func TestMfaService(t *testing.T) {
+	suite.Run(t, new(MfaServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *MfaServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockMfaDb = mfa.NewMockIMfaDb(ctrl)
+	s.mockTotp = totp.NewMockITotp(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.mfaService = NewMfaService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockMfaDb, s.mockTotp)
+}
+
+func (s *MfaServiceTestSuite) expectChallenge(attempts int64) {
+	challenge := `{"UserId":1,"UserName":"john"}`
+	s.mockCacher.EXPECT().Get(gomock.Any(), "auth-mfa-challenge:token:challenge").Return(&challenge, nil)
+	s.mockCacher.EXPECT().Increment(gomock.Any(), "auth-mfa-challenge:attempts:challenge", gomock.Any()).Return(attempts, nil)
+}
+
+func (s *MfaServiceTestSuite) expectDropChallenge() {
+	s.mockCacher.EXPECT().Delete(gomock.Any(), "auth-mfa-challenge:token:challenge").Return(nil)
+	s.mockCacher.EXPECT().Delete(gomock.Any(), "auth-mfa-challenge:attempts:challenge").Return(nil)
+}
+
+func (s *MfaServiceTestSuite) expectEnabledMfa() {
+	s.mockMfaDb.
+		EXPECT().
+		GetMfa(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.GetMfaModel{UserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.GetMfaResponse, model *mfa.GetMfaModel) {
+			ch <- &mfa.GetMfaResponse{Secret: "SECRET", IsEnabled: true}
+		})
+	s.mockTotp.EXPECT().Validate("SECRET", "123456", gomock.Any()).Return(int64(100), true, nil)
+}
+
+func (s *MfaServiceTestSuite) verifyChallenge(model *VerifyChallengeServiceModel) *VerifyChallengeServiceResponse {
+	ch := make(chan *VerifyChallengeServiceResponse)
+	defer close(ch)
+	go s.mfaService.VerifyChallenge(context.Background(), ch, model)
+	return <-ch
+}
+
+func (s *MfaServiceTestSuite) TestVerifyChallenge_ValidCode_ReturnsUserAndDropsChallenge() {
+	// Given
+	s.expectChallenge(1)
+	s.expectEnabledMfa()
+	s.mockMfaDb.
+		EXPECT().
+		UseTimeStep(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.UseTimeStepModel{UserId: 1, Step: 100})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.UseTimeStepResponse, model *mfa.UseTimeStepModel) {
+			ch <- &mfa.UseTimeStepResponse{IsUsed: true}
+		})
+	s.expectDropChallenge()
+
+	// When
+	response := s.verifyChallenge(&VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "123456"})
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(1), response.UserId)
+	s.Equal("john", response.UserName)
+}
+
+func (s *MfaServiceTestSuite) TestVerifyChallenge_ReplayedCode_ReturnsError() {
+	// Given
+	s.expectChallenge(1)
+	s.expectEnabledMfa()
+	s.mockMfaDb.
+		EXPECT().
+		UseTimeStep(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.UseTimeStepResponse, model *mfa.UseTimeStepModel) {
+			ch <- &mfa.UseTimeStepResponse{IsUsed: false}
+		})
+
+	// When
+	response := s.verifyChallenge(&VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "123456"})
+
+	// Then
+	s.EqualError(response.Error, "mfa code is invalid")
+	s.Equal("john", response.UserName)
+}
+
+func (s *MfaServiceTestSuite) TestVerifyChallenge_RecoveryCode_IsMatchedByHash() {
+	// Given
+	s.expectChallenge(1)
+	s.mockMfaDb.
+		EXPECT().
+		UseRecoveryCode(gomock.Any(), gomock.Any(), gomock.Eq(&mfa.UseRecoveryCodeModel{UserId: 1, CodeHash: hasher.HashToken("abcd-efgh")})).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.UseRecoveryCodeResponse, model *mfa.UseRecoveryCodeModel) {
+			ch <- &mfa.UseRecoveryCodeResponse{IsUsed: true}
+		})
+	s.expectDropChallenge()
+
+	// When
+	response := s.verifyChallenge(&VerifyChallengeServiceModel{ChallengeToken: "challenge", RecoveryCode: " ABCD-EFGH "})
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(1), response.UserId)
+}
+
+func (s *MfaServiceTestSuite) TestVerifyChallenge_TooManyAttempts_DropsChallenge() {
+	// Given
+	s.expectChallenge(6)
+	s.expectDropChallenge()
+
+	// When
+	response := s.verifyChallenge(&VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "123456"})
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrLockedOut)
+}
+
+func (s *MfaServiceTestSuite) TestVerifyChallenge_UnknownChallenge_ReturnsError() {
+	// Given
+	s.mockCacher.EXPECT().Get(gomock.Any(), "auth-mfa-challenge:token:challenge").Return(nil, nil)
+
+	// When
+	response := s.verifyChallenge(&VerifyChallengeServiceModel{ChallengeToken: "challenge", Code: "123456"})
+
+	// Then
+	s.EqualError(response.Error, "mfa challenge is invalid or expired")
+}
+
+func (s *MfaServiceTestSuite) TestEnroll_HappyPath_ReturnsUriAndRecoveryCodes() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.AppName).Return("Go Clean Architecture")
+	s.mockTotp.EXPECT().GenerateSecret().Return("SECRET", nil)
+	s.mockTotp.EXPECT().Uri("Go Clean Architecture", "john", "SECRET").Return("otpauth://totp/uri")
+
+	var hashes []string
+	s.mockMfaDb.
+		EXPECT().
+		AddMfaEnrollment(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *mfa.AddMfaEnrollmentResponse, model *mfa.AddMfaEnrollmentModel) {
+			hashes = model.RecoveryCodeHashes
+			ch <- &mfa.AddMfaEnrollmentResponse{}
+		})
+
+	// When
+	ch := make(chan *EnrollServiceResponse)
+	defer close(ch)
+	go s.mfaService.Enroll(context.Background(), ch, &EnrollServiceModel{UserId: 1, UserName: "john"})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal("SECRET", response.Secret)
+	s.Equal("otpauth://totp/uri", response.Uri)
+	s.Len(response.RecoveryCodes, 10)
+	s.Regexp(`^[a-z2-7]{4}-[a-z2-7]{4}$`, response.RecoveryCodes[0])
+	s.Equal(hasher.HashToken(response.RecoveryCodes[0]), hashes[0])
+}
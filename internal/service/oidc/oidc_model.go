@@ -0,0 +1,9 @@
+package oidc
+
+type GetLoginUrlServiceModel struct {
+}
+
+type AuthenticateServiceModel struct {
+	Code  string `validate:"required"`
+	State string `validate:"required"`
+}
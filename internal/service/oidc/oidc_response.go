@@ -0,0 +1,15 @@
+package oidc
+
+type GetLoginUrlServiceResponse struct {
+	Error error `json:"-"`
+	Url   string
+	State string
+}
+
+type AuthenticateServiceResponse struct {
+	Error     error `json:"-"`
+	UserId    int64
+	UserName  string
+	Email     string
+	IsCreated bool
+}
@@ -0,0 +1,401 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-clean-architecture/internal/data/database/identity"
+	"go-clean-architecture/internal/data/proxy/oidc"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.uber.org/zap"
+)
+
+var (
+	errOidcNotConfigured = customerror.New(errors.New("oidc login is not configured"), customerror.LogLevelWarn)
+	errInvalidState      = customerror.New(errors.New("oidc state is invalid or expired"), customerror.LogLevelInfo)
+	errInvalidIdToken    = customerror.New(errors.New("id token is invalid"), customerror.LogLevelWarn)
+)
+
+type IOidcService interface {
+	GetLoginUrl(ctx context.Context, ch chan *GetLoginUrlServiceResponse, model *GetLoginUrlServiceModel)
+	Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel)
+}
+
+type OidcService struct {
+	environment    env.IEnvironment
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	cachr          cacher.ICacher
+	oidcProxy      oidc.IOidcProxy
+	identityDb     identity.IIdentityDb
+	issuerUrl      string
+	clientId       string
+	clientSecret   string
+	redirectUrl    string
+	scopes         []string
+	cacheKeyPrefix string
+	stateDuration  time.Duration
+}
+
+// loginState
+// Kept in cache between login redirect and callback, keyed by state.
+type loginState struct {
+	CodeVerifier string
+	Nonce        string
+}
+
+// idTokenClaims
+// Claims of an OpenID Connect id token which are used to provision the user.
+type idTokenClaims struct {
+	Nonce             string `json:"nonce"`
+	Email             string `json:"email"`
+	EmailVerified     *bool  `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+	jwt.RegisteredClaims
+}
+
+// NewOidcService
+// Returns a new OidcService.
+func NewOidcService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	oidcProxy oidc.IOidcProxy,
+	identityDb identity.IIdentityDb,
+) IOidcService {
+	service := OidcService{
+		environment:    environment,
+		loggr:          loggr,
+		validatr:       validatr,
+		cachr:          cachr,
+		issuerUrl:      environment.Get(env.OidcIssuerUrl),
+		clientId:       environment.Get(env.OidcClientId),
+		clientSecret:   environment.Get(env.OidcClientSecret),
+		redirectUrl:    environment.Get(env.OidcRedirectUrl),
+		scopes:         []string{"openid", "email", "profile"},
+		cacheKeyPrefix: "auth-oidc-state",
+		stateDuration:  time.Minute * 10,
+	}
+
+	if oidcProxy != nil {
+		service.oidcProxy = oidcProxy
+	} else {
+		service.oidcProxy = oidc.NewOidcProxy(environment, loggr, validatr, cachr)
+	}
+
+	if identityDb != nil {
+		service.identityDb = identityDb
+	} else {
+		service.identityDb = identity.NewIdentityDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	return &service
+}
+
+// GetLoginUrl
+// Returns the authorization endpoint url of the identity provider for the authorization code flow with PKCE.
+// State, nonce and code verifier are kept in cache for 10 minutes until the callback.
+func (s *OidcService) GetLoginUrl(ctx context.Context, ch chan *GetLoginUrlServiceResponse, model *GetLoginUrlServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: modelErr}
+		return
+	}
+
+	if !s.isConfigured() {
+		ch <- &GetLoginUrlServiceResponse{Error: errOidcNotConfigured}
+		return
+	}
+
+	discovery := s.getDiscovery(ctx)
+	if discovery.Error != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: discovery.Error}
+		return
+	}
+
+	authorizationUrl, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: err}
+		return
+	}
+
+	state, err := randomString()
+	if err != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: err}
+		return
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: err}
+		return
+	}
+
+	codeVerifier, err := randomString()
+	if err != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: err}
+		return
+	}
+
+	err = s.cachr.Set(ctx, s.getCacheKey(state), loginState{CodeVerifier: codeVerifier, Nonce: nonce}, s.stateDuration)
+	if err != nil {
+		ch <- &GetLoginUrlServiceResponse{Error: err}
+		return
+	}
+
+	query := authorizationUrl.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", s.clientId)
+	query.Set("redirect_uri", s.redirectUrl)
+	query.Set("scope", strings.Join(s.scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authorizationUrl.RawQuery = query.Encode()
+
+	ch <- &GetLoginUrlServiceResponse{Url: authorizationUrl.String(), State: state}
+}
+
+// Authenticate
+// Exchanges the authorization code, verifies the id token and provisions the local user linked to the identity.
+// State is single use, returns an error if it is unknown, expired or already used.
+func (s *OidcService) Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &AuthenticateServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	if !s.isConfigured() {
+		ch <- &AuthenticateServiceResponse{Error: errOidcNotConfigured}
+		return
+	}
+
+	state, err := s.popState(ctx, model.State)
+	if err != nil {
+		ch <- &AuthenticateServiceResponse{Error: err}
+		return
+	}
+
+	discovery := s.getDiscovery(ctx)
+	if discovery.Error != nil {
+		ch <- &AuthenticateServiceResponse{Error: discovery.Error}
+		return
+	}
+
+	chExchangeCodeResponse := make(chan *oidc.ExchangeCodeResponse)
+	defer close(chExchangeCodeResponse)
+
+	go s.oidcProxy.ExchangeCode(
+		ctx, chExchangeCodeResponse, &oidc.ExchangeCodeModel{
+			TokenEndpoint: discovery.TokenEndpoint,
+			ClientId:      s.clientId,
+			ClientSecret:  s.clientSecret,
+			Code:          model.Code,
+			CodeVerifier:  state.CodeVerifier,
+			RedirectUri:   s.redirectUrl,
+		},
+	)
+
+	tokens := <-chExchangeCodeResponse
+	if tokens.Error != nil {
+		ch <- &AuthenticateServiceResponse{Error: tokens.Error}
+		return
+	}
+
+	claims, err := s.verifyIdToken(ctx, tokens.IdToken, discovery, state.Nonce)
+	if err != nil {
+		ch <- &AuthenticateServiceResponse{Error: err}
+		return
+	}
+
+	chProvisionUserResponse := make(chan *identity.ProvisionUserResponse)
+	defer close(chProvisionUserResponse)
+
+	go s.identityDb.ProvisionUser(
+		ctx, chProvisionUserResponse, &identity.ProvisionUserModel{
+			Issuer:   claims.Issuer,
+			Subject:  claims.Subject,
+			UserName: userName(claims),
+			Email:    verifiedEmail(claims),
+		},
+	)
+
+	user := <-chProvisionUserResponse
+	if user.Error != nil {
+		ch <- &AuthenticateServiceResponse{Error: user.Error}
+		return
+	}
+
+	if !user.IsActive {
+		ch <- &AuthenticateServiceResponse{Error: customerror.New(errors.New("user is not active"), customerror.LogLevelInfo)}
+		return
+	}
+
+	if user.IsCreated {
+		s.loggr.Info("User is provisioned from identity provider.",
+			zap.Int64("userId", user.UserId),
+			zap.String("userName", user.UserName),
+			zap.String("issuer", claims.Issuer),
+		)
+	}
+
+	ch <- &AuthenticateServiceResponse{
+		UserId:    user.UserId,
+		UserName:  user.UserName,
+		Email:     user.Email,
+		IsCreated: user.IsCreated,
+	}
+}
+
+// verifyIdToken
+// Verifies signature, issuer, audience, expiry and nonce of the id token.
+// Signing keys are fetched again once if the token is signed with a key which is not cached yet.
+func (s *OidcService) verifyIdToken(ctx context.Context, idToken string, discovery *oidc.GetDiscoveryResponse, nonce string) (*idTokenClaims, error) {
+	claims := idTokenClaims{}
+	token, err := jwt.ParseWithClaims(idToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errors.New("unexpected signing method: " + token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		for _, refresh := range []bool{false, true} {
+			chGetJwksResponse := make(chan *oidc.GetJwksResponse)
+			go s.oidcProxy.GetJwks(ctx, chGetJwksResponse, &oidc.GetJwksModel{JwksUri: discovery.JwksUri, Refresh: refresh})
+			jwks := <-chGetJwksResponse
+			close(chGetJwksResponse)
+			if jwks.Error != nil {
+				return nil, jwks.Error
+			}
+
+			if key, ok := jwks.Jwks.Key(kid); ok {
+				return key.PublicKey()
+			}
+		}
+
+		return nil, errors.New("signing key is not found: " + kid)
+	})
+	if err != nil || !token.Valid {
+		s.loggr.Warn("Id token could not be verified.", zap.Error(err))
+		return nil, errInvalidIdToken
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) ||
+		!claims.VerifyAudience(s.clientId, true) ||
+		claims.ExpiresAt == nil ||
+		claims.Subject == "" ||
+		subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		s.loggr.Warn("Id token claims are invalid.", zap.String("issuer", claims.Issuer), zap.String("subject", claims.Subject))
+		return nil, errInvalidIdToken
+	}
+
+	return &claims, nil
+}
+
+// popState
+// Gets and drops login state so it can only be used once.
+func (s *OidcService) popState(ctx context.Context, state string) (*loginState, error) {
+	cached, err := s.cachr.GetDelete(ctx, s.getCacheKey(state))
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		return nil, errInvalidState
+	}
+
+	var loginState loginState
+	err = json.Unmarshal([]byte(*cached), &loginState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loginState, nil
+}
+
+func (s *OidcService) getDiscovery(ctx context.Context) *oidc.GetDiscoveryResponse {
+	chGetDiscoveryResponse := make(chan *oidc.GetDiscoveryResponse)
+	defer close(chGetDiscoveryResponse)
+
+	go s.oidcProxy.GetDiscovery(ctx, chGetDiscoveryResponse, &oidc.GetDiscoveryModel{IssuerUrl: s.issuerUrl})
+
+	return <-chGetDiscoveryResponse
+}
+
+func (s *OidcService) isConfigured() bool {
+	return s.issuerUrl != "" && s.clientId != "" && s.redirectUrl != ""
+}
+
+func (s *OidcService) getCacheKey(state string) string {
+	return s.cacheKeyPrefix + ":" + state
+}
+
+// userName
+// Returns preferred username of the identity, or local part of its email, limited to the length of usernames.
+func userName(claims *idTokenClaims) string {
+	name := claims.PreferredUsername
+	if name == "" && claims.Email != "" {
+		name = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if name == "" {
+		hash := sha256.Sum256([]byte(claims.Issuer + " " + claims.Subject))
+		name = "user-" + hex.EncodeToString(hash[:6])
+	}
+
+	if runes := []rune(name); len(runes) > 30 {
+		name = string(runes[:30])
+	}
+
+	return name
+}
+
+// verifiedEmail
+// Returns email of the identity unless the provider states it is not verified.
+func verifiedEmail(claims *idTokenClaims) string {
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return ""
+	}
+
+	if len(claims.Email) > 100 {
+		return ""
+	}
+
+	return claims.Email
+}
+
+// randomString
+// Returns 32 random bytes as unpadded base64url, which is also a valid PKCE code verifier.
+func randomString() (string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// codeChallenge
+// Returns the S256 PKCE code challenge of the verifier.
+func codeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/oidc/oidc_service.go
+
+// Package oidc is a generated GoMock package.
+package oidc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOidcService is a mock of IOidcService interface.
+type MockIOidcService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOidcServiceMockRecorder
+}
+
+// MockIOidcServiceMockRecorder is the mock recorder for MockIOidcService.
+type MockIOidcServiceMockRecorder struct {
+	mock *MockIOidcService
+}
+
+// NewMockIOidcService creates a new mock instance.
+func NewMockIOidcService(ctrl *gomock.Controller) *MockIOidcService {
+	mock := &MockIOidcService{ctrl: ctrl}
+	mock.recorder = &MockIOidcServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOidcService) EXPECT() *MockIOidcServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIOidcService) Authenticate(ctx context.Context, ch chan *AuthenticateServiceResponse, model *AuthenticateServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Authenticate", ctx, ch, model)
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIOidcServiceMockRecorder) Authenticate(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIOidcService)(nil).Authenticate), ctx, ch, model)
+}
+
+// GetLoginUrl mocks base method.
+func (m *MockIOidcService) GetLoginUrl(ctx context.Context, ch chan *GetLoginUrlServiceResponse, model *GetLoginUrlServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLoginUrl", ctx, ch, model)
+}
+
+// GetLoginUrl indicates an expected call of GetLoginUrl.
+func (mr *MockIOidcServiceMockRecorder) GetLoginUrl(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginUrl", reflect.TypeOf((*MockIOidcService)(nil).GetLoginUrl), ctx, ch, model)
+}
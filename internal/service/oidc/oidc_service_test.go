@@ -0,0 +1,318 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/identity"
+	"go-clean-architecture/internal/data/proxy/oidc"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/keyring"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// stubOidcServer
+// Minimal OpenID provider which issues an id token for a single user to the code challenge it has seen.
+type stubOidcServer struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	kid           string
+	signingKey    *rsa.PrivateKey
+	clientId      string
+	codeChallenge string
+	nonce         string
+}
+
+func newStubOidcServer(clientId string) (*stubOidcServer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	stub := &stubOidcServer{key: key, signingKey: key, kid: "stub-key", clientId: clientId}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", stub.discovery)
+	mux.HandleFunc("/jwks", stub.jwks)
+	mux.HandleFunc("/token", stub.token)
+	stub.server = httptest.NewServer(mux)
+
+	return stub, nil
+}
+
+// authorize
+// Plays the user logging in at the provider, returns the code and state sent back to the callback.
+func (o *stubOidcServer) authorize(loginUrl string) (string, string) {
+	parsed, _ := url.Parse(loginUrl)
+	query := parsed.Query()
+	if query.Get("code_challenge_method") == "S256" {
+		o.codeChallenge = query.Get("code_challenge")
+	}
+	o.nonce = query.Get("nonce")
+
+	return "code", query.Get("state")
+}
+
+func (o *stubOidcServer) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 o.server.URL,
+		"authorization_endpoint": o.server.URL + "/authorize",
+		"token_endpoint":         o.server.URL + "/token",
+		"jwks_uri":               o.server.URL + "/jwks",
+	})
+}
+
+func (o *stubOidcServer) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(keyring.Jwks{Keys: []keyring.Jwk{{
+		Kty: "RSA",
+		Use: "sig",
+		Kid: o.kid,
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(o.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(o.key.E)).Bytes()),
+	}}})
+}
+
+func (o *stubOidcServer) token(w http.ResponseWriter, r *http.Request) {
+	hash := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("code") != "code" ||
+		r.PostFormValue("client_id") != o.clientId ||
+		base64.RawURLEncoding.EncodeToString(hash[:]) != o.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                o.server.URL,
+		"sub":                "subject-1",
+		"aud":                o.clientId,
+		"iat":                time.Now().Unix(),
+		"exp":                time.Now().Add(time.Minute * 5).Unix(),
+		"nonce":              o.nonce,
+		"email":              "john@example.com",
+		"email_verified":     true,
+		"preferred_username": "john.doe",
+	})
+	token.Header["kid"] = o.kid
+	idToken, _ := token.SignedString(o.signingKey)
+
+	json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "access_token": "access", "token_type": "Bearer"})
+}
+
+type OidcServiceTestSuite struct {
+	suite.Suite
+	oidcService     IOidcService
+	stub            *stubOidcServer
+	cacheErr        error
+	cache           map[string]string
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockIdentityDb  *identity.MockIIdentityDb
+}
+
+// Run suite.
+func TestOidcService(t *testing.T) {
+	suite.Run(t, new(OidcServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+// Service talks to a local stub provider through the real proxy, cache is kept in a map.
+func (s *OidcServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	var err error
+	s.stub, err = newStubOidcServer("client")
+	s.Require().NoError(err)
+	s.T().Cleanup(s.stub.server.Close)
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockIdentityDb = identity.NewMockIIdentityDb(ctrl)
+
+	s.mockEnvironment.EXPECT().Get(env.OidcIssuerUrl).Return(s.stub.server.URL)
+	s.mockEnvironment.EXPECT().Get(env.OidcClientId).Return("client")
+	s.mockEnvironment.EXPECT().Get(env.OidcClientSecret).Return("secret")
+	s.mockEnvironment.EXPECT().Get(env.OidcRedirectUrl).Return("http://localhost/api/v1/auth/oidc/callback")
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.cache = map[string]string{}
+	s.cacheErr = nil
+	s.mockCacher.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, key string, value interface{}, duration time.Duration) {
+		bytes, _ := json.Marshal(value)
+		s.cache[key] = string(bytes)
+	}).AnyTimes()
+	s.mockCacher.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) (*string, error) {
+		value, ok := s.cache[key]
+		if !ok {
+			return nil, nil
+		}
+		return &value, nil
+	}).AnyTimes()
+	s.mockCacher.EXPECT().GetDelete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string) (*string, error) {
+		if s.cacheErr != nil {
+			return nil, s.cacheErr
+		}
+		value, ok := s.cache[key]
+		if !ok {
+			return nil, nil
+		}
+		delete(s.cache, key)
+		return &value, nil
+	}).AnyTimes()
+
+	proxy := oidc.NewOidcProxy(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher)
+	s.oidcService = NewOidcService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, proxy, s.mockIdentityDb)
+}
+
+func (s *OidcServiceTestSuite) login() (string, string) {
+	ch := make(chan *GetLoginUrlServiceResponse)
+	defer close(ch)
+	go s.oidcService.GetLoginUrl(context.Background(), ch, &GetLoginUrlServiceModel{})
+	response := <-ch
+	s.Require().NoError(response.Error)
+
+	return s.stub.authorize(response.Url)
+}
+
+func (s *OidcServiceTestSuite) authenticate(code string, state string) *AuthenticateServiceResponse {
+	ch := make(chan *AuthenticateServiceResponse)
+	defer close(ch)
+	go s.oidcService.Authenticate(context.Background(), ch, &AuthenticateServiceModel{Code: code, State: state})
+	return <-ch
+}
+
+func (s *OidcServiceTestSuite) expectProvisionUser() {
+	s.mockIdentityDb.
+		EXPECT().
+		ProvisionUser(gomock.Any(), gomock.Any(), gomock.Eq(&identity.ProvisionUserModel{
+			Issuer:   s.stub.server.URL,
+			Subject:  "subject-1",
+			UserName: "john.doe",
+			Email:    "john@example.com",
+		})).
+		DoAndReturn(func(ctx context.Context, ch chan *identity.ProvisionUserResponse, model *identity.ProvisionUserModel) {
+			ch <- &identity.ProvisionUserResponse{UserId: 1, UserName: "john.doe", Email: "john@example.com", IsActive: true, IsCreated: true}
+		})
+}
+
+func (s *OidcServiceTestSuite) TestGetLoginUrl_RedirectsToProviderWithPkce() {
+	// When
+	ch := make(chan *GetLoginUrlServiceResponse)
+	defer close(ch)
+	go s.oidcService.GetLoginUrl(context.Background(), ch, &GetLoginUrlServiceModel{})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	loginUrl, err := url.Parse(response.Url)
+	s.Require().NoError(err)
+	s.Equal(s.stub.server.URL+"/authorize", loginUrl.Scheme+"://"+loginUrl.Host+loginUrl.Path)
+	s.Equal("code", loginUrl.Query().Get("response_type"))
+	s.Equal("S256", loginUrl.Query().Get("code_challenge_method"))
+	s.Equal(response.State, loginUrl.Query().Get("state"))
+	s.NotEmpty(loginUrl.Query().Get("nonce"))
+	s.NotContains(response.Url, "code_verifier")
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_StubProvider_ProvisionsUserFromIdToken() {
+	// Given
+	code, state := s.login()
+	s.expectProvisionUser()
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(1), response.UserId)
+	s.Equal("john.doe", response.UserName)
+	s.True(response.IsCreated)
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_ReusedState_ReturnsError() {
+	// Given
+	code, state := s.login()
+	s.expectProvisionUser()
+	s.Require().NoError(s.authenticate(code, state).Error)
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.EqualError(response.Error, "oidc state is invalid or expired")
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_CacheFails_ReturnsError() {
+	// Given
+	code, state := s.login()
+	s.cacheErr = errors.New("redis is down")
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.EqualError(response.Error, "redis is down")
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_CodeChallengeMismatch_ReturnsError() {
+	// Given
+	code, state := s.login()
+	s.stub.codeChallenge = "other"
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.ErrorContains(response.Error, "authorization code is rejected")
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_NonceMismatch_ReturnsError() {
+	// Given
+	code, state := s.login()
+	s.stub.nonce = "other"
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.EqualError(response.Error, "id token is invalid")
+}
+
+func (s *OidcServiceTestSuite) TestAuthenticate_UnknownSigningKey_ReturnsError() {
+	// Given
+	code, state := s.login()
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.stub.signingKey = otherKey
+
+	// When
+	response := s.authenticate(code, state)
+
+	// Then
+	s.EqualError(response.Error, "id token is invalid")
+}
@@ -0,0 +1,303 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-clean-architecture/internal/data/database/outbox"
+	"go-clean-architecture/internal/data/pubsub/publisher"
+	sample_publisher "go-clean-architecture/internal/data/pubsub/publisher/sample"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// Relay settings
+const (
+	batchSize     = 100
+	pollInterval  = time.Second
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute * 5
+)
+
+type IOutboxRelay interface {
+	Start()
+	Shutdown(ctx context.Context) error
+	RelayMessages(ctx context.Context, ch chan *RelayMessagesServiceResponse)
+	GetLag(ctx context.Context, ch chan *GetLagServiceResponse)
+}
+
+type OutboxRelay struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	cachr       cacher.ICacher
+	outboxDb    outbox.IOutboxDb
+	publishers  map[string]publisher.IMessagePublisher
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewOutboxRelay
+// Returns a new OutboxRelay. publishers are keyed by the topics of outbox messages, pub-sub publishers are used if nil.
+func NewOutboxRelay(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	outboxDb outbox.IOutboxDb,
+	publishers map[string]publisher.IMessagePublisher,
+) IOutboxRelay {
+	relay := OutboxRelay{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+		done:        make(chan struct{}),
+	}
+
+	if outboxDb != nil {
+		relay.outboxDb = outboxDb
+	} else {
+		relay.outboxDb = outbox.NewOutboxDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if publishers != nil {
+		relay.publishers = publishers
+	} else {
+		relay.publishers = map[string]publisher.IMessagePublisher{
+			publisher.SampleTopic: sample_publisher.NewSamplePublisher(environment, loggr, validatr, cachr),
+		}
+	}
+
+	return &relay
+}
+
+// Start
+// Relays messages in the background until Shutdown is called. Relays of every process poll, but only the one holding
+// the relay lock publishes, so messages are published once and in order.
+func (r *OutboxRelay) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	go r.run(ctx)
+}
+
+// Shutdown
+// Stops polling and waits for the round in progress, or until ctx is done.
+func (r *OutboxRelay) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		ch := make(chan *RelayMessagesServiceResponse)
+		go r.RelayMessages(ctx, ch)
+		response := <-ch
+		close(ch)
+
+		if response.Error != nil && ctx.Err() == nil {
+			r.loggr.Error("Outbox messages could not be relayed.", zap.Error(response.Error))
+		}
+
+		// A full batch means more messages may be due, so the next round starts without waiting.
+		wait := pollInterval
+		if response.Error == nil && response.IsBatchFull {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RelayMessages
+// Publishes a batch of pending messages in the order they are added and marks them as sent. A failed message is tried
+// again after a growing delay, and later messages with the same ordering key wait for it.
+// Messages may be published more than once if marking fails, so they carry their outbox id for consumers to dedupe.
+func (r *OutboxRelay) RelayMessages(ctx context.Context, ch chan *RelayMessagesServiceResponse) {
+	chLockRelayResponse := make(chan *outbox.LockRelayResponse)
+	defer close(chLockRelayResponse)
+
+	go r.outboxDb.LockRelay(ctx, chLockRelayResponse)
+
+	lockRelayResponse := <-chLockRelayResponse
+	if lockRelayResponse.Error != nil {
+		ch <- &RelayMessagesServiceResponse{Error: lockRelayResponse.Error}
+		return
+	}
+	if !lockRelayResponse.IsLocked {
+		ch <- &RelayMessagesServiceResponse{}
+		return
+	}
+
+	// The lock is released before responding, so the next round can take it again.
+	response := r.relayMessages(ctx)
+	lockRelayResponse.Unlock()
+
+	ch <- response
+}
+
+// GetLag
+// Gets how many messages are waiting to be published and how long the oldest one has been waiting.
+func (r *OutboxRelay) GetLag(ctx context.Context, ch chan *GetLagServiceResponse) {
+	chGetLagResponse := make(chan *outbox.GetLagResponse)
+	defer close(chGetLagResponse)
+
+	go r.outboxDb.GetLag(ctx, chGetLagResponse)
+
+	getLagResponse := <-chGetLagResponse
+	if getLagResponse.Error != nil {
+		ch <- &GetLagServiceResponse{Error: getLagResponse.Error}
+		return
+	}
+
+	ch <- &GetLagServiceResponse{
+		PendingCount:      getLagResponse.PendingCount,
+		FailingCount:      getLagResponse.FailingCount,
+		OldestPendingDate: getLagResponse.OldestPendingDate,
+		LagSeconds:        int64(getLagResponse.Lag.Seconds()),
+	}
+}
+
+// relayMessages
+// Publishes a batch of pending messages while the relay lock is held.
+func (r *OutboxRelay) relayMessages(ctx context.Context) *RelayMessagesServiceResponse {
+	chGetPendingMessagesResponse := make(chan *outbox.GetPendingMessagesResponse)
+	defer close(chGetPendingMessagesResponse)
+
+	go r.outboxDb.GetPendingMessages(ctx, chGetPendingMessagesResponse, &outbox.GetPendingMessagesModel{Limit: batchSize})
+
+	getPendingMessagesResponse := <-chGetPendingMessagesResponse
+	if getPendingMessagesResponse.Error != nil {
+		return &RelayMessagesServiceResponse{IsLocked: true, Error: getPendingMessagesResponse.Error}
+	}
+
+	response := RelayMessagesServiceResponse{
+		IsLocked:    true,
+		IsBatchFull: len(getPendingMessagesResponse.Messages) == batchSize,
+	}
+	blockedKeys := map[string]bool{}
+	for _, message := range getPendingMessagesResponse.Messages {
+		if ctx.Err() != nil {
+			response.Error = ctx.Err()
+			break
+		}
+		if message.OrderingKey != "" && blockedKeys[message.OrderingKey] {
+			continue
+		}
+
+		messageId, err := r.publish(ctx, message)
+		if err != nil {
+			r.loggr.Info("Outbox message could not be published.", zap.Int64("outboxId", message.Id), zap.Int("attempts", message.Attempts+1), zap.Error(err))
+			if message.OrderingKey != "" {
+				blockedKeys[message.OrderingKey] = true
+			}
+			response.FailedCount++
+
+			err = r.markFailed(ctx, message, err)
+		} else {
+			response.SentCount++
+
+			err = r.markSent(ctx, message, messageId)
+		}
+
+		if err != nil {
+			response.Error = err
+			break
+		}
+	}
+
+	return &response
+}
+
+// publish
+// Publishes a message with the publisher of its topic and returns the message id given by the publisher.
+func (r *OutboxRelay) publish(ctx context.Context, message outbox.Message) (string, error) {
+	messagePublisher, ok := r.publishers[message.Topic]
+	if !ok {
+		return "", fmt.Errorf("no publisher is registered for topic %q", message.Topic)
+	}
+
+	attributes := make(map[string]string, len(message.Attributes)+1)
+	for key, value := range message.Attributes {
+		attributes[key] = value
+	}
+	attributes[publisher.EventId] = strconv.FormatInt(message.Id, 10)
+
+	chPublisherResponse := make(chan publisher.PublisherResponse)
+	defer close(chPublisherResponse)
+
+	go messagePublisher.PublishMessage(ctx, chPublisherResponse, &publisher.MessageModel{
+		Data:        message.Payload,
+		Attributes:  attributes,
+		OrderingKey: message.OrderingKey,
+	})
+
+	publisherResponse := <-chPublisherResponse
+	if publisherResponse.Error != nil {
+		return "", publisherResponse.Error
+	}
+	if publisherResponse.MessageId == nil {
+		return "", nil
+	}
+
+	return *publisherResponse.MessageId, nil
+}
+
+func (r *OutboxRelay) markSent(ctx context.Context, message outbox.Message, messageId string) error {
+	chMarkSentResponse := make(chan *outbox.MarkSentResponse)
+	defer close(chMarkSentResponse)
+
+	go r.outboxDb.MarkSent(ctx, chMarkSentResponse, &outbox.MarkSentModel{Id: message.Id, MessageId: messageId})
+
+	return (<-chMarkSentResponse).Error
+}
+
+func (r *OutboxRelay) markFailed(ctx context.Context, message outbox.Message, publishErr error) error {
+	chMarkFailedResponse := make(chan *outbox.MarkFailedResponse)
+	defer close(chMarkFailedResponse)
+
+	go r.outboxDb.MarkFailed(ctx, chMarkFailedResponse, &outbox.MarkFailedModel{
+		Id:         message.Id,
+		Error:      publishErr.Error(),
+		RetryDelay: retryDelay(message.Attempts),
+	})
+
+	return (<-chMarkFailedResponse).Error
+}
+
+// retryDelay
+// Returns the delay before the next attempt of a message which failed attempts times before, doubled by each failure.
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 0; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+
+	return delay
+}
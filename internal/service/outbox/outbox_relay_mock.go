@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/outbox/outbox_relay.go
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOutboxRelay is a mock of IOutboxRelay interface.
+type MockIOutboxRelay struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRelayMockRecorder
+}
+
+// MockIOutboxRelayMockRecorder is the mock recorder for MockIOutboxRelay.
+type MockIOutboxRelayMockRecorder struct {
+	mock *MockIOutboxRelay
+}
+
+// NewMockIOutboxRelay creates a new mock instance.
+func NewMockIOutboxRelay(ctrl *gomock.Controller) *MockIOutboxRelay {
+	mock := &MockIOutboxRelay{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRelayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRelay) EXPECT() *MockIOutboxRelayMockRecorder {
+	return m.recorder
+}
+
+// GetLag mocks base method.
+func (m *MockIOutboxRelay) GetLag(ctx context.Context, ch chan *GetLagServiceResponse) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetLag", ctx, ch)
+}
+
+// GetLag indicates an expected call of GetLag.
+func (mr *MockIOutboxRelayMockRecorder) GetLag(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLag", reflect.TypeOf((*MockIOutboxRelay)(nil).GetLag), ctx, ch)
+}
+
+// RelayMessages mocks base method.
+func (m *MockIOutboxRelay) RelayMessages(ctx context.Context, ch chan *RelayMessagesServiceResponse) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RelayMessages", ctx, ch)
+}
+
+// RelayMessages indicates an expected call of RelayMessages.
+func (mr *MockIOutboxRelayMockRecorder) RelayMessages(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayMessages", reflect.TypeOf((*MockIOutboxRelay)(nil).RelayMessages), ctx, ch)
+}
+
+// Shutdown mocks base method.
+func (m *MockIOutboxRelay) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockIOutboxRelayMockRecorder) Shutdown(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockIOutboxRelay)(nil).Shutdown), ctx)
+}
+
+// Start mocks base method.
+func (m *MockIOutboxRelay) Start() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start")
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIOutboxRelayMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIOutboxRelay)(nil).Start))
+}
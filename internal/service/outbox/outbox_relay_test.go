@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/outbox"
+	"go-clean-architecture/internal/data/pubsub/publisher"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxRelayTestSuite struct {
+	suite.Suite
+	outboxRelay     IOutboxRelay
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockCacher      *cacher.MockICacher
+	mockOutboxDb    *outbox.MockIOutboxDb
+	memoryPublisher *publisher.MemoryPublisher
+	isUnlocked      bool
+}
+
+// Run suite.
+func TestOutboxRelay(t *testing.T) {
+	suite.Run(t, new(OutboxRelayTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *OutboxRelayTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockOutboxDb = outbox.NewMockIOutboxDb(ctrl)
+	s.memoryPublisher = publisher.NewMemoryPublisher()
+	s.isUnlocked = false
+
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.outboxRelay = NewOutboxRelay(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockOutboxDb, map[string]publisher.IMessagePublisher{
+		publisher.SampleTopic: s.memoryPublisher,
+	})
+}
+
+// expectLock
+// Expects the relay lock to be taken, released if it is acquired.
+func (s *OutboxRelayTestSuite) expectLock(isLocked bool) {
+	s.mockOutboxDb.
+		EXPECT().
+		LockRelay(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.LockRelayResponse) {
+			if !isLocked {
+				ch <- &outbox.LockRelayResponse{}
+				return
+			}
+
+			ch <- &outbox.LockRelayResponse{IsLocked: true, Unlock: func() { s.isUnlocked = true }}
+		})
+}
+
+// expectPendingMessages
+// Expects pending messages to be read and returns them.
+func (s *OutboxRelayTestSuite) expectPendingMessages(messages ...outbox.Message) {
+	s.mockOutboxDb.
+		EXPECT().
+		GetPendingMessages(gomock.Any(), gomock.Any(), gomock.Eq(&outbox.GetPendingMessagesModel{Limit: batchSize})).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.GetPendingMessagesResponse, model *outbox.GetPendingMessagesModel) {
+			ch <- &outbox.GetPendingMessagesResponse{Messages: messages}
+		})
+}
+
+func (s *OutboxRelayTestSuite) relayMessages() *RelayMessagesServiceResponse {
+	ch := make(chan *RelayMessagesServiceResponse)
+	defer close(ch)
+	go s.outboxRelay.RelayMessages(context.Background(), ch)
+
+	return <-ch
+}
+
+func (s *OutboxRelayTestSuite) TestRelayMessages_HappyPath_PublishesInOrderAndMarksSent() {
+	// Given
+	s.expectLock(true)
+	s.expectPendingMessages(
+		outbox.Message{Id: 1, Topic: publisher.SampleTopic, OrderingKey: "sample:1", Payload: []byte("first"), Attributes: map[string]string{"type": "test"}},
+		outbox.Message{Id: 2, Topic: publisher.SampleTopic, OrderingKey: "sample:1", Payload: []byte("second")},
+	)
+
+	var sentIds []int64
+	s.mockOutboxDb.
+		EXPECT().
+		MarkSent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.MarkSentResponse, model *outbox.MarkSentModel) {
+			sentIds = append(sentIds, model.Id)
+			ch <- &outbox.MarkSentResponse{}
+		})
+
+	// When
+	response := s.relayMessages()
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(2, response.SentCount)
+	s.Equal([]int64{1, 2}, sentIds)
+	s.True(s.isUnlocked)
+
+	messages := s.memoryPublisher.Messages()
+	s.Require().Len(messages, 2)
+	s.Equal([]byte("first"), messages[0].Data)
+	s.Equal("sample:1", messages[0].OrderingKey)
+	s.Equal(map[string]string{"type": "test", publisher.EventId: "1"}, messages[0].Attributes)
+	s.Equal([]byte("second"), messages[1].Data)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayMessages_PublishFailed_BlocksOnlySameOrderingKey() {
+	// Given
+	s.expectLock(true)
+	s.expectPendingMessages(
+		outbox.Message{Id: 1, Topic: publisher.SampleTopic, OrderingKey: "sample:1", Payload: []byte("first"), Attributes: map[string]string{}, Attempts: 2},
+		outbox.Message{Id: 2, Topic: publisher.SampleTopic, OrderingKey: "sample:2", Payload: []byte("other")},
+		outbox.Message{Id: 3, Topic: publisher.SampleTopic, OrderingKey: "sample:1", Payload: []byte("second")},
+	)
+	s.memoryPublisher.Fail(errors.New("pub-sub is unavailable"))
+
+	s.mockOutboxDb.
+		EXPECT().
+		MarkFailed(gomock.Any(), gomock.Any(), gomock.Eq(&outbox.MarkFailedModel{Id: 1, Error: "pub-sub is unavailable", RetryDelay: time.Second * 4})).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.MarkFailedResponse, model *outbox.MarkFailedModel) {
+			ch <- &outbox.MarkFailedResponse{}
+		})
+	s.mockOutboxDb.
+		EXPECT().
+		MarkSent(gomock.Any(), gomock.Any(), gomock.Eq(&outbox.MarkSentModel{Id: 2, MessageId: "1"})).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.MarkSentResponse, model *outbox.MarkSentModel) {
+			ch <- &outbox.MarkSentResponse{}
+		})
+
+	// When
+	response := s.relayMessages()
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(1, response.SentCount)
+	s.Equal(1, response.FailedCount)
+
+	messages := s.memoryPublisher.Messages()
+	s.Require().Len(messages, 1)
+	s.Equal([]byte("other"), messages[0].Data)
+}
+
+func (s *OutboxRelayTestSuite) TestRelayMessages_LockHeldByAnotherRelay_SkipsRound() {
+	// Given
+	s.expectLock(false)
+
+	// When
+	response := s.relayMessages()
+
+	// Then
+	s.NoError(response.Error)
+	s.False(response.IsLocked)
+	s.Empty(s.memoryPublisher.Messages())
+}
+
+func (s *OutboxRelayTestSuite) TestRelayMessages_MarkSentFailed_AbortsRound() {
+	// Given
+	s.expectLock(true)
+	s.expectPendingMessages(
+		outbox.Message{Id: 1, Topic: publisher.SampleTopic, Payload: []byte("first")},
+		outbox.Message{Id: 2, Topic: publisher.SampleTopic, Payload: []byte("second")},
+	)
+	s.mockOutboxDb.
+		EXPECT().
+		MarkSent(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.MarkSentResponse, model *outbox.MarkSentModel) {
+			ch <- &outbox.MarkSentResponse{Error: errors.New("db error")}
+		})
+
+	// When
+	response := s.relayMessages()
+
+	// Then
+	s.Error(response.Error)
+	s.Len(s.memoryPublisher.Messages(), 1)
+	s.True(s.isUnlocked)
+}
+
+func (s *OutboxRelayTestSuite) TestRetryDelay_ManyAttempts_IsCapped() {
+	s.Equal(time.Second, retryDelay(0))
+	s.Equal(time.Second*8, retryDelay(3))
+	s.Equal(maxRetryDelay, retryDelay(100))
+}
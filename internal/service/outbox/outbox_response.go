@@ -0,0 +1,19 @@
+package outbox
+
+import "time"
+
+type RelayMessagesServiceResponse struct {
+	Error       error `json:"-"`
+	IsLocked    bool
+	IsBatchFull bool
+	SentCount   int
+	FailedCount int
+}
+
+type GetLagServiceResponse struct {
+	Error             error `json:"-"`
+	PendingCount      int64
+	FailingCount      int64
+	OldestPendingDate *time.Time `json:",omitempty"`
+	LagSeconds        int64
+}
@@ -0,0 +1,20 @@
+package revocation
+
+import "time"
+
+type RevokeTokenServiceModel struct {
+	Jti       string `validate:"required,uuid"`
+	ExpiresAt *time.Time
+	RevokedBy int64 `validate:"required"`
+}
+
+type RevokeUserServiceModel struct {
+	UserId    int64 `validate:"required"`
+	RevokedBy int64 `validate:"required"`
+}
+
+type IsRevokedServiceModel struct {
+	Jti      string
+	UserId   int64     `validate:"required"`
+	IssuedAt time.Time `validate:"required"`
+}
@@ -0,0 +1,17 @@
+package revocation
+
+type RevokeTokenServiceResponse struct {
+	Error     error `json:"-"`
+	IsRevoked bool
+}
+
+type RevokeUserServiceResponse struct {
+	Error               error `json:"-"`
+	IsRevoked           bool
+	RevokedRefreshCount int64
+}
+
+type IsRevokedServiceResponse struct {
+	Error     error `json:"-"`
+	IsRevoked bool
+}
@@ -0,0 +1,237 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"go.uber.org/zap"
+)
+
+// MaxTokenLifetime
+// Longest lifetime of an access token, programmatic access tokens are limited to 365 expiry days.
+const MaxTokenLifetime = time.Hour * 24 * 365
+
+type IRevocationService interface {
+	RevokeToken(ctx context.Context, ch chan *RevokeTokenServiceResponse, model *RevokeTokenServiceModel)
+	RevokeUser(ctx context.Context, ch chan *RevokeUserServiceResponse, model *RevokeUserServiceModel)
+	IsRevoked(ctx context.Context, ch chan *IsRevokedServiceResponse, model *IsRevokedServiceModel)
+}
+
+type RevocationService struct {
+	environment     env.IEnvironment
+	loggr           logger.ILogger
+	validatr        validator.IValidator
+	cachr           cacher.ICacher
+	authDb          auth.IAuthDb
+	cacheKeyPrefix  string
+	localTtl        time.Duration
+	localMaxEntries int
+	localMutex      sync.Mutex
+	localEntries    map[string]localEntry
+}
+
+// localEntry
+// Revocation state read from the cache, kept in process for a short time.
+type localEntry struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// NewRevocationService
+// Returns a new RevocationService.
+func NewRevocationService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	authDb auth.IAuthDb,
+) IRevocationService {
+	service := RevocationService{
+		environment:     environment,
+		loggr:           loggr,
+		validatr:        validatr,
+		cachr:           cachr,
+		cacheKeyPrefix:  "auth-revocation",
+		localTtl:        time.Second * 5,
+		localMaxEntries: 10000,
+		localEntries:    map[string]localEntry{},
+	}
+
+	if authDb != nil {
+		service.authDb = authDb
+	} else {
+		service.authDb = auth.NewAuthDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	return &service
+}
+
+// RevokeToken
+// Revokes an access token by its jti until it expires.
+// Expiry is optional, tokens with an unknown expiry are kept revoked for the longest token lifetime.
+func (s *RevocationService) RevokeToken(ctx context.Context, ch chan *RevokeTokenServiceResponse, model *RevokeTokenServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeTokenServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	ttl := MaxTokenLifetime
+	if model.ExpiresAt != nil {
+		ttl = time.Until(*model.ExpiresAt)
+	}
+
+	if ttl <= 0 {
+		ch <- &RevokeTokenServiceResponse{Error: customerror.New(errors.New("token is already expired"), customerror.LogLevelInfo)}
+		return
+	}
+
+	key := s.getCacheKey("jti", model.Jti)
+	err := s.cachr.Set(ctx, key, 1, ttl)
+	if err != nil {
+		ch <- &RevokeTokenServiceResponse{Error: err}
+		return
+	}
+	s.setLocal(key, 1)
+
+	s.loggr.Info("Access token is revoked.", zap.String("jti", model.Jti), zap.Int64("revokedBy", model.RevokedBy))
+
+	ch <- &RevokeTokenServiceResponse{IsRevoked: true}
+}
+
+// RevokeUser
+// Revokes every access and refresh token issued to the user until now.
+// Issue times of tokens are in seconds, so access tokens issued in the current second are revoked as well.
+func (s *RevocationService) RevokeUser(ctx context.Context, ch chan *RevokeUserServiceResponse, model *RevokeUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &RevokeUserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRevokeRefreshTokenResponse := make(chan *auth.RevokeRefreshTokenResponse)
+	defer close(chRevokeRefreshTokenResponse)
+
+	go s.authDb.RevokeUserRefreshTokens(
+		ctx, chRevokeRefreshTokenResponse, &auth.RevokeUserRefreshTokensModel{
+			UserId: model.UserId,
+		},
+	)
+
+	revokeRefreshTokenResponse := <-chRevokeRefreshTokenResponse
+	if revokeRefreshTokenResponse.Error != nil {
+		ch <- &RevokeUserServiceResponse{Error: revokeRefreshTokenResponse.Error}
+		return
+	}
+
+	revokedBefore := time.Now().Truncate(time.Second).Add(time.Second).Unix()
+	key := s.getCacheKey("user", strconv.FormatInt(model.UserId, 10))
+	err := s.cachr.Set(ctx, key, revokedBefore, MaxTokenLifetime)
+	if err != nil {
+		ch <- &RevokeUserServiceResponse{Error: err}
+		return
+	}
+	s.setLocal(key, revokedBefore)
+
+	s.loggr.Info("Access tokens of the user are revoked.",
+		zap.Int64("userId", model.UserId),
+		zap.Int64("revokedBy", model.RevokedBy),
+		zap.Int64("revokedRefreshCount", revokeRefreshTokenResponse.RevokedCount),
+	)
+
+	ch <- &RevokeUserServiceResponse{
+		IsRevoked:           true,
+		RevokedRefreshCount: revokeRefreshTokenResponse.RevokedCount,
+	}
+}
+
+// IsRevoked
+// Checks if an access token is revoked by its jti or by its user.
+// Results are kept in process for a few seconds to avoid a cache round-trip on every request.
+func (s *RevocationService) IsRevoked(ctx context.Context, ch chan *IsRevokedServiceResponse, model *IsRevokedServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &IsRevokedServiceResponse{Error: modelErr}
+		return
+	}
+
+	if model.Jti != "" {
+		isRevoked, err := s.get(ctx, s.getCacheKey("jti", model.Jti))
+		if err != nil {
+			ch <- &IsRevokedServiceResponse{Error: err}
+			return
+		}
+
+		if isRevoked != 0 {
+			ch <- &IsRevokedServiceResponse{IsRevoked: true}
+			return
+		}
+	}
+
+	revokedBefore, err := s.get(ctx, s.getCacheKey("user", strconv.FormatInt(model.UserId, 10)))
+	if err != nil {
+		ch <- &IsRevokedServiceResponse{Error: err}
+		return
+	}
+
+	ch <- &IsRevokedServiceResponse{IsRevoked: revokedBefore != 0 && model.IssuedAt.Unix() < revokedBefore}
+}
+
+// get
+// Returns the value of the key from the process if it is fresh, from the cache otherwise. Missing keys are zero.
+// Cache errors are returned and not kept in process, so revocation fails closed while the cache is unavailable.
+func (s *RevocationService) get(ctx context.Context, key string) (int64, error) {
+	s.localMutex.Lock()
+	entry, ok := s.localEntries[key]
+	s.localMutex.Unlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	cached, err := s.cachr.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	var value int64
+	if cached != nil {
+		parsed, err := strconv.ParseInt(*cached, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		value = parsed
+	}
+
+	s.setLocal(key, value)
+
+	return value, nil
+}
+
+// setLocal
+// Keeps the value in process. Entries are dropped all together once the limit is reached to bound memory usage.
+func (s *RevocationService) setLocal(key string, value int64) {
+	s.localMutex.Lock()
+	defer s.localMutex.Unlock()
+
+	if len(s.localEntries) >= s.localMaxEntries {
+		s.localEntries = map[string]localEntry{}
+	}
+
+	s.localEntries[key] = localEntry{value: value, expiresAt: time.Now().Add(s.localTtl)}
+}
+
+func (s *RevocationService) getCacheKey(kind string, key string) string {
+	return s.cacheKeyPrefix + ":" + kind + ":" + key
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/revocation/revocation_service.go
+
+// Package revocation is a generated GoMock package.
+package revocation
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRevocationService is a mock of IRevocationService interface.
+type MockIRevocationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRevocationServiceMockRecorder
+}
+
+// MockIRevocationServiceMockRecorder is the mock recorder for MockIRevocationService.
+type MockIRevocationServiceMockRecorder struct {
+	mock *MockIRevocationService
+}
+
+// NewMockIRevocationService creates a new mock instance.
+func NewMockIRevocationService(ctrl *gomock.Controller) *MockIRevocationService {
+	mock := &MockIRevocationService{ctrl: ctrl}
+	mock.recorder = &MockIRevocationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRevocationService) EXPECT() *MockIRevocationServiceMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockIRevocationService) IsRevoked(ctx context.Context, ch chan *IsRevokedServiceResponse, model *IsRevokedServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IsRevoked", ctx, ch, model)
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockIRevocationServiceMockRecorder) IsRevoked(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockIRevocationService)(nil).IsRevoked), ctx, ch, model)
+}
+
+// RevokeToken mocks base method.
+func (m *MockIRevocationService) RevokeToken(ctx context.Context, ch chan *RevokeTokenServiceResponse, model *RevokeTokenServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeToken", ctx, ch, model)
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockIRevocationServiceMockRecorder) RevokeToken(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockIRevocationService)(nil).RevokeToken), ctx, ch, model)
+}
+
+// RevokeUser mocks base method.
+func (m *MockIRevocationService) RevokeUser(ctx context.Context, ch chan *RevokeUserServiceResponse, model *RevokeUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeUser", ctx, ch, model)
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockIRevocationServiceMockRecorder) RevokeUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockIRevocationService)(nil).RevokeUser), ctx, ch, model)
+}
@@ -0,0 +1,232 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testJti = "8f14e45f-ceea-467f-a0e8-0a1b2c3d4e5f"
+
+type RevocationServiceTestSuite struct {
+	suite.Suite
+	revocationService IRevocationService
+	mockEnvironment   *env.MockIEnvironment
+	mockLogger        *logger.MockILogger
+	mockValidator     *validator.MockIValidator
+	mockCacher        *cacher.MockICacher
+	mockAuthDb        *auth.MockIAuthDb
+}
+
+// Run suite.
+func TestRevocationService(t *testing.T) {
+	suite.Run(t, new(RevocationServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *RevocationServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockAuthDb = auth.NewMockIAuthDb(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.revocationService = NewRevocationService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockAuthDb)
+}
+
+func (s *RevocationServiceTestSuite) isRevoked(model *IsRevokedServiceModel) *IsRevokedServiceResponse {
+	ch := make(chan *IsRevokedServiceResponse)
+	defer close(ch)
+	go s.revocationService.IsRevoked(context.Background(), ch, model)
+	return <-ch
+}
+
+func (s *RevocationServiceTestSuite) expectCached(key string, value *string) {
+	s.mockCacher.EXPECT().Get(gomock.Any(), key).Return(value, nil).Times(1)
+}
+
+func (s *RevocationServiceTestSuite) TestRevokeToken_ExpiresAt_SetsTtlToRemainingLifetime() {
+	// Given
+	expiresAt := time.Now().Add(time.Minute * 10)
+	s.mockCacher.
+		EXPECT().
+		Set(gomock.Any(), "auth-revocation:jti:"+testJti, 1, gomock.Any()).
+		Do(func(ctx context.Context, key string, value interface{}, duration time.Duration) {
+			s.InDelta(time.Minute*10, duration, float64(time.Second))
+		})
+
+	// When
+	ch := make(chan *RevokeTokenServiceResponse)
+	defer close(ch)
+	go s.revocationService.RevokeToken(context.Background(), ch, &RevokeTokenServiceModel{Jti: testJti, ExpiresAt: &expiresAt, RevokedBy: 2})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestRevokeToken_ExpiredToken_ReturnsError() {
+	// Given
+	expiresAt := time.Now().Add(-time.Minute)
+
+	// When
+	ch := make(chan *RevokeTokenServiceResponse)
+	defer close(ch)
+	go s.revocationService.RevokeToken(context.Background(), ch, &RevokeTokenServiceModel{Jti: testJti, ExpiresAt: &expiresAt, RevokedBy: 2})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "token is already expired")
+}
+
+func (s *RevocationServiceTestSuite) TestRevokeUser_RevokesRefreshTokensAndAccessTokensIssuedBefore() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), gomock.Eq(&auth.RevokeUserRefreshTokensModel{UserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.RevokeRefreshTokenResponse, model *auth.RevokeUserRefreshTokensModel) {
+			ch <- &auth.RevokeRefreshTokenResponse{RevokedCount: 3}
+		})
+	s.mockCacher.EXPECT().Set(gomock.Any(), "auth-revocation:user:1", gomock.Any(), MaxTokenLifetime)
+	s.expectCached("auth-revocation:jti:"+testJti, nil)
+
+	// When
+	ch := make(chan *RevokeUserServiceResponse)
+	defer close(ch)
+	go s.revocationService.RevokeUser(context.Background(), ch, &RevokeUserServiceModel{UserId: 1, RevokedBy: 2})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(3), response.RevokedRefreshCount)
+	s.True(s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now().Add(-time.Minute)}).IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestRevokeUser_CacheFailed_ReturnsError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.RevokeRefreshTokenResponse, model *auth.RevokeUserRefreshTokensModel) {
+			ch <- &auth.RevokeRefreshTokenResponse{}
+		})
+	s.mockCacher.EXPECT().Set(gomock.Any(), "auth-revocation:user:1", gomock.Any(), MaxTokenLifetime).Return(errors.New("redis is unavailable"))
+
+	// When
+	ch := make(chan *RevokeUserServiceResponse)
+	defer close(ch)
+	go s.revocationService.RevokeUser(context.Background(), ch, &RevokeUserServiceModel{UserId: 1, RevokedBy: 2})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "redis is unavailable")
+	s.False(response.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestIsRevoked_RevokedJti_ReturnsTrue() {
+	// Given
+	revoked := "1"
+	s.expectCached("auth-revocation:jti:"+testJti, &revoked)
+
+	// When
+	response := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestIsRevoked_IssuedBeforeUserRevocation_ReturnsTrue() {
+	// Given
+	revokedBefore := strconv.FormatInt(time.Now().Unix(), 10)
+	s.expectCached("auth-revocation:jti:"+testJti, nil)
+	s.expectCached("auth-revocation:user:1", &revokedBefore)
+
+	// When
+	response := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now().Add(-time.Hour)})
+
+	// Then
+	s.True(response.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestIsRevoked_IssuedAfterUserRevocation_ReturnsFalse() {
+	// Given
+	revokedBefore := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	s.expectCached("auth-revocation:jti:"+testJti, nil)
+	s.expectCached("auth-revocation:user:1", &revokedBefore)
+
+	// When
+	response := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+
+	// Then
+	s.False(response.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestRevokeUser_TokenIssuedInSameSecond_IsRevoked() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.RevokeRefreshTokenResponse, model *auth.RevokeUserRefreshTokensModel) {
+			ch <- &auth.RevokeRefreshTokenResponse{}
+		})
+	s.mockCacher.EXPECT().Set(gomock.Any(), "auth-revocation:user:1", gomock.Any(), MaxTokenLifetime)
+	s.expectCached("auth-revocation:jti:"+testJti, nil)
+
+	// When
+	ch := make(chan *RevokeUserServiceResponse)
+	defer close(ch)
+	go s.revocationService.RevokeUser(context.Background(), ch, &RevokeUserServiceModel{UserId: 1, RevokedBy: 2})
+	s.Require().NoError((<-ch).Error)
+	issuedAt := jwt.NewNumericDate(time.Now()).Time
+
+	// Then
+	s.True(s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: issuedAt}).IsRevoked)
+	s.False(s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: issuedAt.Add(time.Second)}).IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestIsRevoked_RepeatedChecks_AreServedInProcess() {
+	// Given
+	s.expectCached("auth-revocation:jti:"+testJti, nil)
+	s.expectCached("auth-revocation:user:1", nil)
+
+	// When
+	first := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+	second := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+
+	// Then
+	s.False(first.IsRevoked)
+	s.False(second.IsRevoked)
+}
+
+func (s *RevocationServiceTestSuite) TestIsRevoked_CacheFailed_ReturnsErrorAndDoesNotKeepResult() {
+	// Given
+	s.mockCacher.EXPECT().Get(gomock.Any(), "auth-revocation:jti:"+testJti).Return(nil, errors.New("redis is unavailable")).Times(2)
+
+	// When
+	first := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+	second := s.isRevoked(&IsRevokedServiceModel{Jti: testJti, UserId: 1, IssuedAt: time.Now()})
+
+	// Then
+	s.EqualError(first.Error, "redis is unavailable")
+	s.EqualError(second.Error, "redis is unavailable")
+}
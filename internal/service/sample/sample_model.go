@@ -2,8 +2,32 @@ package sample
 
 import (
 	"go-clean-architecture/internal/data/pubsub/publisher/sample"
+	"go-clean-architecture/internal/util/query"
 )
 
+type GetSamplesServiceModel struct {
+	Query          query.Model
+	IncludeDeleted bool
+}
+
+type GetSampleByIdServiceModel struct {
+	SampleId int64 `validate:"required"`
+}
+
+type AddSampleServiceModel struct {
+	SampleName string `validate:"required,max=100"`
+	SampleType string `validate:"required,max=50"`
+	SampleCode *int
+}
+
+type DeleteSampleServiceModel struct {
+	SampleId int64 `validate:"required"`
+}
+
+type RestoreSampleServiceModel struct {
+	SampleId int64 `validate:"required"`
+}
+
 type GetSampleServiceModel struct {
 	Id         int    `validate:"required,gte=0"`
 	SampleName string `validate:"required"`
@@ -13,6 +37,7 @@ type UpdateSampleServiceModel struct {
 	SampleId     int    `validate:"required,gte=0"`
 	SampleStatus int    `validate:"required,gte=0"`
 	ModifiedBy   string `validate:"required"`
+	Version      int    `validate:"gte=0"`
 }
 
 type PublishPubSubMessageServiceModel struct {
@@ -1,20 +1,36 @@
 package sample
 
+import (
+	"go-clean-architecture/internal/data/database/sample"
+	"go-clean-architecture/internal/util/query"
+)
+
+type GetSamplesServiceResponse struct {
+	Error   error `json:"-"`
+	Samples []sample.Sample
+	Page    query.Page
+}
+
+type SampleServiceResponse struct {
+	Error  error `json:"-"`
+	Sample sample.Sample
+}
+
+type DeleteSampleServiceResponse struct {
+	Error     error `json:"-"`
+	IsDeleted bool
+}
+
 type GetSampleServiceResponse struct {
 	Error      error `json:"-"`
 	Id         int
 	SampleName string
 }
 
-type UpdateSampleServiceResponse struct {
-	Error        error `json:"-"`
-	IsSuccessful bool
-}
-
 type PublishPubSubMessageServiceResponse struct {
 	Error        error `json:"-"`
 	IsSuccessful bool
-	MessageIds   []string
+	OutboxIds    []int64
 }
 
 type PostSampleXmlServiceResponse struct {
@@ -1,37 +1,61 @@
 package sample
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"go-clean-architecture/internal/data/database/sample"
 	sample_proxy "go-clean-architecture/internal/data/proxy/sample"
-	sample_publisher "go-clean-architecture/internal/data/pubsub/publisher/sample"
+	"strconv"
 	"time"
 
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/data/database/outbox"
 	"go-clean-architecture/internal/data/pubsub/publisher"
 	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
 	"go-clean-architecture/internal/util/validator"
 )
 
+var errSampleNotFound = customerror.New(fmt.Errorf("sample is %w", customerror.ErrNotFound), customerror.LogLevelInfo)
+
+// Sample events
+// Types of the events added to the outbox with each change of a sample, set as publisher.EventType attribute.
+const (
+	SampleEventCreated  = "sample.created"
+	SampleEventUpdated  = "sample.updated"
+	SampleEventDeleted  = "sample.deleted"
+	SampleEventRestored = "sample.restored"
+)
+
 type ISampleService interface {
-	GetGoogle(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel)
-	GetDatabase(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel)
-	GetCache(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel)
-	PublishPubSubMessage(ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel)
-	UpdateSample(ch chan *UpdateSampleServiceResponse, model *UpdateSampleServiceModel)
-	PostSampleXml(ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel)
+	GetSamples(ctx context.Context, ch chan *GetSamplesServiceResponse, model *GetSamplesServiceModel)
+	GetSample(ctx context.Context, ch chan *SampleServiceResponse, model *GetSampleByIdServiceModel)
+	AddSample(ctx context.Context, ch chan *SampleServiceResponse, model *AddSampleServiceModel)
+	UpdateSample(ctx context.Context, ch chan *SampleServiceResponse, model *UpdateSampleServiceModel)
+	DeleteSample(ctx context.Context, ch chan *DeleteSampleServiceResponse, model *DeleteSampleServiceModel)
+	RestoreSample(ctx context.Context, ch chan *SampleServiceResponse, model *RestoreSampleServiceModel)
+	GetGoogle(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel)
+	GetCache(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel)
+	PublishPubSubMessage(ctx context.Context, ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel)
+	PostSampleXml(ctx context.Context, ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel)
 }
 
 type SampleService struct {
-	environment     env.IEnvironment
-	loggr           logger.ILogger
-	validatr        validator.IValidator
-	cachr           cacher.ICacher
-	sampleProxy     sample_proxy.ISampleProxy
-	sampleDb        sample.ISampleDb
-	samplePublisher sample_publisher.ISamplePublisher
-	sampleXmlProxy  sample_proxy.ISampleXmlProxy
+	environment    env.IEnvironment
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	cachr          cacher.ICacher
+	sampleProxy    sample_proxy.ISampleProxy
+	sampleDb       sample.ISampleDb
+	outboxDb       outbox.IOutboxDb
+	sampleXmlProxy sample_proxy.ISampleXmlProxy
+	unitOfWork     database.IUnitOfWork
 }
 
 // NewSampleService
@@ -41,7 +65,8 @@ func NewSampleService(
 	loggr logger.ILogger,
 	validatr validator.IValidator,
 	cachr cacher.ICacher,
-	sampleProxy sample_proxy.ISampleProxy, sampleDb sample.ISampleDb, samplePublisher sample_publisher.ISamplePublisher, sampleXmlProxy sample_proxy.ISampleXmlProxy) ISampleService {
+	sampleProxy sample_proxy.ISampleProxy, sampleDb sample.ISampleDb, outboxDb outbox.IOutboxDb, sampleXmlProxy sample_proxy.ISampleXmlProxy,
+	unitOfWork database.IUnitOfWork) ISampleService {
 	service := SampleService{
 		environment: environment,
 		loggr:       loggr,
@@ -64,76 +89,206 @@ func NewSampleService(
 	if sampleDb != nil {
 		service.sampleDb = sampleDb
 	} else {
-		service.sampleDb = sample.NewSampleDb(environment, loggr, validatr, cachr)
+		service.sampleDb = sample.NewSampleDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if outboxDb != nil {
+		service.outboxDb = outboxDb
+	} else {
+		service.outboxDb = outbox.NewOutboxDb(environment, loggr, validatr, cachr, nil)
 	}
 
-	if samplePublisher != nil {
-		service.samplePublisher = samplePublisher
+	if unitOfWork != nil {
+		service.unitOfWork = unitOfWork
 	} else {
-		service.samplePublisher = sample_publisher.NewSamplePublisher(environment, loggr, validatr, cachr)
+		service.unitOfWork = database.NewUnitOfWork(environment, nil)
 	}
 
 	return &service
 }
 
-// GetGoogle
-// Gets a sample service response from Google via proxy.
-func (s *SampleService) GetGoogle(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+// GetSamples
+// Gets a page of samples filtered and sorted by the fields of sample.QuerySchema. Deleted samples are only
+// included if requested.
+func (s *SampleService) GetSamples(ctx context.Context, ch chan *GetSamplesServiceResponse, model *GetSamplesServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
-		ch <- &GetSampleServiceResponse{Error: modelErr}
+		ch <- &GetSamplesServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
 		return
 	}
 
-	getSampleCh := make(chan *sample_proxy.GetSampleProxyResponse)
-	defer close(getSampleCh)
-	go s.sampleProxy.GetGoogle(getSampleCh, &sample_proxy.GetSampleProxyModel{
-		Id:         model.Id,
-		SampleName: model.SampleName,
+	sampleQuery, err := query.Parse(sample.QuerySchema, model.Query)
+	if err != nil {
+		ch <- &GetSamplesServiceResponse{Error: customerror.New(err, customerror.LogLevelInfo)}
+		return
+	}
+	sampleQuery.IncludeDeleted = model.IncludeDeleted
+
+	chGetSamplesResponse := make(chan *sample.GetSamplesDbResponse)
+	defer close(chGetSamplesResponse)
+
+	go s.sampleDb.GetSamples(ctx, chGetSamplesResponse, &sample.GetSamplesDbModel{Query: sampleQuery})
+
+	getSamplesResponse := <-chGetSamplesResponse
+	if getSamplesResponse.Error != nil {
+		ch <- &GetSamplesServiceResponse{Error: getSamplesResponse.Error}
+		return
+	}
+
+	ch <- &GetSamplesServiceResponse{
+		Samples: getSamplesResponse.Samples,
+		Page:    getSamplesResponse.Page,
+	}
+}
+
+// GetSample
+// Gets a sample by id.
+func (s *SampleService) GetSample(ctx context.Context, ch chan *SampleServiceResponse, model *GetSampleByIdServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chGetSampleResponse := make(chan *sample.SampleDbResponse)
+	defer close(chGetSampleResponse)
+
+	go s.sampleDb.GetSample(ctx, chGetSampleResponse, &sample.GetSampleDbModel{Id: model.SampleId})
+
+	ch <- sampleServiceResponse(<-chGetSampleResponse)
+}
+
+// AddSample
+// Adds a sample with the initial status, along with its created event.
+func (s *SampleService) AddSample(ctx context.Context, ch chan *SampleServiceResponse, model *AddSampleServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	ch <- s.changeSample(ctx, SampleEventCreated, func(tx database.ITransaction) *sample.SampleDbResponse {
+		chAddSampleResponse := make(chan *sample.SampleDbResponse)
+		defer close(chAddSampleResponse)
+
+		go s.sampleDb.AddSample(ctx, chAddSampleResponse, &sample.AddSampleDbModel{
+			SampleName:  model.SampleName,
+			SampleType:  model.SampleType,
+			SampleCode:  model.SampleCode,
+			Transaction: tx,
+		})
+
+		return <-chAddSampleResponse
 	})
-	sampleProxyResponse := <-getSampleCh
-	if sampleProxyResponse.Error != nil {
-		ch <- &GetSampleServiceResponse{Error: sampleProxyResponse.Error}
+}
+
+// UpdateSample
+// Updates status of a sample with details provided by google pub sub message or via http endpoint.
+// If Version is set, fails with database.ErrVersionMismatch if the sample is modified since that version.
+// The updated event is added along with the change.
+func (s *SampleService) UpdateSample(ctx context.Context, ch chan *SampleServiceResponse, model *UpdateSampleServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
 		return
 	}
 
-	ch <- &GetSampleServiceResponse{
-		Id:         sampleProxyResponse.Id,
-		SampleName: sampleProxyResponse.SampleName,
+	ch <- s.changeSample(ctx, SampleEventUpdated, func(tx database.ITransaction) *sample.SampleDbResponse {
+		chUpdateSampleResponse := make(chan *sample.SampleDbResponse)
+		defer close(chUpdateSampleResponse)
+
+		go s.sampleDb.UpdateSample(ctx, chUpdateSampleResponse, &sample.UpdateSampleDbModel{
+			Id:           int64(model.SampleId),
+			SampleStatus: model.SampleStatus,
+			ModifiedBy:   model.ModifiedBy,
+			Version:      model.Version,
+			Transaction:  tx,
+		})
+
+		return <-chUpdateSampleResponse
+	})
+}
+
+// DeleteSample
+// Soft deletes a sample along with its deleted event, it can be restored by an admin.
+func (s *SampleService) DeleteSample(ctx context.Context, ch chan *DeleteSampleServiceResponse, model *DeleteSampleServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &DeleteSampleServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	deleteSampleResponse := s.changeSample(ctx, SampleEventDeleted, func(tx database.ITransaction) *sample.SampleDbResponse {
+		chDeleteSampleResponse := make(chan *sample.SampleDbResponse)
+		defer close(chDeleteSampleResponse)
+
+		go s.sampleDb.DeleteSample(ctx, chDeleteSampleResponse, &sample.DeleteSampleDbModel{
+			Id:          model.SampleId,
+			Transaction: tx,
+		})
+
+		return <-chDeleteSampleResponse
+	})
+	if deleteSampleResponse.Error != nil {
+		ch <- &DeleteSampleServiceResponse{Error: deleteSampleResponse.Error}
+		return
 	}
+
+	ch <- &DeleteSampleServiceResponse{IsDeleted: true}
 }
 
-// GetDatabase
-// Gets a sample service response from postgresql database.
-func (s *SampleService) GetDatabase(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+// RestoreSample
+// Restores a deleted sample along with its restored event.
+func (s *SampleService) RestoreSample(ctx context.Context, ch chan *SampleServiceResponse, model *RestoreSampleServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &SampleServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	ch <- s.changeSample(ctx, SampleEventRestored, func(tx database.ITransaction) *sample.SampleDbResponse {
+		chRestoreSampleResponse := make(chan *sample.SampleDbResponse)
+		defer close(chRestoreSampleResponse)
+
+		go s.sampleDb.RestoreSample(ctx, chRestoreSampleResponse, &sample.RestoreSampleDbModel{
+			Id:          model.SampleId,
+			Transaction: tx,
+		})
+
+		return <-chRestoreSampleResponse
+	})
+}
+
+// GetGoogle
+// Gets a sample service response from Google via proxy.
+func (s *SampleService) GetGoogle(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &GetSampleServiceResponse{Error: modelErr}
 		return
 	}
 
-	getSampleCh := make(chan *sample.GetSampleDbResponse)
+	getSampleCh := make(chan *sample_proxy.GetSampleProxyResponse)
 	defer close(getSampleCh)
-	go s.sampleDb.GetSample(getSampleCh, &sample.GetSampleDbModel{
+	go s.sampleProxy.GetGoogle(ctx, getSampleCh, &sample_proxy.GetSampleProxyModel{
 		Id:         model.Id,
 		SampleName: model.SampleName,
 	})
-
-	sampleDbResponse := <-getSampleCh
-	if sampleDbResponse.Error != nil {
-		ch <- &GetSampleServiceResponse{Error: sampleDbResponse.Error}
+	sampleProxyResponse := <-getSampleCh
+	if sampleProxyResponse.Error != nil {
+		ch <- &GetSampleServiceResponse{Error: sampleProxyResponse.Error}
 		return
 	}
 
 	ch <- &GetSampleServiceResponse{
-		Id:         sampleDbResponse.Id,
-		SampleName: sampleDbResponse.SampleName,
+		Id:         sampleProxyResponse.Id,
+		SampleName: sampleProxyResponse.SampleName,
 	}
 }
 
 // GetCache
 // Gets a sample service response from redis cache.
-func (s *SampleService) GetCache(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+func (s *SampleService) GetCache(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &GetSampleServiceResponse{Error: modelErr}
@@ -141,9 +296,14 @@ func (s *SampleService) GetCache(ch chan *GetSampleServiceResponse, model *GetSa
 	}
 
 	var response GetSampleServiceResponse
-	cacheValue := s.cachr.Get("dummy_cache_key")
+	cacheValue, err := s.cachr.Get(ctx, "dummy_cache_key")
+	if err != nil {
+		ch <- &GetSampleServiceResponse{Error: err}
+		return
+	}
+
 	if cacheValue != nil {
-		err := json.Unmarshal([]byte(*cacheValue), &response)
+		err = json.Unmarshal([]byte(*cacheValue), &response)
 		if err != nil {
 			s.loggr.Panic("Panicked while unmarshalling json to type.")
 		}
@@ -157,62 +317,70 @@ func (s *SampleService) GetCache(ch chan *GetSampleServiceResponse, model *GetSa
 		SampleName: "Cached new response here!",
 	}
 
-	s.cachr.Set("dummy_cache_key", response, 30*time.Second)
+	err = s.cachr.Set(ctx, "dummy_cache_key", response, 30*time.Second)
+	if err != nil {
+		ch <- &GetSampleServiceResponse{Error: err}
+		return
+	}
+
 	ch <- &response
 }
 
 // PublishPubSubMessage
-// Publishes a pub sub sample message to topic.
-func (s *SampleService) PublishPubSubMessage(ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel) {
+// Adds sample messages to the outbox in a single transaction, which are published to topic by the outbox relay.
+// Messages of a sample share an ordering key, so they are published in the order they are added.
+func (s *SampleService) PublishPubSubMessage(ctx context.Context, ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &PublishPubSubMessageServiceResponse{Error: modelErr}
 		return
 	}
 
-	publisherCh := make(chan publisher.PublisherResponse, model.Count)
-	defer close(publisherCh)
-	for i := 0; i < model.Count; i++ {
-		go s.samplePublisher.Publish(publisherCh, &model.Message, map[string]string{publisher.DummyAttribute: "overriding dummy attribute value here"})
+	payload, err := json.Marshal(model.Message)
+	if err != nil {
+		ch <- &PublishPubSubMessageServiceResponse{Error: err}
+		return
 	}
 
-	// Await all responses from routines simultaneously.
-	var messageIds []string
-	for i := 0; i < model.Count; i++ {
-		response := <-publisherCh
-		if response.Error != nil {
-			ch <- &PublishPubSubMessageServiceResponse{Error: response.Error}
-			return
+	var outboxIds []int64
+	err = s.unitOfWork.Run(ctx, func(tx database.ITransaction) error {
+		// Ids are collected again if the transaction is retried.
+		outboxIds = nil
+		for i := 0; i < model.Count; i++ {
+			chAddMessageResponse := make(chan *outbox.AddMessageResponse)
+			go s.outboxDb.AddMessage(ctx, chAddMessageResponse, &outbox.AddMessageModel{
+				Topic:       publisher.SampleTopic,
+				OrderingKey: "sample:" + strconv.Itoa(model.Message.SampleId),
+				Payload:     payload,
+				Attributes:  map[string]string{publisher.DummyAttribute: "overriding dummy attribute value here"},
+				Transaction: tx,
+			})
+
+			addMessageResponse := <-chAddMessageResponse
+			close(chAddMessageResponse)
+			if addMessageResponse.Error != nil {
+				return addMessageResponse.Error
+			}
+
+			outboxIds = append(outboxIds, addMessageResponse.Id)
 		}
-		messageIds = append(messageIds, *response.MessageId)
+
+		return nil
+	})
+	if err != nil {
+		ch <- &PublishPubSubMessageServiceResponse{Error: err}
+		return
 	}
 
 	ch <- &PublishPubSubMessageServiceResponse{
-		MessageIds:   messageIds,
+		OutboxIds:    outboxIds,
 		IsSuccessful: true,
 	}
 }
 
-// UpdateSample
-// Updates a sample with details provided by google pub sub message or via http endpoint.
-func (s *SampleService) UpdateSample(ch chan *UpdateSampleServiceResponse, model *UpdateSampleServiceModel) {
-	modelErr := s.validatr.ValidateStruct(model)
-	if modelErr != nil {
-		ch <- &UpdateSampleServiceResponse{Error: modelErr}
-		return
-	}
-
-	// Doing some dummy stuff.
-	// Launching rocket to the sky..
-	// Calculating...
-
-	response := UpdateSampleServiceResponse{IsSuccessful: true}
-	ch <- &response
-}
-
 // PostSampleXml
 // Post sample xml
-func (s *SampleService) PostSampleXml(ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel) {
+func (s *SampleService) PostSampleXml(ctx context.Context, ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel) {
 	modelErr := s.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		ch <- &PostSampleXmlServiceResponse{Error: modelErr}
@@ -222,7 +390,7 @@ func (s *SampleService) PostSampleXml(ch chan *PostSampleXmlServiceResponse, mod
 	getSampleCh := make(chan *sample_proxy.PostSampleXmlProxyResponse)
 	defer close(getSampleCh)
 
-	go s.sampleXmlProxy.PostSampleXml(getSampleCh, &sample_proxy.PostSampleXmlProxyModel{
+	go s.sampleXmlProxy.PostSampleXml(ctx, getSampleCh, &sample_proxy.PostSampleXmlProxyModel{
 		SampleName: model.SampleName,
 		SampleType: model.SampleType,
 		SampleCode: model.SampleCode,
@@ -236,3 +404,59 @@ func (s *SampleService) PostSampleXml(ch chan *PostSampleXmlServiceResponse, mod
 
 	ch <- &PostSampleXmlServiceResponse{}
 }
+
+// changeSample
+// Runs change of a sample and adds its event to the outbox in a single transaction, so the event is only
+// published if the change is committed. Change is run again if the transaction is retried.
+func (s *SampleService) changeSample(ctx context.Context, eventType string, change func(tx database.ITransaction) *sample.SampleDbResponse) *SampleServiceResponse {
+	var changeResponse *sample.SampleDbResponse
+	err := s.unitOfWork.Run(ctx, func(tx database.ITransaction) error {
+		changeResponse = change(tx)
+		if changeResponse.Error != nil {
+			return changeResponse.Error
+		}
+
+		return s.addSampleEvent(ctx, tx, eventType, &changeResponse.Sample)
+	})
+	if err != nil {
+		return sampleServiceResponse(&sample.SampleDbResponse{Error: err})
+	}
+
+	return sampleServiceResponse(changeResponse)
+}
+
+// addSampleEvent
+// Adds event of a sample to the outbox in transaction of the change. Events of a sample share an ordering key,
+// so they are published in the order of changes.
+func (s *SampleService) addSampleEvent(ctx context.Context, tx database.ITransaction, eventType string, sampleModel *sample.Sample) error {
+	payload, err := json.Marshal(sampleModel)
+	if err != nil {
+		return err
+	}
+
+	chAddMessageResponse := make(chan *outbox.AddMessageResponse)
+	defer close(chAddMessageResponse)
+
+	go s.outboxDb.AddMessage(ctx, chAddMessageResponse, &outbox.AddMessageModel{
+		Topic:       publisher.SampleTopic,
+		OrderingKey: "sample:" + strconv.FormatInt(sampleModel.Id, 10),
+		Payload:     payload,
+		Attributes:  map[string]string{publisher.EventType: eventType},
+		Transaction: tx,
+	})
+
+	return (<-chAddMessageResponse).Error
+}
+
+// sampleServiceResponse
+// Maps a sample response of db to service response with not found error.
+func sampleServiceResponse(response *sample.SampleDbResponse) *SampleServiceResponse {
+	switch {
+	case errors.Is(response.Error, sql.ErrNoRows):
+		return &SampleServiceResponse{Error: errSampleNotFound}
+	case response.Error != nil:
+		return &SampleServiceResponse{Error: response.Error}
+	default:
+		return &SampleServiceResponse{Sample: response.Sample}
+	}
+}
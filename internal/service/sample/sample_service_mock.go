@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal\service\sample\sample_service.go
+// Source: ../internal/service/sample/sample_service.go
 
 // Package sample is a generated GoMock package.
 package sample
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,74 +34,122 @@ func (m *MockISampleService) EXPECT() *MockISampleServiceMockRecorder {
 	return m.recorder
 }
 
-// GetCache mocks base method.
-func (m *MockISampleService) GetCache(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+// AddSample mocks base method.
+func (m *MockISampleService) AddSample(ctx context.Context, ch chan *SampleServiceResponse, model *AddSampleServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetCache", ch, model)
+	m.ctrl.Call(m, "AddSample", ctx, ch, model)
 }
 
-// GetCache indicates an expected call of GetCache.
-func (mr *MockISampleServiceMockRecorder) GetCache(ch, model interface{}) *gomock.Call {
+// AddSample indicates an expected call of AddSample.
+func (mr *MockISampleServiceMockRecorder) AddSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSample", reflect.TypeOf((*MockISampleService)(nil).AddSample), ctx, ch, model)
+}
+
+// DeleteSample mocks base method.
+func (m *MockISampleService) DeleteSample(ctx context.Context, ch chan *DeleteSampleServiceResponse, model *DeleteSampleServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteSample", ctx, ch, model)
+}
+
+// DeleteSample indicates an expected call of DeleteSample.
+func (mr *MockISampleServiceMockRecorder) DeleteSample(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCache", reflect.TypeOf((*MockISampleService)(nil).GetCache), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSample", reflect.TypeOf((*MockISampleService)(nil).DeleteSample), ctx, ch, model)
 }
 
-// GetDatabase mocks base method.
-func (m *MockISampleService) GetDatabase(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+// GetCache mocks base method.
+func (m *MockISampleService) GetCache(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetDatabase", ch, model)
+	m.ctrl.Call(m, "GetCache", ctx, ch, model)
 }
 
-// GetDatabase indicates an expected call of GetDatabase.
-func (mr *MockISampleServiceMockRecorder) GetDatabase(ch, model interface{}) *gomock.Call {
+// GetCache indicates an expected call of GetCache.
+func (mr *MockISampleServiceMockRecorder) GetCache(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabase", reflect.TypeOf((*MockISampleService)(nil).GetDatabase), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCache", reflect.TypeOf((*MockISampleService)(nil).GetCache), ctx, ch, model)
 }
 
 // GetGoogle mocks base method.
-func (m *MockISampleService) GetGoogle(ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
+func (m *MockISampleService) GetGoogle(ctx context.Context, ch chan *GetSampleServiceResponse, model *GetSampleServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetGoogle", ch, model)
+	m.ctrl.Call(m, "GetGoogle", ctx, ch, model)
 }
 
 // GetGoogle indicates an expected call of GetGoogle.
-func (mr *MockISampleServiceMockRecorder) GetGoogle(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleServiceMockRecorder) GetGoogle(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoogle", reflect.TypeOf((*MockISampleService)(nil).GetGoogle), ctx, ch, model)
+}
+
+// GetSample mocks base method.
+func (m *MockISampleService) GetSample(ctx context.Context, ch chan *SampleServiceResponse, model *GetSampleByIdServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSample", ctx, ch, model)
+}
+
+// GetSample indicates an expected call of GetSample.
+func (mr *MockISampleServiceMockRecorder) GetSample(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSample", reflect.TypeOf((*MockISampleService)(nil).GetSample), ctx, ch, model)
+}
+
+// GetSamples mocks base method.
+func (m *MockISampleService) GetSamples(ctx context.Context, ch chan *GetSamplesServiceResponse, model *GetSamplesServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetSamples", ctx, ch, model)
+}
+
+// GetSamples indicates an expected call of GetSamples.
+func (mr *MockISampleServiceMockRecorder) GetSamples(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoogle", reflect.TypeOf((*MockISampleService)(nil).GetGoogle), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSamples", reflect.TypeOf((*MockISampleService)(nil).GetSamples), ctx, ch, model)
 }
 
 // PostSampleXml mocks base method.
-func (m *MockISampleService) PostSampleXml(ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel) {
+func (m *MockISampleService) PostSampleXml(ctx context.Context, ch chan *PostSampleXmlServiceResponse, model *PostSampleXmlServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "PostSampleXml", ch, model)
+	m.ctrl.Call(m, "PostSampleXml", ctx, ch, model)
 }
 
 // PostSampleXml indicates an expected call of PostSampleXml.
-func (mr *MockISampleServiceMockRecorder) PostSampleXml(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleServiceMockRecorder) PostSampleXml(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSampleXml", reflect.TypeOf((*MockISampleService)(nil).PostSampleXml), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSampleXml", reflect.TypeOf((*MockISampleService)(nil).PostSampleXml), ctx, ch, model)
 }
 
 // PublishPubSubMessage mocks base method.
-func (m *MockISampleService) PublishPubSubMessage(ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel) {
+func (m *MockISampleService) PublishPubSubMessage(ctx context.Context, ch chan *PublishPubSubMessageServiceResponse, model *PublishPubSubMessageServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "PublishPubSubMessage", ch, model)
+	m.ctrl.Call(m, "PublishPubSubMessage", ctx, ch, model)
 }
 
 // PublishPubSubMessage indicates an expected call of PublishPubSubMessage.
-func (mr *MockISampleServiceMockRecorder) PublishPubSubMessage(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleServiceMockRecorder) PublishPubSubMessage(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPubSubMessage", reflect.TypeOf((*MockISampleService)(nil).PublishPubSubMessage), ctx, ch, model)
+}
+
+// RestoreSample mocks base method.
+func (m *MockISampleService) RestoreSample(ctx context.Context, ch chan *SampleServiceResponse, model *RestoreSampleServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreSample", ctx, ch, model)
+}
+
+// RestoreSample indicates an expected call of RestoreSample.
+func (mr *MockISampleServiceMockRecorder) RestoreSample(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPubSubMessage", reflect.TypeOf((*MockISampleService)(nil).PublishPubSubMessage), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSample", reflect.TypeOf((*MockISampleService)(nil).RestoreSample), ctx, ch, model)
 }
 
 // UpdateSample mocks base method.
-func (m *MockISampleService) UpdateSample(ch chan *UpdateSampleServiceResponse, model *UpdateSampleServiceModel) {
+func (m *MockISampleService) UpdateSample(ctx context.Context, ch chan *SampleServiceResponse, model *UpdateSampleServiceModel) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "UpdateSample", ch, model)
+	m.ctrl.Call(m, "UpdateSample", ctx, ch, model)
 }
 
 // UpdateSample indicates an expected call of UpdateSample.
-func (mr *MockISampleServiceMockRecorder) UpdateSample(ch, model interface{}) *gomock.Call {
+func (mr *MockISampleServiceMockRecorder) UpdateSample(ctx, ch, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSample", reflect.TypeOf((*MockISampleService)(nil).UpdateSample), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSample", reflect.TypeOf((*MockISampleService)(nil).UpdateSample), ctx, ch, model)
 }
@@ -1,12 +1,20 @@
 package sample
 
 import (
+	"context"
+	"database/sql"
+	"go-clean-architecture/internal/data/database"
+	"go-clean-architecture/internal/data/database/outbox"
 	sampleDb "go-clean-architecture/internal/data/database/sample"
 	sampleProxy "go-clean-architecture/internal/data/proxy/sample"
+	"go-clean-architecture/internal/data/pubsub/publisher"
 	samplePubSubPublisher "go-clean-architecture/internal/data/pubsub/publisher/sample"
 	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
 	"go-clean-architecture/internal/util/env"
 	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
+	"go-clean-architecture/internal/util/requestcontext"
 	"go-clean-architecture/internal/util/validator"
 
 	"errors"
@@ -18,15 +26,16 @@ import (
 
 type SampleServiceTestSuite struct {
 	suite.Suite
-	sampleService             ISampleService
-	mockEnvironment           *env.MockIEnvironment
-	mockLogger                *logger.MockILogger
-	mockValidator             *validator.MockIValidator
-	mockCacher                *cacher.MockICacher
-	mockSampleProxy           *sampleProxy.MockISampleProxy
-	mockSampleDb              *sampleDb.MockISampleDb
-	mockSamplePubSubPublisher *samplePubSubPublisher.MockISamplePublisher
-	mockSampleXmlProxy        *sampleProxy.MockISampleXmlProxy
+	sampleService      ISampleService
+	mockEnvironment    *env.MockIEnvironment
+	mockLogger         *logger.MockILogger
+	mockValidator      *validator.MockIValidator
+	mockCacher         *cacher.MockICacher
+	mockSampleProxy    *sampleProxy.MockISampleProxy
+	mockSampleDb       *sampleDb.MockISampleDb
+	mockOutboxDb       *outbox.MockIOutboxDb
+	mockSampleXmlProxy *sampleProxy.MockISampleXmlProxy
+	fakeUnitOfWork     *database.FakeUnitOfWork
 }
 
 // Run suite.
@@ -47,10 +56,11 @@ func (s *SampleServiceTestSuite) SetupTest() {
 	s.mockCacher = cacher.NewMockICacher(ctrl)
 	s.mockSampleProxy = sampleProxy.NewMockISampleProxy(ctrl)
 	s.mockSampleDb = sampleDb.NewMockISampleDb(ctrl)
-	s.mockSamplePubSubPublisher = samplePubSubPublisher.NewMockISamplePublisher(ctrl)
+	s.mockOutboxDb = outbox.NewMockIOutboxDb(ctrl)
 	s.mockSampleXmlProxy = sampleProxy.NewMockISampleXmlProxy(ctrl)
+	s.fakeUnitOfWork = database.NewFakeUnitOfWork()
 
-	s.sampleService = NewSampleService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockSampleProxy, s.mockSampleDb, s.mockSamplePubSubPublisher, s.mockSampleXmlProxy)
+	s.sampleService = NewSampleService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockSampleProxy, s.mockSampleDb, s.mockOutboxDb, s.mockSampleXmlProxy, s.fakeUnitOfWork)
 }
 
 // Runs after each test in the suite.
@@ -71,7 +81,7 @@ func (s *SampleServiceTestSuite) TestGetGoogle_ModelHasValidationError_ReturnsEr
 	// When
 	ch := make(chan *GetSampleServiceResponse)
 	defer close(ch)
-	go s.sampleService.GetGoogle(ch, &GetSampleServiceModel{
+	go s.sampleService.GetGoogle(context.Background(), ch, &GetSampleServiceModel{
 		Id:         id,
 		SampleName: sampleName,
 	})
@@ -93,8 +103,8 @@ func (s *SampleServiceTestSuite) TestGetGoogle_ProxyReturnedError_ReturnsError()
 
 	s.mockSampleProxy.
 		EXPECT().
-		GetGoogle(gomock.Any(), gomock.Eq(&sampleProxy.GetSampleProxyModel{Id: id, SampleName: sampleName})).
-		DoAndReturn(func(ch chan *sampleProxy.GetSampleProxyResponse, model *sampleProxy.GetSampleProxyModel) {
+		GetGoogle(gomock.Any(), gomock.Any(), gomock.Eq(&sampleProxy.GetSampleProxyModel{Id: id, SampleName: sampleName})).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleProxy.GetSampleProxyResponse, model *sampleProxy.GetSampleProxyModel) {
 			ch <- &sampleProxy.GetSampleProxyResponse{Error: errors.New("proxy has returned error")}
 			return
 		})
@@ -102,7 +112,7 @@ func (s *SampleServiceTestSuite) TestGetGoogle_ProxyReturnedError_ReturnsError()
 	// When
 	ch := make(chan *GetSampleServiceResponse)
 	defer close(ch)
-	go s.sampleService.GetGoogle(ch, &GetSampleServiceModel{
+	go s.sampleService.GetGoogle(context.Background(), ch, &GetSampleServiceModel{
 		Id:         id,
 		SampleName: sampleName,
 	})
@@ -123,8 +133,8 @@ func (s *SampleServiceTestSuite) TestGetGoogle_HappyPath_Success() {
 		Return(nil)
 	s.mockSampleProxy.
 		EXPECT().
-		GetGoogle(gomock.Any(), gomock.Eq(&sampleProxy.GetSampleProxyModel{Id: id, SampleName: sampleName})).
-		DoAndReturn(func(ch chan *sampleProxy.GetSampleProxyResponse, model *sampleProxy.GetSampleProxyModel) {
+		GetGoogle(gomock.Any(), gomock.Any(), gomock.Eq(&sampleProxy.GetSampleProxyModel{Id: id, SampleName: sampleName})).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleProxy.GetSampleProxyResponse, model *sampleProxy.GetSampleProxyModel) {
 			ch <- &sampleProxy.GetSampleProxyResponse{Id: id, SampleName: sampleName}
 			return
 		})
@@ -132,7 +142,7 @@ func (s *SampleServiceTestSuite) TestGetGoogle_HappyPath_Success() {
 	// When
 	ch := make(chan *GetSampleServiceResponse)
 	defer close(ch)
-	go s.sampleService.GetGoogle(ch, &GetSampleServiceModel{
+	go s.sampleService.GetGoogle(context.Background(), ch, &GetSampleServiceModel{
 		Id:         id,
 		SampleName: sampleName,
 	})
@@ -142,3 +152,386 @@ func (s *SampleServiceTestSuite) TestGetGoogle_HappyPath_Success() {
 	s.Equal(id, response.Id)
 	s.Equal(sampleName, response.SampleName)
 }
+
+func (s *SampleServiceTestSuite) TestGetGoogle_RequestContext_IsPassedToProxy() {
+	// Given
+	id := 99
+	sampleName := "test_get_google_service"
+	ctx := requestcontext.WithRequestId(context.Background(), "request-1")
+
+	s.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Any()).
+		Return(nil)
+	s.mockSampleProxy.
+		EXPECT().
+		GetGoogle(gomock.Eq(ctx), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleProxy.GetSampleProxyResponse, model *sampleProxy.GetSampleProxyModel) {
+			ch <- &sampleProxy.GetSampleProxyResponse{Id: id, SampleName: sampleName}
+		})
+
+	// When
+	ch := make(chan *GetSampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.GetGoogle(ctx, ch, &GetSampleServiceModel{
+		Id:         id,
+		SampleName: sampleName,
+	})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+}
+
+func (s *SampleServiceTestSuite) TestGetSamples_HappyPath_ReturnsPage() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		GetSamples(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.GetSamplesDbResponse, model *sampleDb.GetSamplesDbModel) {
+			s.Equal(10, model.Query.PageSize)
+			s.Equal(2, model.Query.PageIndex)
+			s.Equal([]query.Sort{{Field: "sampleStatus", Descending: true}, {Field: "id"}}, model.Query.Sorts)
+			ch <- &sampleDb.GetSamplesDbResponse{Samples: []sampleDb.Sample{{Id: 21}}, Page: query.Page{TotalCount: 21, PageSize: 10}}
+		})
+
+	// When
+	ch := make(chan *GetSamplesServiceResponse)
+	defer close(ch)
+	go s.sampleService.GetSamples(context.Background(), ch, &GetSamplesServiceModel{
+		Query: query.Model{PageSize: 10, PageIndex: 2, Filter: []string{"sampleStatus:gte:1"}, Sort: "-sampleStatus"},
+	})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Len(response.Samples, 1)
+	s.Equal(int64(21), response.Page.TotalCount)
+}
+
+func (s *SampleServiceTestSuite) TestGetSamples_FieldIsNotWhitelisted_ReturnsError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	// When
+	ch := make(chan *GetSamplesServiceResponse)
+	defer close(ch)
+	go s.sampleService.GetSamples(context.Background(), ch, &GetSamplesServiceModel{
+		Query: query.Model{Sort: "sampleCode"},
+	})
+	response := <-ch
+
+	// Then
+	s.Error(response.Error)
+}
+
+func (s *SampleServiceTestSuite) TestGetSamples_IncludeDeleted_IsPassedToQuery() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		GetSamples(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.GetSamplesDbResponse, model *sampleDb.GetSamplesDbModel) {
+			s.True(model.Query.IncludeDeleted)
+			ch <- &sampleDb.GetSamplesDbResponse{Samples: []sampleDb.Sample{}}
+		})
+
+	// When
+	ch := make(chan *GetSamplesServiceResponse)
+	defer close(ch)
+	go s.sampleService.GetSamples(context.Background(), ch, &GetSamplesServiceModel{IncludeDeleted: true})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+}
+
+func (s *SampleServiceTestSuite) TestGetSample_SampleNotFound_ReturnsNotFoundError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		GetSample(gomock.Any(), gomock.Any(), gomock.Eq(&sampleDb.GetSampleDbModel{Id: 7})).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.GetSampleDbModel) {
+			ch <- &sampleDb.SampleDbResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.GetSample(context.Background(), ch, &GetSampleByIdServiceModel{SampleId: 7})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrNotFound)
+}
+
+func (s *SampleServiceTestSuite) TestAddSample_HappyPath_ReturnsAddedSample() {
+	// Given
+	code := 3
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		AddSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.AddSampleDbModel) {
+			s.Equal("name", model.SampleName)
+			s.Equal("type", model.SampleType)
+			s.Equal(&code, model.SampleCode)
+			ch <- &sampleDb.SampleDbResponse{Sample: sampleDb.Sample{Id: 1, SampleName: model.SampleName, SampleType: model.SampleType, SampleCode: model.SampleCode}}
+		})
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			ch <- &outbox.AddMessageResponse{Id: 1}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.AddSample(context.Background(), ch, &AddSampleServiceModel{SampleName: "name", SampleType: "type", SampleCode: &code})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(1), response.Sample.Id)
+}
+
+func (s *SampleServiceTestSuite) TestUpdateSample_HappyPath_PersistsStatusAndModifiedBy() {
+	// Given
+	modifiedBy := "receiver"
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		UpdateSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.UpdateSampleDbModel) {
+			s.Equal(int64(5), model.Id)
+			s.Equal(2, model.SampleStatus)
+			s.Equal(modifiedBy, model.ModifiedBy)
+			ch <- &sampleDb.SampleDbResponse{Sample: sampleDb.Sample{Id: model.Id, SampleStatus: model.SampleStatus, ModifiedBy: &modifiedBy}}
+		})
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			ch <- &outbox.AddMessageResponse{Id: 1}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.UpdateSample(context.Background(), ch, &UpdateSampleServiceModel{SampleId: 5, SampleStatus: 2, ModifiedBy: modifiedBy})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(2, response.Sample.SampleStatus)
+	s.Equal(modifiedBy, *response.Sample.ModifiedBy)
+}
+
+func (s *SampleServiceTestSuite) TestUpdateSample_SampleNotFound_ReturnsNotFoundError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		UpdateSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.UpdateSampleDbModel) {
+			ch <- &sampleDb.SampleDbResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.UpdateSample(context.Background(), ch, &UpdateSampleServiceModel{SampleId: 5, SampleStatus: 2, ModifiedBy: "receiver"})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrNotFound)
+}
+
+func (s *SampleServiceTestSuite) TestDeleteSample_SampleNotFound_ReturnsNotFoundError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		DeleteSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.DeleteSampleDbModel) {
+			s.Equal(int64(5), model.Id)
+			ch <- &sampleDb.SampleDbResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *DeleteSampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.DeleteSample(context.Background(), ch, &DeleteSampleServiceModel{SampleId: 5})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrNotFound)
+	s.False(response.IsDeleted)
+}
+
+func (s *SampleServiceTestSuite) TestRestoreSample_SampleIsNotDeleted_ReturnsNotFoundError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		RestoreSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.RestoreSampleDbModel) {
+			s.Equal(int64(5), model.Id)
+			ch <- &sampleDb.SampleDbResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.RestoreSample(context.Background(), ch, &RestoreSampleServiceModel{SampleId: 5})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrNotFound)
+}
+
+func (s *SampleServiceTestSuite) TestPublishPubSubMessage_HappyPath_AddsMessagesToOutboxInOneTransaction() {
+	// Given
+	message := samplePubSubPublisher.SamplePublisherModel{SampleId: 5, SampleStatus: 2, ModifiedBy: "publisher"}
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	var nextId int64
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			s.Equal(publisher.SampleTopic, model.Topic)
+			s.Equal("sample:5", model.OrderingKey)
+			s.JSONEq(`{"SampleId":5,"SampleStatus":2,"ModifiedBy":"publisher"}`, string(model.Payload))
+			s.NotNil(model.Transaction)
+			nextId++
+			ch <- &outbox.AddMessageResponse{Id: nextId}
+		})
+
+	// When
+	ch := make(chan *PublishPubSubMessageServiceResponse)
+	defer close(ch)
+	go s.sampleService.PublishPubSubMessage(context.Background(), ch, &PublishPubSubMessageServiceModel{Count: 2, Message: message})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsSuccessful)
+	s.Equal([]int64{1, 2}, response.OutboxIds)
+	s.Equal(1, s.fakeUnitOfWork.Commits)
+}
+
+func (s *SampleServiceTestSuite) TestPublishPubSubMessage_AddMessageFailed_RollsBackAndReturnsError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			ch <- &outbox.AddMessageResponse{Error: errors.New("db error")}
+		})
+
+	// When
+	ch := make(chan *PublishPubSubMessageServiceResponse)
+	defer close(ch)
+	go s.sampleService.PublishPubSubMessage(context.Background(), ch, &PublishPubSubMessageServiceModel{
+		Count:   3,
+		Message: samplePubSubPublisher.SamplePublisherModel{SampleId: 5, SampleStatus: 2, ModifiedBy: "publisher"},
+	})
+	response := <-ch
+
+	// Then
+	s.Error(response.Error)
+	s.False(response.IsSuccessful)
+	s.Equal(0, s.fakeUnitOfWork.Commits)
+	s.Equal(1, s.fakeUnitOfWork.Rollbacks)
+}
+
+func (s *SampleServiceTestSuite) TestUpdateSample_VersionIsChanged_ReturnsPreconditionFailedError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		UpdateSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.UpdateSampleDbModel) {
+			s.Equal(3, model.Version)
+			ch <- &sampleDb.SampleDbResponse{Error: database.ErrVersionMismatch}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.UpdateSample(context.Background(), ch, &UpdateSampleServiceModel{SampleId: 5, SampleStatus: 2, ModifiedBy: "receiver", Version: 3})
+	response := <-ch
+
+	// Then
+	s.ErrorIs(response.Error, customerror.ErrPreconditionFailed)
+}
+
+func (s *SampleServiceTestSuite) TestDeleteSample_HappyPath_DeletesAndAddsEventInOneTransaction() {
+	// Given
+	var changeTx database.ITransaction
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		DeleteSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.DeleteSampleDbModel) {
+			changeTx = model.Transaction
+			ch <- &sampleDb.SampleDbResponse{Sample: sampleDb.Sample{Id: model.Id}}
+		})
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			s.Equal(publisher.SampleTopic, model.Topic)
+			s.Equal("sample:5", model.OrderingKey)
+			s.Equal(SampleEventDeleted, model.Attributes[publisher.EventType])
+			s.NotNil(model.Transaction)
+			s.Same(changeTx, model.Transaction)
+			ch <- &outbox.AddMessageResponse{Id: 1}
+		})
+
+	// When
+	ch := make(chan *DeleteSampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.DeleteSample(context.Background(), ch, &DeleteSampleServiceModel{SampleId: 5})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsDeleted)
+	s.Equal(1, s.fakeUnitOfWork.Commits)
+}
+
+func (s *SampleServiceTestSuite) TestUpdateSample_AddEventFailed_RollsBackAndReturnsError() {
+	// Given
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockSampleDb.
+		EXPECT().
+		UpdateSample(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *sampleDb.SampleDbResponse, model *sampleDb.UpdateSampleDbModel) {
+			s.NotNil(model.Transaction)
+			ch <- &sampleDb.SampleDbResponse{Sample: sampleDb.Sample{Id: model.Id, SampleStatus: model.SampleStatus}}
+		})
+	s.mockOutboxDb.
+		EXPECT().
+		AddMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *outbox.AddMessageResponse, model *outbox.AddMessageModel) {
+			ch <- &outbox.AddMessageResponse{Error: errors.New("db error")}
+		})
+
+	// When
+	ch := make(chan *SampleServiceResponse)
+	defer close(ch)
+	go s.sampleService.UpdateSample(context.Background(), ch, &UpdateSampleServiceModel{SampleId: 5, SampleStatus: 2, ModifiedBy: "receiver"})
+	response := <-ch
+
+	// Then
+	s.Error(response.Error)
+	s.Equal(0, s.fakeUnitOfWork.Commits)
+	s.Equal(1, s.fakeUnitOfWork.Rollbacks)
+}
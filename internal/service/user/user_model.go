@@ -0,0 +1,60 @@
+package user
+
+import "go-clean-architecture/internal/util/query"
+
+type GetUsersServiceModel struct {
+	Query          query.Model
+	IncludeDeleted bool
+}
+
+type GetUserServiceModel struct {
+	UserId int64 `validate:"required"`
+}
+
+type AddUserServiceModel struct {
+	UserName       string `validate:"required,min=3,max=30,alphanum"`
+	Email          string `validate:"omitempty,email,max=100"`
+	Password       string `validate:"omitempty,min=8,max=128"`
+	IsActive       bool
+	IsProgrammatic bool
+	ActorUserId    int64 // 0 for users created by the system, e.g. from command line
+}
+
+type UpdateUserServiceModel struct {
+	UserId      int64  `validate:"required"`
+	UserName    string `validate:"required,min=3,max=30,alphanum"`
+	Email       string `validate:"omitempty,email,max=100"`
+	ActorUserId int64  `validate:"required"`
+}
+
+type SetUserActiveServiceModel struct {
+	UserId      int64 `validate:"required"`
+	IsActive    bool
+	ActorUserId int64 `validate:"required"`
+}
+
+type SetUserProgrammaticServiceModel struct {
+	UserId         int64 `validate:"required"`
+	IsProgrammatic bool
+	ActorUserId    int64 `validate:"required"`
+}
+
+type ForcePasswordResetServiceModel struct {
+	UserId      int64 `validate:"required"`
+	ActorUserId int64 `validate:"required"`
+}
+
+type DeleteUserServiceModel struct {
+	UserId      int64 `validate:"required"`
+	ActorUserId int64 `validate:"required"`
+}
+
+type RestoreUserServiceModel struct {
+	UserId      int64 `validate:"required"`
+	ActorUserId int64 `validate:"required"`
+}
+
+type GetUserAuditEventsServiceModel struct {
+	UserId int64 `validate:"required"`
+	Limit  int   `validate:"required,gte=1,lte=500"`
+}
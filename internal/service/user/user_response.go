@@ -0,0 +1,27 @@
+package user
+
+import (
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/util/query"
+)
+
+type GetUsersServiceResponse struct {
+	Error error `json:"-"`
+	Users []auth.User
+	Page  query.Page
+}
+
+type UserServiceResponse struct {
+	Error error `json:"-"`
+	User  auth.User
+}
+
+type DeleteUserServiceResponse struct {
+	Error     error `json:"-"`
+	IsDeleted bool
+}
+
+type GetUserAuditEventsServiceResponse struct {
+	Error  error `json:"-"`
+	Events []auth.UserAuditEvent
+}
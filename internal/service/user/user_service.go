@@ -0,0 +1,465 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/data/notification"
+	"go-clean-architecture/internal/service/revocation"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/helper"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var errUserNotFound = customerror.New(fmt.Errorf("user is %w", customerror.ErrNotFound), customerror.LogLevelInfo)
+var errUserExists = customerror.New(fmt.Errorf("%w: username or email is taken", customerror.ErrConflict), customerror.LogLevelInfo)
+
+type IUserService interface {
+	GetUsers(ctx context.Context, ch chan *GetUsersServiceResponse, model *GetUsersServiceModel)
+	GetUser(ctx context.Context, ch chan *UserServiceResponse, model *GetUserServiceModel)
+	AddUser(ctx context.Context, ch chan *UserServiceResponse, model *AddUserServiceModel)
+	UpdateUser(ctx context.Context, ch chan *UserServiceResponse, model *UpdateUserServiceModel)
+	SetUserActive(ctx context.Context, ch chan *UserServiceResponse, model *SetUserActiveServiceModel)
+	SetUserProgrammatic(ctx context.Context, ch chan *UserServiceResponse, model *SetUserProgrammaticServiceModel)
+	ForcePasswordReset(ctx context.Context, ch chan *UserServiceResponse, model *ForcePasswordResetServiceModel)
+	DeleteUser(ctx context.Context, ch chan *DeleteUserServiceResponse, model *DeleteUserServiceModel)
+	RestoreUser(ctx context.Context, ch chan *UserServiceResponse, model *RestoreUserServiceModel)
+	GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsServiceResponse, model *GetUserAuditEventsServiceModel)
+}
+
+type UserService struct {
+	environment        env.IEnvironment
+	loggr              logger.ILogger
+	validatr           validator.IValidator
+	cachr              cacher.ICacher
+	authDb             auth.IAuthDb
+	passwordHasher     hasher.IPasswordHasher
+	notificationSender notification.INotificationSender
+	revocationService  revocation.IRevocationService
+}
+
+// NewUserService
+// Returns a new UserService.
+func NewUserService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	authDb auth.IAuthDb,
+	passwordHasher hasher.IPasswordHasher,
+	notificationSender notification.INotificationSender,
+	revocationService revocation.IRevocationService,
+) IUserService {
+	service := UserService{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		cachr:       cachr,
+	}
+
+	if authDb != nil {
+		service.authDb = authDb
+	} else {
+		service.authDb = auth.NewAuthDb(environment, loggr, validatr, cachr, nil)
+	}
+
+	if passwordHasher != nil {
+		service.passwordHasher = passwordHasher
+	} else {
+		service.passwordHasher = hasher.New(environment)
+	}
+
+	if notificationSender != nil {
+		service.notificationSender = notificationSender
+	} else {
+		service.notificationSender = notification.NewLogNotificationSender(environment, loggr, validatr, cachr)
+	}
+
+	if revocationService != nil {
+		service.revocationService = revocationService
+	} else {
+		service.revocationService = revocation.NewRevocationService(environment, loggr, validatr, cachr, service.authDb)
+	}
+
+	return &service
+}
+
+// GetUsers
+// Gets a page of users filtered and sorted by the fields of auth.UserQuerySchema. Deleted users are only included
+// if requested.
+func (s *UserService) GetUsers(ctx context.Context, ch chan *GetUsersServiceResponse, model *GetUsersServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUsersServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	userQuery, err := query.Parse(auth.UserQuerySchema, model.Query)
+	if err != nil {
+		ch <- &GetUsersServiceResponse{Error: customerror.New(err, customerror.LogLevelInfo)}
+		return
+	}
+	userQuery.IncludeDeleted = model.IncludeDeleted
+
+	chGetUsersResponse := make(chan *auth.GetUsersResponse)
+	defer close(chGetUsersResponse)
+
+	go s.authDb.GetUsers(ctx, chGetUsersResponse, &auth.GetUsersModel{Query: userQuery})
+
+	getUsersResponse := <-chGetUsersResponse
+	if getUsersResponse.Error != nil {
+		ch <- &GetUsersServiceResponse{Error: getUsersResponse.Error}
+		return
+	}
+
+	ch <- &GetUsersServiceResponse{
+		Users: getUsersResponse.Users,
+		Page:  getUsersResponse.Page,
+	}
+}
+
+// GetUser
+// Gets a user by id.
+func (s *UserService) GetUser(ctx context.Context, ch chan *UserServiceResponse, model *GetUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chGetUserByIdResponse := make(chan *auth.UserResponse)
+	defer close(chGetUserByIdResponse)
+
+	go s.authDb.GetUserById(ctx, chGetUserByIdResponse, &auth.GetUserByIdModel{UserId: model.UserId})
+
+	ch <- userServiceResponse(<-chGetUserByIdResponse)
+}
+
+// AddUser
+// Adds a user on behalf of an admin. Password is optional, users without one can be sent a password reset link.
+func (s *UserService) AddUser(ctx context.Context, ch chan *UserServiceResponse, model *AddUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	var passwordHash string
+	if model.Password != "" {
+		var err error
+		passwordHash, err = s.passwordHasher.Hash(model.Password)
+		if err != nil {
+			ch <- &UserServiceResponse{Error: err}
+			return
+		}
+	}
+
+	chCreateUserResponse := make(chan *auth.UserResponse)
+	defer close(chCreateUserResponse)
+
+	go s.authDb.CreateUser(
+		ctx, chCreateUserResponse, &auth.CreateUserModel{
+			UserName:       model.UserName,
+			Email:          model.Email,
+			PasswordHash:   passwordHash,
+			IsActive:       model.IsActive,
+			IsProgrammatic: model.IsProgrammatic,
+			ActorUserId:    model.ActorUserId,
+		},
+	)
+
+	ch <- userServiceResponse(<-chCreateUserResponse)
+}
+
+// UpdateUser
+// Updates username and email of a user.
+func (s *UserService) UpdateUser(ctx context.Context, ch chan *UserServiceResponse, model *UpdateUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chUpdateUserResponse := make(chan *auth.UserResponse)
+	defer close(chUpdateUserResponse)
+
+	go s.authDb.UpdateUser(
+		ctx, chUpdateUserResponse, &auth.UpdateUserModel{
+			UserId:      model.UserId,
+			UserName:    model.UserName,
+			Email:       model.Email,
+			ActorUserId: model.ActorUserId,
+		},
+	)
+
+	ch <- userServiceResponse(<-chUpdateUserResponse)
+}
+
+// SetUserActive
+// Activates or deactivates a user. Deactivated users are logged out of every session.
+// Returns an error if admin tries to deactivate itself.
+func (s *UserService) SetUserActive(ctx context.Context, ch chan *UserServiceResponse, model *SetUserActiveServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	if !model.IsActive && model.UserId == model.ActorUserId {
+		ch <- &UserServiceResponse{Error: customerror.New(errors.New("you cannot deactivate your own user"), customerror.LogLevelInfo)}
+		return
+	}
+
+	chSetUserActiveResponse := make(chan *auth.UserResponse)
+	defer close(chSetUserActiveResponse)
+
+	go s.authDb.SetUserActive(
+		ctx, chSetUserActiveResponse, &auth.SetUserActiveModel{
+			UserId:      model.UserId,
+			IsActive:    model.IsActive,
+			ActorUserId: model.ActorUserId,
+		},
+	)
+
+	response := userServiceResponse(<-chSetUserActiveResponse)
+	if response.Error == nil && !model.IsActive {
+		response.Error = s.revokeUser(ctx, model.UserId, model.ActorUserId)
+	}
+
+	ch <- response
+}
+
+// SetUserProgrammatic
+// Marks a user as programmatic, which can only authenticate with api keys and programmatic access tokens, or as interactive.
+func (s *UserService) SetUserProgrammatic(ctx context.Context, ch chan *UserServiceResponse, model *SetUserProgrammaticServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chSetUserProgrammaticResponse := make(chan *auth.UserResponse)
+	defer close(chSetUserProgrammaticResponse)
+
+	go s.authDb.SetUserProgrammatic(
+		ctx, chSetUserProgrammaticResponse, &auth.SetUserProgrammaticModel{
+			UserId:         model.UserId,
+			IsProgrammatic: model.IsProgrammatic,
+			ActorUserId:    model.ActorUserId,
+		},
+	)
+
+	ch <- userServiceResponse(<-chSetUserProgrammaticResponse)
+}
+
+// ForcePasswordReset
+// Logs a user out of every session and emails a password reset link which expires in a day.
+// Returns an error if user has no email or is programmatic.
+func (s *UserService) ForcePasswordReset(ctx context.Context, ch chan *UserServiceResponse, model *ForcePasswordResetServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chGetUserByIdResponse := make(chan *auth.UserResponse)
+	defer close(chGetUserByIdResponse)
+
+	go s.authDb.GetUserById(ctx, chGetUserByIdResponse, &auth.GetUserByIdModel{UserId: model.UserId})
+
+	getUserByIdResponse := userServiceResponse(<-chGetUserByIdResponse)
+	if getUserByIdResponse.Error != nil {
+		ch <- getUserByIdResponse
+		return
+	}
+
+	if getUserByIdResponse.User.Email == "" || getUserByIdResponse.User.IsProgrammatic {
+		ch <- &UserServiceResponse{Error: customerror.New(errors.New("password of a programmatic user or a user without email cannot be reset"), customerror.LogLevelInfo)}
+		return
+	}
+
+	chForcePasswordResetResponse := make(chan *auth.UserResponse)
+	defer close(chForcePasswordResetResponse)
+
+	resetToken := uuid.NewString()
+	go s.authDb.ForcePasswordReset(
+		ctx, chForcePasswordResetResponse, &auth.ForcePasswordResetModel{
+			UserId:      model.UserId,
+			TokenHash:   hasher.HashToken(resetToken),
+			ActorUserId: model.ActorUserId,
+		},
+	)
+
+	response := userServiceResponse(<-chForcePasswordResetResponse)
+	if response.Error != nil {
+		ch <- response
+		return
+	}
+
+	err := s.revokeUser(ctx, model.UserId, model.ActorUserId)
+	if err != nil {
+		ch <- &UserServiceResponse{Error: err}
+		return
+	}
+
+	chSendNotificationResponse := make(chan *notification.SendNotificationResponse)
+	defer close(chSendNotificationResponse)
+
+	go s.notificationSender.Send(
+		ctx, chSendNotificationResponse, &notification.SendNotificationModel{
+			Recipient: response.User.Email,
+			Subject:   "Reset your password",
+			Body:      "An administrator requested you to reset your password. Please use the link below, it expires in a day.\n" + helper.GetTokenLink(s.environment, s.loggr, env.AuthPasswordResetUrl, resetToken),
+		},
+	)
+
+	sendNotificationResponse := <-chSendNotificationResponse
+	if sendNotificationResponse.Error != nil {
+		ch <- &UserServiceResponse{Error: sendNotificationResponse.Error}
+		return
+	}
+
+	ch <- response
+}
+
+// DeleteUser
+// Soft deletes a user and logs it out of every session, it can be restored by an admin.
+// Returns an error if admin tries to delete itself.
+func (s *UserService) DeleteUser(ctx context.Context, ch chan *DeleteUserServiceResponse, model *DeleteUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &DeleteUserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	if model.UserId == model.ActorUserId {
+		ch <- &DeleteUserServiceResponse{Error: customerror.New(errors.New("you cannot delete your own user"), customerror.LogLevelInfo)}
+		return
+	}
+
+	chDeleteUserResponse := make(chan *auth.DeleteUserResponse)
+	defer close(chDeleteUserResponse)
+
+	go s.authDb.DeleteUser(
+		ctx, chDeleteUserResponse, &auth.DeleteUserModel{
+			UserId:      model.UserId,
+			ActorUserId: model.ActorUserId,
+		},
+	)
+
+	deleteUserResponse := <-chDeleteUserResponse
+	if errors.Is(deleteUserResponse.Error, sql.ErrNoRows) {
+		ch <- &DeleteUserServiceResponse{Error: errUserNotFound}
+		return
+	}
+	if deleteUserResponse.Error != nil {
+		ch <- &DeleteUserServiceResponse{Error: deleteUserResponse.Error}
+		return
+	}
+
+	err := s.revokeUser(ctx, model.UserId, model.ActorUserId)
+	if err != nil {
+		ch <- &DeleteUserServiceResponse{Error: err}
+		return
+	}
+
+	ch <- &DeleteUserServiceResponse{IsDeleted: true}
+}
+
+// RestoreUser
+// Restores a deleted user. Its sessions are not restored, it has to log in again.
+func (s *UserService) RestoreUser(ctx context.Context, ch chan *UserServiceResponse, model *RestoreUserServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &UserServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chRestoreUserResponse := make(chan *auth.UserResponse)
+	defer close(chRestoreUserResponse)
+
+	go s.authDb.RestoreUser(
+		ctx, chRestoreUserResponse, &auth.RestoreUserModel{
+			UserId:      model.UserId,
+			ActorUserId: model.ActorUserId,
+		},
+	)
+
+	ch <- userServiceResponse(<-chRestoreUserResponse)
+}
+
+// GetUserAuditEvents
+// Gets changes made to a user by admins, newest first.
+func (s *UserService) GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsServiceResponse, model *GetUserAuditEventsServiceModel) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		ch <- &GetUserAuditEventsServiceResponse{Error: customerror.New(modelErr, customerror.LogLevelInfo)}
+		return
+	}
+
+	chGetUserAuditEventsResponse := make(chan *auth.GetUserAuditEventsResponse)
+	defer close(chGetUserAuditEventsResponse)
+
+	go s.authDb.GetUserAuditEvents(
+		ctx, chGetUserAuditEventsResponse, &auth.GetUserAuditEventsModel{
+			UserId: model.UserId,
+			Limit:  model.Limit,
+		},
+	)
+
+	getUserAuditEventsResponse := <-chGetUserAuditEventsResponse
+	if getUserAuditEventsResponse.Error != nil {
+		ch <- &GetUserAuditEventsServiceResponse{Error: getUserAuditEventsResponse.Error}
+		return
+	}
+
+	ch <- &GetUserAuditEventsServiceResponse{Events: getUserAuditEventsResponse.Events}
+}
+
+// revokeUser
+// Revokes access tokens of the user which are issued until now, so changes take effect immediately.
+func (s *UserService) revokeUser(ctx context.Context, userId int64, actorUserId int64) error {
+	chRevokeUserResponse := make(chan *revocation.RevokeUserServiceResponse)
+	defer close(chRevokeUserResponse)
+
+	go s.revocationService.RevokeUser(
+		ctx, chRevokeUserResponse, &revocation.RevokeUserServiceModel{
+			UserId:    userId,
+			RevokedBy: actorUserId,
+		},
+	)
+
+	revokeUserResponse := <-chRevokeUserResponse
+	if revokeUserResponse.Error != nil {
+		s.loggr.Error("Access tokens of the user could not be revoked.", zap.Int64("userId", userId), zap.Error(revokeUserResponse.Error))
+		return revokeUserResponse.Error
+	}
+
+	return nil
+}
+
+// userServiceResponse
+// Maps a user response of db to service response with not found and taken errors.
+func userServiceResponse(response *auth.UserResponse) *UserServiceResponse {
+	switch {
+	case errors.Is(response.Error, sql.ErrNoRows):
+		return &UserServiceResponse{Error: errUserNotFound}
+	case errors.Is(response.Error, auth.ErrUserExists):
+		return &UserServiceResponse{Error: errUserExists}
+	case response.Error != nil:
+		return &UserServiceResponse{Error: response.Error}
+	default:
+		return &UserServiceResponse{User: response.User}
+	}
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/user/user_service.go
+
+// Package user is a generated GoMock package.
+package user
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIUserService is a mock of IUserService interface.
+type MockIUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserServiceMockRecorder
+}
+
+// MockIUserServiceMockRecorder is the mock recorder for MockIUserService.
+type MockIUserServiceMockRecorder struct {
+	mock *MockIUserService
+}
+
+// NewMockIUserService creates a new mock instance.
+func NewMockIUserService(ctrl *gomock.Controller) *MockIUserService {
+	mock := &MockIUserService{ctrl: ctrl}
+	mock.recorder = &MockIUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserService) EXPECT() *MockIUserServiceMockRecorder {
+	return m.recorder
+}
+
+// AddUser mocks base method.
+func (m *MockIUserService) AddUser(ctx context.Context, ch chan *UserServiceResponse, model *AddUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUser", ctx, ch, model)
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockIUserServiceMockRecorder) AddUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockIUserService)(nil).AddUser), ctx, ch, model)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIUserService) DeleteUser(ctx context.Context, ch chan *DeleteUserServiceResponse, model *DeleteUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", ctx, ch, model)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIUserServiceMockRecorder) DeleteUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserService)(nil).DeleteUser), ctx, ch, model)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockIUserService) ForcePasswordReset(ctx context.Context, ch chan *UserServiceResponse, model *ForcePasswordResetServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForcePasswordReset", ctx, ch, model)
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockIUserServiceMockRecorder) ForcePasswordReset(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockIUserService)(nil).ForcePasswordReset), ctx, ch, model)
+}
+
+// GetUser mocks base method.
+func (m *MockIUserService) GetUser(ctx context.Context, ch chan *UserServiceResponse, model *GetUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUser", ctx, ch, model)
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockIUserServiceMockRecorder) GetUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserService)(nil).GetUser), ctx, ch, model)
+}
+
+// GetUserAuditEvents mocks base method.
+func (m *MockIUserService) GetUserAuditEvents(ctx context.Context, ch chan *GetUserAuditEventsServiceResponse, model *GetUserAuditEventsServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUserAuditEvents", ctx, ch, model)
+}
+
+// GetUserAuditEvents indicates an expected call of GetUserAuditEvents.
+func (mr *MockIUserServiceMockRecorder) GetUserAuditEvents(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAuditEvents", reflect.TypeOf((*MockIUserService)(nil).GetUserAuditEvents), ctx, ch, model)
+}
+
+// GetUsers mocks base method.
+func (m *MockIUserService) GetUsers(ctx context.Context, ch chan *GetUsersServiceResponse, model *GetUsersServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetUsers", ctx, ch, model)
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIUserServiceMockRecorder) GetUsers(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIUserService)(nil).GetUsers), ctx, ch, model)
+}
+
+// RestoreUser mocks base method.
+func (m *MockIUserService) RestoreUser(ctx context.Context, ch chan *UserServiceResponse, model *RestoreUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreUser", ctx, ch, model)
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockIUserServiceMockRecorder) RestoreUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockIUserService)(nil).RestoreUser), ctx, ch, model)
+}
+
+// SetUserActive mocks base method.
+func (m *MockIUserService) SetUserActive(ctx context.Context, ch chan *UserServiceResponse, model *SetUserActiveServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserActive", ctx, ch, model)
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockIUserServiceMockRecorder) SetUserActive(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockIUserService)(nil).SetUserActive), ctx, ch, model)
+}
+
+// SetUserProgrammatic mocks base method.
+func (m *MockIUserService) SetUserProgrammatic(ctx context.Context, ch chan *UserServiceResponse, model *SetUserProgrammaticServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserProgrammatic", ctx, ch, model)
+}
+
+// SetUserProgrammatic indicates an expected call of SetUserProgrammatic.
+func (mr *MockIUserServiceMockRecorder) SetUserProgrammatic(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserProgrammatic", reflect.TypeOf((*MockIUserService)(nil).SetUserProgrammatic), ctx, ch, model)
+}
+
+// UpdateUser mocks base method.
+func (m *MockIUserService) UpdateUser(ctx context.Context, ch chan *UserServiceResponse, model *UpdateUserServiceModel) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUser", ctx, ch, model)
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockIUserServiceMockRecorder) UpdateUser(ctx, ch, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserService)(nil).UpdateUser), ctx, ch, model)
+}
@@ -0,0 +1,338 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-clean-architecture/internal/data/database/auth"
+	"go-clean-architecture/internal/data/notification"
+	"go-clean-architecture/internal/service/revocation"
+	"go-clean-architecture/internal/util/cacher"
+	"go-clean-architecture/internal/util/customerror"
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/hasher"
+	"go-clean-architecture/internal/util/logger"
+	"go-clean-architecture/internal/util/query"
+	"go-clean-architecture/internal/util/validator"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserServiceTestSuite struct {
+	suite.Suite
+	userService        IUserService
+	mockEnvironment    *env.MockIEnvironment
+	mockLogger         *logger.MockILogger
+	mockValidator      *validator.MockIValidator
+	mockCacher         *cacher.MockICacher
+	mockAuthDb         *auth.MockIAuthDb
+	mockPasswordHasher *hasher.MockIPasswordHasher
+	mockNotification   *notification.MockINotificationSender
+	mockRevocation     *revocation.MockIRevocationService
+}
+
+// Run suite.
+func TestUserService(t *testing.T) {
+	suite.Run(t, new(UserServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *UserServiceTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockCacher = cacher.NewMockICacher(ctrl)
+	s.mockAuthDb = auth.NewMockIAuthDb(ctrl)
+	s.mockPasswordHasher = hasher.NewMockIPasswordHasher(ctrl)
+	s.mockNotification = notification.NewMockINotificationSender(ctrl)
+	s.mockRevocation = revocation.NewMockIRevocationService(ctrl)
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).AnyTimes()
+
+	s.userService = NewUserService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockCacher, s.mockAuthDb, s.mockPasswordHasher, s.mockNotification, s.mockRevocation)
+}
+
+func (s *UserServiceTestSuite) expectRevokeUser(userId int64) {
+	s.mockRevocation.
+		EXPECT().
+		RevokeUser(gomock.Any(), gomock.Any(), gomock.Eq(&revocation.RevokeUserServiceModel{UserId: userId, RevokedBy: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *revocation.RevokeUserServiceResponse, model *revocation.RevokeUserServiceModel) {
+			ch <- &revocation.RevokeUserServiceResponse{IsRevoked: true}
+		})
+}
+
+func (s *UserServiceTestSuite) TestGetUsers_HappyPath_ParsesQueryAgainstUserSchema() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		GetUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.GetUsersResponse, model *auth.GetUsersModel) {
+			s.Equal(1, model.Query.PageIndex)
+			s.Len(model.Query.Filters, 2)
+			s.True(model.Query.IncludeDeleted)
+			ch <- &auth.GetUsersResponse{Users: []auth.User{{Id: 21, UserName: "john"}}, Page: query.Page{TotalCount: 21, PageSize: 20}}
+		})
+
+	// When
+	ch := make(chan *GetUsersServiceResponse)
+	defer close(ch)
+	go s.userService.GetUsers(context.Background(), ch, &GetUsersServiceModel{
+		Query:          query.Model{PageIndex: 1, Filter: []string{"userName:prefix:jo", "isActive:eq:true"}},
+		IncludeDeleted: true,
+	})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Len(response.Users, 1)
+	s.Equal(int64(21), response.Page.TotalCount)
+}
+
+func (s *UserServiceTestSuite) TestGetUsers_FieldIsNotWhitelisted_ReturnsError() {
+	// When
+	ch := make(chan *GetUsersServiceResponse)
+	defer close(ch)
+	go s.userService.GetUsers(context.Background(), ch, &GetUsersServiceModel{Query: query.Model{Sort: "email"}})
+	response := <-ch
+
+	// Then
+	s.Error(response.Error)
+}
+
+func (s *UserServiceTestSuite) TestAddUser_WithoutPassword_AddsUserWithoutHash() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		CreateUser(gomock.Any(), gomock.Any(), gomock.Eq(&auth.CreateUserModel{UserName: "robot", IsActive: true, IsProgrammatic: true, ActorUserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.CreateUserModel) {
+			ch <- &auth.UserResponse{User: auth.User{Id: 5, UserName: "robot", IsActive: true, IsProgrammatic: true}}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.AddUser(context.Background(), ch, &AddUserServiceModel{UserName: "robot", IsActive: true, IsProgrammatic: true, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(5), response.User.Id)
+}
+
+func (s *UserServiceTestSuite) TestUpdateUser_UserNameTaken_ReturnsConflictError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		UpdateUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.UpdateUserModel) {
+			ch <- &auth.UserResponse{Error: auth.ErrUserExists}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.UpdateUser(context.Background(), ch, &UpdateUserServiceModel{UserId: 2, UserName: "taken", ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.True(errors.Is(response.Error, customerror.ErrConflict))
+}
+
+func (s *UserServiceTestSuite) TestGetUser_UnknownUser_ReturnsNotFoundError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		GetUserById(gomock.Any(), gomock.Any(), gomock.Eq(&auth.GetUserByIdModel{UserId: 2})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.GetUserByIdModel) {
+			ch <- &auth.UserResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.GetUser(context.Background(), ch, &GetUserServiceModel{UserId: 2})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "user is not found")
+	s.True(errors.Is(response.Error, customerror.ErrNotFound))
+}
+
+func (s *UserServiceTestSuite) TestSetUserActive_Deactivate_RevokesTokens() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		SetUserActive(gomock.Any(), gomock.Any(), gomock.Eq(&auth.SetUserActiveModel{UserId: 2, IsActive: false, ActorUserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.SetUserActiveModel) {
+			ch <- &auth.UserResponse{User: auth.User{Id: 2, UserName: "john"}}
+		})
+	s.expectRevokeUser(2)
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.SetUserActive(context.Background(), ch, &SetUserActiveServiceModel{UserId: 2, IsActive: false, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.False(response.User.IsActive)
+}
+
+func (s *UserServiceTestSuite) TestSetUserActive_DeactivateSelf_ReturnsError() {
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.SetUserActive(context.Background(), ch, &SetUserActiveServiceModel{UserId: 1, IsActive: false, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.EqualError(response.Error, "you cannot deactivate your own user")
+}
+
+func (s *UserServiceTestSuite) TestForcePasswordReset_HappyPath_SendsResetLinkAndRevokesTokens() {
+	// Given
+	user := auth.User{Id: 2, UserName: "john", Email: "john@example.com", IsActive: true, HasPassword: true}
+	s.mockAuthDb.
+		EXPECT().
+		GetUserById(gomock.Any(), gomock.Any(), gomock.Eq(&auth.GetUserByIdModel{UserId: 2})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.GetUserByIdModel) {
+			ch <- &auth.UserResponse{User: user}
+		})
+	var tokenHash string
+	s.mockAuthDb.
+		EXPECT().
+		ForcePasswordReset(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.ForcePasswordResetModel) {
+			tokenHash = model.TokenHash
+			ch <- &auth.UserResponse{User: user}
+		})
+	s.expectRevokeUser(2)
+	s.mockEnvironment.EXPECT().Get(env.AuthPasswordResetUrl).Return("http://localhost/reset")
+	var body string
+	s.mockNotification.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *notification.SendNotificationResponse, model *notification.SendNotificationModel) {
+			body = model.Body
+			ch <- &notification.SendNotificationResponse{}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.ForcePasswordReset(context.Background(), ch, &ForcePasswordResetServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	token := body[strings.Index(body, "token=")+len("token="):]
+	s.Equal(hasher.HashToken(token), tokenHash)
+}
+
+func (s *UserServiceTestSuite) TestForcePasswordReset_ProgrammaticUser_ReturnsError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		GetUserById(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.GetUserByIdModel) {
+			ch <- &auth.UserResponse{User: auth.User{Id: 2, UserName: "robot", Email: "robot@example.com", IsProgrammatic: true}}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.ForcePasswordReset(context.Background(), ch, &ForcePasswordResetServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.Error(response.Error)
+}
+
+func (s *UserServiceTestSuite) TestDeleteUser_UnknownUser_ReturnsNotFoundError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		DeleteUser(gomock.Any(), gomock.Any(), gomock.Eq(&auth.DeleteUserModel{UserId: 2, ActorUserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.DeleteUserResponse, model *auth.DeleteUserModel) {
+			ch <- &auth.DeleteUserResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *DeleteUserServiceResponse)
+	defer close(ch)
+	go s.userService.DeleteUser(context.Background(), ch, &DeleteUserServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.True(errors.Is(response.Error, customerror.ErrNotFound))
+	s.False(response.IsDeleted)
+}
+
+func (s *UserServiceTestSuite) TestDeleteUser_HappyPath_RevokesTokens() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		DeleteUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.DeleteUserResponse, model *auth.DeleteUserModel) {
+			ch <- &auth.DeleteUserResponse{}
+		})
+	s.expectRevokeUser(2)
+
+	// When
+	ch := make(chan *DeleteUserServiceResponse)
+	defer close(ch)
+	go s.userService.DeleteUser(context.Background(), ch, &DeleteUserServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.True(response.IsDeleted)
+}
+
+func (s *UserServiceTestSuite) TestRestoreUser_NotDeletedUser_ReturnsNotFoundError() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RestoreUser(gomock.Any(), gomock.Any(), gomock.Eq(&auth.RestoreUserModel{UserId: 2, ActorUserId: 1})).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.RestoreUserModel) {
+			ch <- &auth.UserResponse{Error: sql.ErrNoRows}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.RestoreUser(context.Background(), ch, &RestoreUserServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.True(errors.Is(response.Error, customerror.ErrNotFound))
+}
+
+func (s *UserServiceTestSuite) TestRestoreUser_HappyPath_ReturnsUser() {
+	// Given
+	s.mockAuthDb.
+		EXPECT().
+		RestoreUser(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ch chan *auth.UserResponse, model *auth.RestoreUserModel) {
+			ch <- &auth.UserResponse{User: auth.User{Id: 2, UserName: "restored"}}
+		})
+
+	// When
+	ch := make(chan *UserServiceResponse)
+	defer close(ch)
+	go s.userService.RestoreUser(context.Background(), ch, &RestoreUserServiceModel{UserId: 2, ActorUserId: 1})
+	response := <-ch
+
+	// Then
+	s.NoError(response.Error)
+	s.Equal(int64(2), response.User.Id)
+	s.Nil(response.User.DeletedAt)
+}
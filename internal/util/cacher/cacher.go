@@ -9,11 +9,24 @@ import (
 	"go-clean-architecture/internal/util/env"
 )
 
+// incrementScript
+// Increments and sets expiration atomically, EXPIRE NX option requires Redis 7.
+var incrementScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count`)
+
 type ICacher interface {
-	Set(key string, value interface{}, duration time.Duration)
-	Get(key string) *string
-	Delete(key string) error
-	Ping() error
+	Set(ctx context.Context, key string, value interface{}, duration time.Duration) error
+	Get(ctx context.Context, key string) (*string, error)
+	Delete(ctx context.Context, key string) error
+	GetDelete(ctx context.Context, key string) (*string, error)
+	Increment(ctx context.Context, key string, duration time.Duration) (int64, error)
+	TimeToLive(ctx context.Context, key string) (time.Duration, error)
+	Ping(ctx context.Context) error
+	Close() error
 }
 
 type Cacher struct {
@@ -36,41 +49,41 @@ func New(environment env.IEnvironment) ICacher {
 	}
 }
 
-func (c *Cacher) Set(key string, value interface{}, duration time.Duration) {
+// Set
+// Sets value of key as json which expires after duration.
+// Returns an error if value cannot be marshalled, redis fails or ctx is done.
+func (c *Cacher) Set(ctx context.Context, key string, value interface{}, duration time.Duration) error {
 	bytes, err := json.Marshal(value)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	err = c.client.Set(ctx, key, bytes, duration).Err()
-	if err != nil {
-		panic(err)
-	}
+	return c.client.Set(ctx, key, bytes, duration).Err()
 }
 
-func (c *Cacher) Get(key string) *string {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+// Get
+// Gets value of key.
+// Returns nil without an error if key does not exist, or an error if redis fails or ctx is done.
+func (c *Cacher) Get(ctx context.Context, key string) (*string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
-	stringCmd := c.client.Get(ctx, key)
-
-	if stringCmd.Val() == "" {
-		return nil
+	value, err := c.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, nil
 	}
-
-	value, err := stringCmd.Result()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return &value
+	return &value, nil
 }
 
-func (c *Cacher) Delete(key string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+func (c *Cacher) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	intCmd := c.client.Del(ctx, key)
@@ -81,8 +94,55 @@ func (c *Cacher) Delete(key string) error {
 
 	return nil
 }
-func (c *Cacher) Ping() error {
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+
+// GetDelete
+// Gets and deletes value of key atomically, so only one caller can receive it. Requires Redis 6.2.
+// Returns nil without an error if key does not exist.
+func (c *Cacher) GetDelete(ctx context.Context, key string) (*string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	value, err := c.client.GetDel(ctx, key).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// Increment
+// Increments the counter at key and returns its new value.
+// Expiration is only set when counter is created, so the window starts with the first increment.
+func (c *Cacher) Increment(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return incrementScript.Run(ctx, c.client, []string{key}, duration.Milliseconds()).Int64()
+}
+
+// TimeToLive
+// Returns remaining time to live of key, or zero if key does not exist or has no expiration.
+func (c *Cacher) TimeToLive(ctx context.Context, key string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	duration, err := c.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	if duration < 0 {
+		return 0, nil
+	}
+
+	return duration, nil
+}
+
+func (c *Cacher) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	statusCmd := c.client.Ping(ctx)
@@ -93,3 +153,9 @@ func (c *Cacher) Ping() error {
 
 	return nil
 }
+
+// Close
+// Closes the client, waiting for commands in progress. Cacher cannot be used afterwards.
+func (c *Cacher) Close() error {
+	return c.client.Close()
+}
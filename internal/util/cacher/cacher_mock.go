@@ -5,6 +5,7 @@
 package cacher
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -34,56 +35,118 @@ func (m *MockICacher) EXPECT() *MockICacherMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockICacher) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockICacherMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockICacher)(nil).Close))
+}
+
 // Delete mocks base method.
-func (m *MockICacher) Delete(key string) error {
+func (m *MockICacher) Delete(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", key)
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockICacherMockRecorder) Delete(key interface{}) *gomock.Call {
+func (mr *MockICacherMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICacher)(nil).Delete), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICacher)(nil).Delete), ctx, key)
 }
 
 // Get mocks base method.
-func (m *MockICacher) Get(key string) *string {
+func (m *MockICacher) Get(ctx context.Context, key string) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", key)
+	ret := m.ctrl.Call(m, "Get", ctx, key)
 	ret0, _ := ret[0].(*string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockICacherMockRecorder) Get(key interface{}) *gomock.Call {
+func (mr *MockICacherMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockICacher)(nil).Get), ctx, key)
+}
+
+// GetDelete mocks base method.
+func (m *MockICacher) GetDelete(ctx context.Context, key string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelete", ctx, key)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelete indicates an expected call of GetDelete.
+func (mr *MockICacherMockRecorder) GetDelete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelete", reflect.TypeOf((*MockICacher)(nil).GetDelete), ctx, key)
+}
+
+// Increment mocks base method.
+func (m *MockICacher) Increment(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, duration)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockICacherMockRecorder) Increment(ctx, key, duration interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockICacher)(nil).Get), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockICacher)(nil).Increment), ctx, key, duration)
 }
 
 // Ping mocks base method.
-func (m *MockICacher) Ping() error {
+func (m *MockICacher) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Ping")
+	ret := m.ctrl.Call(m, "Ping", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Ping indicates an expected call of Ping.
-func (mr *MockICacherMockRecorder) Ping() *gomock.Call {
+func (mr *MockICacherMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockICacher)(nil).Ping))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockICacher)(nil).Ping), ctx)
 }
 
 // Set mocks base method.
-func (m *MockICacher) Set(key string, value interface{}, duration time.Duration) {
+func (m *MockICacher) Set(ctx context.Context, key string, value interface{}, duration time.Duration) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Set", key, value, duration)
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Set indicates an expected call of Set.
-func (mr *MockICacherMockRecorder) Set(key, value, duration interface{}) *gomock.Call {
+func (mr *MockICacherMockRecorder) Set(ctx, key, value, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockICacher)(nil).Set), ctx, key, value, duration)
+}
+
+// TimeToLive mocks base method.
+func (m *MockICacher) TimeToLive(ctx context.Context, key string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeToLive", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeToLive indicates an expected call of TimeToLive.
+func (mr *MockICacherMockRecorder) TimeToLive(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockICacher)(nil).Set), key, value, duration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeToLive", reflect.TypeOf((*MockICacher)(nil).TimeToLive), ctx, key)
 }
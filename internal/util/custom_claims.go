@@ -1,11 +1,48 @@
 package util
 
 import (
+	"errors"
+
+	"go-clean-architecture/internal/util/keyring"
+
 	"github.com/golang-jwt/jwt/v4"
 )
 
 type CustomClaims struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// ParseClaims
+// Verifies a JWT issued by us and returns its claims.
+// Verification key is selected by kid header and token must be signed with that key's algorithm.
+func ParseClaims(tokenString string, keyrng keyring.IKeyring) (*CustomClaims, error) {
+	token, err := jwt.ParseWithClaims(
+		tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			key, err := keyrng.VerificationKey(kid)
+			if err != nil {
+				return nil, err
+			}
+
+			if token.Method.Alg() != key.Method.Alg() {
+				return nil, errors.New("unexpected signing method: " + token.Method.Alg())
+			}
+
+			return key.Public, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*CustomClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("JWT token is invalid")
+	}
+
+	return claims, nil
+}
@@ -1,5 +1,16 @@
 package customerror
 
+import "errors"
+
+// Sentinel errors which are mapped to specific HTTP status codes.
+var (
+	ErrLockedOut          = errors.New("too many failed attempts")
+	ErrRateLimited        = errors.New("too many requests")
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
 type Error struct {
 	Loglevel Loglevel
 	Err      error
@@ -17,3 +28,8 @@ func New(err error, loglevel Loglevel) *Error {
 func (e *Error) Error() string {
 	return e.Err.Error()
 }
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
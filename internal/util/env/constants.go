@@ -2,16 +2,48 @@ package env
 
 // AppEnviroment Application
 const (
-	AppEnvironment = "APP_ENVIRONMENT"
-	AppName        = "APP_NAME"
-	AppHost        = "APP_HOST"
+	AppEnvironment     = "APP_ENVIRONMENT"
+	AppName            = "APP_NAME"
+	AppHost            = "APP_HOST"
+	AppPort            = "PORT"
+	AppShutdownTimeout = "APP_SHUTDOWN_TIMEOUT"
+	AppRequestTimeout  = "APP_REQUEST_TIMEOUT"
 )
 
 // Jwt
-const JwtSecret = "JWT_SECRET"
+const (
+	JwtSecret        = "JWT_SECRET"
+	JwtKeysDirectory = "JWT_KEYS_DIRECTORY"
+	JwtActiveKeyId   = "JWT_ACTIVE_KEY_ID"
+)
+
+// Password
+const PasswordHashAlgorithm = "PASSWORD_HASH_ALGORITHM"
+
+// Auth
+const (
+	AuthActivationUrl    = "AUTH_ACTIVATION_URL"
+	AuthPasswordResetUrl = "AUTH_PASSWORD_RESET_URL"
+)
+
+// Oidc
+const (
+	OidcIssuerUrl    = "OIDC_ISSUER_URL"
+	OidcClientId     = "OIDC_CLIENT_ID"
+	OidcClientSecret = "OIDC_CLIENT_SECRET"
+	OidcRedirectUrl  = "OIDC_REDIRECT_URL"
+	OidcSecureCookie = "OIDC_SECURE_COOKIE"
+)
 
 // Database
-const PostgresqlConnectionString = "POSTGRESQL_CONNECTION_STRING"
+const (
+	PostgresqlConnectionString      = "POSTGRESQL_CONNECTION_STRING"
+	PostgresqlMaxOpenConnections    = "POSTGRESQL_MAX_OPEN_CONNECTIONS"
+	PostgresqlMaxIdleConnections    = "POSTGRESQL_MAX_IDLE_CONNECTIONS"
+	PostgresqlConnectionMaxLifetime = "POSTGRESQL_CONNECTION_MAX_LIFETIME"
+	PostgresqlConnectionMaxIdleTime = "POSTGRESQL_CONNECTION_MAX_IDLE_TIME"
+	PostgresqlAutoMigrate           = "POSTGRESQL_AUTO_MIGRATE"
+)
 
 // Redis
 const (
@@ -34,3 +66,8 @@ const (
 	SampleProxyUrl     = "SAMPLE_PROXY_URL"
 	SampleProxyTimeout = "SAMPLE_PROXY_TIMEOUT"
 )
+
+// Command
+const (
+	CreateUserPassword = "CREATE_USER_PASSWORD"
+)
@@ -0,0 +1,74 @@
+package hasher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const DefaultBcryptCost = 12
+
+// bcryptPrehashKey
+// Key of the HMAC which passwords are reduced with before bcrypt.
+var bcryptPrehashKey = []byte("go-clean-architecture:bcrypt")
+
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher
+// Returns a new BcryptHasher.
+// Hashes are encoded in modular crypt format which carries the cost: $2a$12$<salt+key>
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword(prehash(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+func (h *BcryptHasher) Verify(password string, encodedHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), prehash(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (h *BcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.cost
+}
+
+func (h *BcryptHasher) Matches(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+// prehash
+// Reduces password to a base64 HMAC-SHA256 of 44 bytes. Bcrypt ignores everything after 72 bytes,
+// so longer passwords sharing their first 72 bytes would verify against each other otherwise.
+func prehash(password string) []byte {
+	mac := hmac.New(sha256.New, bcryptPrehashKey)
+	mac.Write([]byte(password))
+
+	return []byte(base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,97 @@
+package hasher
+
+import (
+	"errors"
+	"strings"
+
+	"go-clean-architecture/internal/util/env"
+)
+
+// Algorithms
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmSha256   = "sha256"
+)
+
+var ErrUnknownHashFormat = errors.New("password hash format is unknown")
+
+type IPasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, encodedHash string) (bool, error)
+	NeedsRehash(encodedHash string) bool
+}
+
+// algorithm
+// Single hashing scheme which is able to recognise its own encoded hashes.
+type algorithm interface {
+	IPasswordHasher
+	Matches(encodedHash string) bool
+}
+
+type PasswordHasher struct {
+	preferred  algorithm
+	algorithms []algorithm
+}
+
+// New
+// Returns a new PasswordHasher which hashes with the algorithm given by PASSWORD_HASH_ALGORITHM
+// and verifies hashes of every supported algorithm.
+func New(environment env.IEnvironment) IPasswordHasher {
+	argon2id := NewArgon2idHasher(DefaultArgon2idParams)
+	bcrypt := NewBcryptHasher(DefaultBcryptCost)
+	sha256 := NewSha256Hasher()
+
+	hasher := PasswordHasher{
+		preferred:  argon2id,
+		algorithms: []algorithm{argon2id, bcrypt, sha256},
+	}
+
+	switch strings.ToLower(environment.Get(env.PasswordHashAlgorithm)) {
+	case AlgorithmBcrypt:
+		hasher.preferred = bcrypt
+	case AlgorithmArgon2id, "":
+		hasher.preferred = argon2id
+	default:
+		panic("PASSWORD_HASH_ALGORITHM variable is not supported.")
+	}
+
+	return &hasher
+}
+
+// Hash
+// Hashes password with the preferred algorithm and returns the encoded hash.
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+// Verify
+// Verifies password against an encoded hash produced by any supported algorithm.
+func (h *PasswordHasher) Verify(password string, encodedHash string) (bool, error) {
+	algorithm := h.find(encodedHash)
+	if algorithm == nil {
+		return false, ErrUnknownHashFormat
+	}
+
+	return algorithm.Verify(password, encodedHash)
+}
+
+// NeedsRehash
+// Returns true if encoded hash is not produced by the preferred algorithm with its current parameters.
+func (h *PasswordHasher) NeedsRehash(encodedHash string) bool {
+	if !h.preferred.Matches(encodedHash) {
+		return true
+	}
+
+	return h.preferred.NeedsRehash(encodedHash)
+}
+
+func (h *PasswordHasher) find(encodedHash string) algorithm {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Matches(encodedHash) {
+			return algorithm
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/util/hasher/password_hasher.go
+
+// Package hasher is a generated GoMock package.
+package hasher
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPasswordHasher is a mock of IPasswordHasher interface.
+type MockIPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPasswordHasherMockRecorder
+}
+
+// MockIPasswordHasherMockRecorder is the mock recorder for MockIPasswordHasher.
+type MockIPasswordHasherMockRecorder struct {
+	mock *MockIPasswordHasher
+}
+
+// NewMockIPasswordHasher creates a new mock instance.
+func NewMockIPasswordHasher(ctrl *gomock.Controller) *MockIPasswordHasher {
+	mock := &MockIPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockIPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPasswordHasher) EXPECT() *MockIPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *MockIPasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockIPasswordHasherMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockIPasswordHasher)(nil).Hash), password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockIPasswordHasher) NeedsRehash(encodedHash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", encodedHash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockIPasswordHasherMockRecorder) NeedsRehash(encodedHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockIPasswordHasher)(nil).NeedsRehash), encodedHash)
+}
+
+// Verify mocks base method.
+func (m *MockIPasswordHasher) Verify(password, encodedHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", password, encodedHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIPasswordHasherMockRecorder) Verify(password, encodedHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIPasswordHasher)(nil).Verify), password, encodedHash)
+}
+
+// Mockalgorithm is a mock of algorithm interface.
+type Mockalgorithm struct {
+	ctrl     *gomock.Controller
+	recorder *MockalgorithmMockRecorder
+}
+
+// MockalgorithmMockRecorder is the mock recorder for Mockalgorithm.
+type MockalgorithmMockRecorder struct {
+	mock *Mockalgorithm
+}
+
+// NewMockalgorithm creates a new mock instance.
+func NewMockalgorithm(ctrl *gomock.Controller) *Mockalgorithm {
+	mock := &Mockalgorithm{ctrl: ctrl}
+	mock.recorder = &MockalgorithmMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockalgorithm) EXPECT() *MockalgorithmMockRecorder {
+	return m.recorder
+}
+
+// Hash mocks base method.
+func (m *Mockalgorithm) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockalgorithmMockRecorder) Hash(password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*Mockalgorithm)(nil).Hash), password)
+}
+
+// Matches mocks base method.
+func (m *Mockalgorithm) Matches(encodedHash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Matches", encodedHash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Matches indicates an expected call of Matches.
+func (mr *MockalgorithmMockRecorder) Matches(encodedHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Matches", reflect.TypeOf((*Mockalgorithm)(nil).Matches), encodedHash)
+}
+
+// NeedsRehash mocks base method.
+func (m *Mockalgorithm) NeedsRehash(encodedHash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", encodedHash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockalgorithmMockRecorder) NeedsRehash(encodedHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*Mockalgorithm)(nil).NeedsRehash), encodedHash)
+}
+
+// Verify mocks base method.
+func (m *Mockalgorithm) Verify(password, encodedHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", password, encodedHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockalgorithmMockRecorder) Verify(password, encodedHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*Mockalgorithm)(nil).Verify), password, encodedHash)
+}
@@ -0,0 +1,144 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"go-clean-architecture/internal/util/env"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordHasherTestSuite struct {
+	suite.Suite
+	passwordHasher  IPasswordHasher
+	mockEnvironment *env.MockIEnvironment
+}
+
+// Run suite.
+func TestPasswordHasher(t *testing.T) {
+	suite.Run(t, new(PasswordHasherTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *PasswordHasherTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+
+	// Cheap parameters to keep tests fast.
+	argon2id := NewArgon2idHasher(Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	s.passwordHasher = &PasswordHasher{
+		preferred:  argon2id,
+		algorithms: []algorithm{argon2id, NewBcryptHasher(4), NewSha256Hasher()},
+	}
+}
+
+func (s *PasswordHasherTestSuite) TestNew_UnsupportedAlgorithm_Panics() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.PasswordHashAlgorithm).Return("md5")
+
+	// When & Then
+	s.Panics(func() { New(s.mockEnvironment) })
+}
+
+func (s *PasswordHasherTestSuite) TestHash_PreferredAlgorithm_VerifiesAndDoesNotNeedRehash() {
+	// Given
+	encodedHash, err := s.passwordHasher.Hash("secret")
+	s.NoError(err)
+
+	// When
+	isVerified, err := s.passwordHasher.Verify("secret", encodedHash)
+
+	// Then
+	s.NoError(err)
+	s.True(isVerified)
+	s.False(s.passwordHasher.NeedsRehash(encodedHash))
+}
+
+func (s *PasswordHasherTestSuite) TestHash_SamePassword_ProducesDifferentHashes() {
+	// Given
+	first, _ := s.passwordHasher.Hash("secret")
+
+	// When
+	second, _ := s.passwordHasher.Hash("secret")
+
+	// Then
+	s.NotEqual(first, second)
+}
+
+func (s *PasswordHasherTestSuite) TestVerify_WrongPassword_ReturnsFalse() {
+	// Given
+	encodedHash, _ := s.passwordHasher.Hash("secret")
+
+	// When
+	isVerified, err := s.passwordHasher.Verify("not-secret", encodedHash)
+
+	// Then
+	s.NoError(err)
+	s.False(isVerified)
+}
+
+func (s *PasswordHasherTestSuite) TestVerify_LegacySha256Hash_VerifiesAndNeedsRehash() {
+	// Given
+	encodedHash := "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b" // sha256("secret")
+
+	// When
+	isVerified, err := s.passwordHasher.Verify("secret", encodedHash)
+
+	// Then
+	s.NoError(err)
+	s.True(isVerified)
+	s.True(s.passwordHasher.NeedsRehash(encodedHash))
+}
+
+func (s *PasswordHasherTestSuite) TestVerify_BcryptHash_VerifiesAndNeedsRehash() {
+	// Given
+	encodedHash, _ := NewBcryptHasher(4).Hash("secret")
+
+	// When
+	isVerified, err := s.passwordHasher.Verify("secret", encodedHash)
+
+	// Then
+	s.NoError(err)
+	s.True(isVerified)
+	s.True(s.passwordHasher.NeedsRehash(encodedHash))
+}
+
+func (s *PasswordHasherTestSuite) TestVerify_BcryptHashOfLongPassword_RejectsPasswordWithSameFirst72Bytes() {
+	// Given
+	prefix := strings.Repeat("a", 72)
+	encodedHash, _ := NewBcryptHasher(4).Hash(prefix + "first")
+
+	// When
+	isVerified, err := s.passwordHasher.Verify(prefix+"second", encodedHash)
+	isOriginalVerified, originalErr := s.passwordHasher.Verify(prefix+"first", encodedHash)
+
+	// Then
+	s.NoError(err)
+	s.False(isVerified)
+	s.NoError(originalErr)
+	s.True(isOriginalVerified)
+}
+
+func (s *PasswordHasherTestSuite) TestNeedsRehash_OutdatedArgon2idParams_ReturnsTrue() {
+	// Given
+	encodedHash, _ := NewArgon2idHasher(Argon2idParams{Memory: 512, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}).Hash("secret")
+
+	// When
+	needsRehash := s.passwordHasher.NeedsRehash(encodedHash)
+
+	// Then
+	s.True(needsRehash)
+}
+
+func (s *PasswordHasherTestSuite) TestVerify_UnknownFormat_ReturnsError() {
+	// When
+	isVerified, err := s.passwordHasher.Verify("secret", "plain-text")
+
+	// Then
+	s.ErrorIs(err, ErrUnknownHashFormat)
+	s.False(isVerified)
+}
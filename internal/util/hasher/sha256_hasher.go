@@ -0,0 +1,42 @@
+package hasher
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+)
+
+// Sha256Hasher
+// Legacy unsalted SHA-256 hex digests. Only used to verify existing rows so they can be rehashed on login.
+type Sha256Hasher struct{}
+
+// NewSha256Hasher
+// Returns a new Sha256Hasher.
+func NewSha256Hasher() *Sha256Hasher {
+	return &Sha256Hasher{}
+}
+
+func (h *Sha256Hasher) Hash(password string) (string, error) {
+	return "", errors.New("sha256 password hashing is not allowed")
+}
+
+func (h *Sha256Hasher) Verify(password string, encodedHash string) (bool, error) {
+	hash := sha256.Sum256([]byte(password))
+	otherHash := hex.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(encodedHash), []byte(otherHash)) == 1, nil
+}
+
+func (h *Sha256Hasher) NeedsRehash(encodedHash string) bool {
+	return true
+}
+
+func (h *Sha256Hasher) Matches(encodedHash string) bool {
+	if len(encodedHash) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(encodedHash)
+	return err == nil
+}
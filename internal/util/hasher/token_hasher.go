@@ -0,0 +1,14 @@
+package hasher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken
+// Returns sha256 hex digest of a random secret such as an emailed token, an api key or a recovery code.
+// They have enough entropy, so a fast unsalted hash is sufficient to store and look them up.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,11 @@
+package hasher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashToken_KnownToken_ReturnsSha256HexDigest(t *testing.T) {
+	assert.Equal(t, "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0", HashToken("token"))
+}
@@ -0,0 +1,19 @@
+package helper
+
+import (
+	"context"
+
+	"go-clean-architecture/internal/util/requestcontext"
+)
+
+// GetActorId
+// Returns id of the authenticated user carried by ctx, which is recorded in created_by and updated_by columns.
+// Returns nil for anonymous requests and background work.
+func GetActorId(ctx context.Context) *int64 {
+	user, ok := requestcontext.GetUser(ctx)
+	if !ok || user.Id == 0 {
+		return nil
+	}
+
+	return &user.Id
+}
@@ -0,0 +1,23 @@
+package helper
+
+import (
+	"net/url"
+
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+)
+
+// GetTokenLink
+// Returns the url in given environment variable with token added to its query, such as activation and reset links.
+func GetTokenLink(environment env.IEnvironment, loggr logger.ILogger, environmentKey string, token string) string {
+	link, err := url.Parse(environment.Get(environmentKey))
+	if err != nil {
+		loggr.Panic("Couldn't convert " + environmentKey + " environment variable to url.URL !")
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
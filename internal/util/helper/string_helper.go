@@ -0,0 +1,11 @@
+package helper
+
+// Truncate
+// Returns value limited to given number of characters, such as user agents stored in limited columns.
+func Truncate(value string, length int) string {
+	if runes := []rune(value); len(runes) > length {
+		return string(runes[:length])
+	}
+
+	return value
+}
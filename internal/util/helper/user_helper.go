@@ -2,6 +2,7 @@ package helper
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,6 +11,12 @@ import (
 const UserId = "claims:user_id"
 const UserName = "claims:user_name"
 const UserEmail = "claims:user_email"
+const UserRoles = "claims:user_roles"
+const UserPermissions = "claims:user_permissions"
+const ApiKeyId = "claims:api_key_id"
+const TokenId = "claims:jti"
+const TokenIssuedAt = "claims:issued_at"
+const TokenExpiresAt = "claims:expires_at"
 
 func GetUserId(context *gin.Context) int64 {
 	userId, _ := strconv.ParseInt(context.GetString(UserId), 10, 64)
@@ -23,3 +30,64 @@ func GetUserName(context *gin.Context) string {
 func GetUserEmail(context *gin.Context) string {
 	return context.GetString(UserEmail)
 }
+
+// GetApiKeyId
+// Returns id of the api key if request is authenticated with one, otherwise zero.
+func GetApiKeyId(context *gin.Context) int64 {
+	return context.GetInt64(ApiKeyId)
+}
+
+// GetTokenId
+// Returns jti of the access token, empty for api keys and tokens issued before jti was added.
+func GetTokenId(context *gin.Context) string {
+	return context.GetString(TokenId)
+}
+
+// GetTokenIssuedAt
+// Returns issue time of the access token, nil for api keys.
+func GetTokenIssuedAt(context *gin.Context) *time.Time {
+	return getTime(context, TokenIssuedAt)
+}
+
+// GetTokenExpiresAt
+// Returns expiry of the access token, nil for api keys.
+func GetTokenExpiresAt(context *gin.Context) *time.Time {
+	return getTime(context, TokenExpiresAt)
+}
+
+func getTime(context *gin.Context, key string) *time.Time {
+	if _, ok := context.Get(key); !ok {
+		return nil
+	}
+
+	value := context.GetTime(key)
+	return &value
+}
+
+func GetUserRoles(context *gin.Context) []string {
+	return context.GetStringSlice(UserRoles)
+}
+
+func GetUserPermissions(context *gin.Context) []string {
+	return context.GetStringSlice(UserPermissions)
+}
+
+func HasRole(context *gin.Context, role string) bool {
+	return Contains(GetUserRoles(context), role)
+}
+
+func HasPermission(context *gin.Context, permission string) bool {
+	return Contains(GetUserPermissions(context), permission)
+}
+
+// Contains
+// Returns whether values contain value, such as a role or a permission.
+func Contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
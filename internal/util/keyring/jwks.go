@@ -0,0 +1,125 @@
+package keyring
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// Jwks
+// JSON Web Key Set as described in RFC 7517.
+type Jwks struct {
+	Keys []Jwk `json:"keys"`
+}
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// newJwk
+// Returns the public part of an asymmetric key. Symmetric keys are never published.
+func newJwk(key *Key) (Jwk, bool) {
+	jwk := Jwk{
+		Use: "sig",
+		Kid: key.Id,
+		Alg: key.Method.Alg(),
+	}
+
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encode(public.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(public.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = public.Curve.Params().Name
+		jwk.X = encode(public.X.FillBytes(make([]byte, size)))
+		jwk.Y = encode(public.Y.FillBytes(make([]byte, size)))
+	default:
+		return Jwk{}, false
+	}
+
+	return jwk, true
+}
+
+// PublicKey
+// Returns the RSA or EC public key described by the JWK, used to verify tokens of other issuers.
+func (j Jwk) PublicKey() (interface{}, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decode(j.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decode(j.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported curve: " + j.Crv)
+		}
+
+		x, err := decode(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decode(j.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		public := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(public.X, public.Y) {
+			return nil, errors.New("point is not on curve: " + j.Crv)
+		}
+
+		return public, nil
+	default:
+		return nil, errors.New("unsupported key type: " + j.Kty)
+	}
+}
+
+// Key
+// Returns the key with given kid.
+func (j *Jwks) Key(kid string) (Jwk, bool) {
+	for _, key := range j.Keys {
+		if key.Kid == kid {
+			return key, true
+		}
+	}
+
+	return Jwk{}, false
+}
+
+func decode(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
+
+func encode(bytes []byte) string {
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,200 @@
+package keyring
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go-clean-architecture/internal/util/env"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrKeyNotFound = errors.New("signing key is not found")
+
+type IKeyring interface {
+	SigningKey() (*Key, error)
+	VerificationKey(kid string) (*Key, error)
+	Jwks() *Jwks
+}
+
+// Key
+// Private is only set for keys which can sign, keys kept for grace period may only have Public.
+type Key struct {
+	Id      string
+	Method  jwt.SigningMethod
+	Private interface{}
+	Public  interface{}
+}
+
+type Keyring struct {
+	activeKeyId string
+	keys        map[string]*Key
+}
+
+// New
+// Returns a new Keyring which loads PEM files from JWT_KEYS_DIRECTORY, each named after its kid as <kid>.pem.
+// Key with JWT_ACTIVE_KEY_ID signs new tokens, all other keys are only used to verify tokens signed before rotation.
+// Falls back to HS256 with JWT_SECRET if JWT_KEYS_DIRECTORY is not set.
+func New(environment env.IEnvironment) IKeyring {
+	directory := environment.Get(env.JwtKeysDirectory)
+	if directory == "" {
+		return newHmacKeyring(environment.Get(env.JwtSecret))
+	}
+
+	keyring := Keyring{
+		activeKeyId: environment.Get(env.JwtActiveKeyId),
+		keys:        make(map[string]*Key),
+	}
+
+	fileNames, err := filepath.Glob(filepath.Join(directory, "*.pem"))
+	if err != nil {
+		panic("Panicked while listing JWT_KEYS_DIRECTORY.")
+	}
+
+	for _, fileName := range fileNames {
+		kid := strings.TrimSuffix(filepath.Base(fileName), ".pem")
+
+		bytes, err := os.ReadFile(fileName)
+		if err != nil {
+			panic(fmt.Sprintf("Panicked while reading %s key file.", kid))
+		}
+
+		key, err := parseKey(kid, bytes)
+		if err != nil {
+			panic(fmt.Sprintf("Panicked while parsing %s key file. %s", kid, err))
+		}
+
+		keyring.keys[kid] = key
+	}
+
+	activeKey, ok := keyring.keys[keyring.activeKeyId]
+	if !ok || activeKey.Private == nil {
+		panic("JWT_ACTIVE_KEY_ID variable must point to a private key inside JWT_KEYS_DIRECTORY.")
+	}
+
+	return &keyring
+}
+
+func newHmacKeyring(secret string) *Keyring {
+	key := Key{
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+
+	return &Keyring{
+		keys: map[string]*Key{"": &key},
+	}
+}
+
+// SigningKey
+// Returns the active key which signs new tokens.
+func (k *Keyring) SigningKey() (*Key, error) {
+	key, ok := k.keys[k.activeKeyId]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// VerificationKey
+// Returns the key identified by kid header of a token.
+func (k *Keyring) VerificationKey(kid string) (*Key, error) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+// Jwks
+// Returns public parts of asymmetric keys as a JSON Web Key Set.
+func (k *Keyring) Jwks() *Jwks {
+	jwks := Jwks{Keys: []Jwk{}}
+
+	for _, key := range k.keys {
+		jwk, ok := newJwk(key)
+		if ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+
+	return &jwks
+}
+
+// parseKey
+// Parses a PEM encoded RSA or ECDSA key. Private keys may be PKCS#1, PKCS#8 or SEC 1, public keys must be PKIX.
+func parseKey(kid string, bytes []byte) (*Key, error) {
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, errors.New("file does not contain a PEM block")
+	}
+
+	var parsed interface{}
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("PEM block type %s is not supported", block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := Key{Id: kid}
+
+	switch typed := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Private = typed
+		key.Public = &typed.PublicKey
+	case *ecdsa.PrivateKey:
+		key.Private = typed
+		key.Public = &typed.PublicKey
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		key.Public = typed
+	default:
+		return nil, errors.New("key type is not supported, only RSA and ECDSA keys are allowed")
+	}
+
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch public.Curve {
+		case elliptic.P256():
+			key.Method = jwt.SigningMethodES256
+		case elliptic.P384():
+			key.Method = jwt.SigningMethodES384
+		case elliptic.P521():
+			key.Method = jwt.SigningMethodES512
+		default:
+			return nil, errors.New("elliptic curve is not supported")
+		}
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/util/keyring/keyring.go
+
+// Package keyring is a generated GoMock package.
+package keyring
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIKeyring is a mock of IKeyring interface.
+type MockIKeyring struct {
+	ctrl     *gomock.Controller
+	recorder *MockIKeyringMockRecorder
+}
+
+// MockIKeyringMockRecorder is the mock recorder for MockIKeyring.
+type MockIKeyringMockRecorder struct {
+	mock *MockIKeyring
+}
+
+// NewMockIKeyring creates a new mock instance.
+func NewMockIKeyring(ctrl *gomock.Controller) *MockIKeyring {
+	mock := &MockIKeyring{ctrl: ctrl}
+	mock.recorder = &MockIKeyringMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIKeyring) EXPECT() *MockIKeyringMockRecorder {
+	return m.recorder
+}
+
+// Jwks mocks base method.
+func (m *MockIKeyring) Jwks() *Jwks {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Jwks")
+	ret0, _ := ret[0].(*Jwks)
+	return ret0
+}
+
+// Jwks indicates an expected call of Jwks.
+func (mr *MockIKeyringMockRecorder) Jwks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Jwks", reflect.TypeOf((*MockIKeyring)(nil).Jwks))
+}
+
+// SigningKey mocks base method.
+func (m *MockIKeyring) SigningKey() (*Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SigningKey")
+	ret0, _ := ret[0].(*Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SigningKey indicates an expected call of SigningKey.
+func (mr *MockIKeyringMockRecorder) SigningKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SigningKey", reflect.TypeOf((*MockIKeyring)(nil).SigningKey))
+}
+
+// VerificationKey mocks base method.
+func (m *MockIKeyring) VerificationKey(kid string) (*Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerificationKey", kid)
+	ret0, _ := ret[0].(*Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerificationKey indicates an expected call of VerificationKey.
+func (mr *MockIKeyringMockRecorder) VerificationKey(kid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerificationKey", reflect.TypeOf((*MockIKeyring)(nil).VerificationKey), kid)
+}
@@ -0,0 +1,178 @@
+package keyring
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-clean-architecture/internal/util/env"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyringTestSuite struct {
+	suite.Suite
+	mockEnvironment *env.MockIEnvironment
+	directory       string
+	previousKey     *rsa.PrivateKey
+}
+
+// Run suite.
+func TestKeyring(t *testing.T) {
+	suite.Run(t, new(KeyringTestSuite))
+}
+
+// Runs before each test in the suite.
+// Writes an active ES256 private key and a previous RS256 key of which only the public part is kept.
+func (s *KeyringTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.directory = s.T().TempDir()
+
+	activeKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	activeKeyBytes, err := x509.MarshalECPrivateKey(activeKey)
+	s.Require().NoError(err)
+	s.writePem("2022-06", "EC PRIVATE KEY", activeKeyBytes)
+
+	s.previousKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	previousKeyBytes, err := x509.MarshalPKIXPublicKey(&s.previousKey.PublicKey)
+	s.Require().NoError(err)
+	s.writePem("2022-05", "PUBLIC KEY", previousKeyBytes)
+}
+
+func (s *KeyringTestSuite) writePem(kid string, blockType string, bytes []byte) {
+	content := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes})
+	s.Require().NoError(os.WriteFile(filepath.Join(s.directory, kid+".pem"), content, 0600))
+}
+
+func (s *KeyringTestSuite) expectEnvironment(activeKeyId string) {
+	s.mockEnvironment.EXPECT().Get(env.JwtKeysDirectory).Return(s.directory)
+	s.mockEnvironment.EXPECT().Get(env.JwtActiveKeyId).Return(activeKeyId)
+}
+
+func (s *KeyringTestSuite) TestNew_ActiveKeyHasNoPrivatePart_Panics() {
+	// Given
+	s.expectEnvironment("2022-05")
+
+	// When & Then
+	s.Panics(func() { New(s.mockEnvironment) })
+}
+
+func (s *KeyringTestSuite) TestSigningKey_ActiveKey_SignsVerifiableTokens() {
+	// Given
+	s.expectEnvironment("2022-06")
+	keyring := New(s.mockEnvironment)
+
+	// When
+	signingKey, err := keyring.SigningKey()
+	s.Require().NoError(err)
+	token := jwt.NewWithClaims(signingKey.Method, jwt.RegisteredClaims{Subject: "1"})
+	token.Header["kid"] = signingKey.Id
+	tokenString, err := token.SignedString(signingKey.Private)
+	s.Require().NoError(err)
+
+	// Then
+	s.Equal("ES256", signingKey.Method.Alg())
+	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		key, err := keyring.VerificationKey(token.Header["kid"].(string))
+		if err != nil {
+			return nil, err
+		}
+		return key.Public, nil
+	})
+	s.NoError(err)
+}
+
+func (s *KeyringTestSuite) TestVerificationKey_PreviousKey_VerifiesTokensSignedBeforeRotation() {
+	// Given
+	s.expectEnvironment("2022-06")
+	keyring := New(s.mockEnvironment)
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{Subject: "1"}).SignedString(s.previousKey)
+	s.Require().NoError(err)
+
+	// When
+	key, err := keyring.VerificationKey("2022-05")
+	s.Require().NoError(err)
+	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) { return key.Public, nil })
+
+	// Then
+	s.NoError(err)
+	s.Nil(key.Private)
+}
+
+func (s *KeyringTestSuite) TestVerificationKey_UnknownKid_ReturnsError() {
+	// Given
+	s.expectEnvironment("2022-06")
+	keyring := New(s.mockEnvironment)
+
+	// When
+	_, err := keyring.VerificationKey("unknown")
+
+	// Then
+	s.ErrorIs(err, ErrKeyNotFound)
+}
+
+func (s *KeyringTestSuite) TestJwks_AsymmetricKeys_PublishesAllPublicKeys() {
+	// Given
+	s.expectEnvironment("2022-06")
+	keyring := New(s.mockEnvironment)
+
+	// When
+	jwks := keyring.Jwks()
+
+	// Then
+	s.Len(jwks.Keys, 2)
+	s.Equal("2022-05", jwks.Keys[0].Kid)
+	s.Equal("RSA", jwks.Keys[0].Kty)
+	s.Equal("RS256", jwks.Keys[0].Alg)
+	s.Equal("AQAB", jwks.Keys[0].E)
+	s.Equal("2022-06", jwks.Keys[1].Kid)
+	s.Equal("EC", jwks.Keys[1].Kty)
+	s.Equal("P-256", jwks.Keys[1].Crv)
+	s.Len(jwks.Keys[1].X, 43)
+}
+
+func (s *KeyringTestSuite) TestJwks_HmacFallback_PublishesNothing() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.JwtKeysDirectory).Return("")
+	s.mockEnvironment.EXPECT().Get(env.JwtSecret).Return("secret")
+
+	// When
+	jwks := New(s.mockEnvironment).Jwks()
+
+	// Then
+	s.Empty(jwks.Keys)
+}
+
+func (s *KeyringTestSuite) TestJwkPublicKey_PublishedKeys_RoundTrip() {
+	// Given
+	s.expectEnvironment("2022-06")
+	keyring := New(s.mockEnvironment)
+	jwks := keyring.Jwks()
+
+	for _, kid := range []string{"2022-05", "2022-06"} {
+		jwk, ok := jwks.Key(kid)
+		s.Require().True(ok)
+		key, err := keyring.VerificationKey(kid)
+		s.Require().NoError(err)
+
+		// When
+		public, err := jwk.PublicKey()
+
+		// Then
+		s.NoError(err)
+		s.Equal(key.Public, public)
+	}
+}
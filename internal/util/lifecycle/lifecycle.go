@@ -0,0 +1,154 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+
+	"go.uber.org/zap"
+)
+
+// defaultShutdownTimeout
+// Used when APP_SHUTDOWN_TIMEOUT is not set.
+const defaultShutdownTimeout = 30 * time.Second
+
+// Component
+// A resource owned by the lifecycle. Start must not block, long running work is started in a goroutine
+// and reported with Fail if it stops unexpectedly. Stop must return once ctx is done. Either of them can be nil.
+type Component struct {
+	Name  string
+	Start func() error
+	Stop  func(ctx context.Context) error
+}
+
+type ILifecycle interface {
+	Add(component Component)
+	Fail(err error)
+	Run(ctx context.Context) error
+}
+
+type Lifecycle struct {
+	loggr      logger.ILogger
+	timeout    time.Duration
+	components []Component
+	failures   chan error
+	mutex      sync.Mutex
+}
+
+// New
+// Returns a new Lifecycle whose shutdown deadline is read from APP_SHUTDOWN_TIMEOUT in seconds.
+// Panics if the timeout is invalid.
+func New(environment env.IEnvironment, loggr logger.ILogger) ILifecycle {
+	timeout := defaultShutdownTimeout
+	if value := environment.Get(env.AppShutdownTimeout); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 1 {
+			panic(fmt.Sprintf("Couldn't convert %s environment variable to a positive int !", env.AppShutdownTimeout))
+		}
+		timeout = time.Second * time.Duration(seconds)
+	}
+
+	return &Lifecycle{
+		loggr:    loggr,
+		timeout:  timeout,
+		failures: make(chan error, 1),
+	}
+}
+
+// Add
+// Registers a component. Components are started in the order they are added and stopped in reverse order,
+// so a component must be added after the components it depends on.
+func (l *Lifecycle) Add(component Component) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.components = append(l.components, component)
+}
+
+// Fail
+// Reports that a running component stopped unexpectedly, which shuts down the lifecycle.
+// Only the first failure is kept.
+func (l *Lifecycle) Fail(err error) {
+	select {
+	case l.failures <- err:
+	default:
+	}
+}
+
+// Run
+// Starts components and blocks until ctx is done or a component fails, then stops the started components
+// in reverse order within the shutdown deadline. Returns the start or failure error, otherwise the stop errors.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	l.mutex.Lock()
+	components := l.components
+	l.mutex.Unlock()
+
+	var err error
+	started := 0
+	for _, component := range components {
+		if component.Start != nil {
+			err = component.Start()
+			if err != nil {
+				err = fmt.Errorf("%s could not be started: %w", component.Name, err)
+				break
+			}
+		}
+
+		started++
+		l.loggr.Info("Component is started.", zap.String("component", component.Name))
+	}
+
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			l.loggr.Info("Shutting down.", zap.Duration("timeout", l.timeout))
+		case err = <-l.failures:
+			l.loggr.Error("Shutting down after a component failed.", zap.Error(err))
+		}
+	}
+
+	stopErr := l.stop(components[:started])
+	if err != nil {
+		return err
+	}
+
+	return stopErr
+}
+
+// stop
+// Stops components in reverse order. Components are stopped even if the deadline is exceeded by a previous one,
+// so resources like connections are still closed.
+func (l *Lifecycle) stop(components []Component) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	var messages []string
+	for i := len(components) - 1; i >= 0; i-- {
+		component := components[i]
+		if component.Stop == nil {
+			continue
+		}
+
+		err := component.Stop(ctx)
+		if err != nil {
+			l.loggr.Error("Component could not be stopped.", zap.String("component", component.Name), zap.Error(err))
+			messages = append(messages, component.Name+" could not be stopped: "+err.Error())
+			continue
+		}
+
+		l.loggr.Info("Component is stopped.", zap.String("component", component.Name))
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/util/lifecycle/lifecycle.go
+
+// Package lifecycle is a generated GoMock package.
+package lifecycle
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILifecycle is a mock of ILifecycle interface.
+type MockILifecycle struct {
+	ctrl     *gomock.Controller
+	recorder *MockILifecycleMockRecorder
+}
+
+// MockILifecycleMockRecorder is the mock recorder for MockILifecycle.
+type MockILifecycleMockRecorder struct {
+	mock *MockILifecycle
+}
+
+// NewMockILifecycle creates a new mock instance.
+func NewMockILifecycle(ctrl *gomock.Controller) *MockILifecycle {
+	mock := &MockILifecycle{ctrl: ctrl}
+	mock.recorder = &MockILifecycleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILifecycle) EXPECT() *MockILifecycleMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockILifecycle) Add(component Component) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", component)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockILifecycleMockRecorder) Add(component interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockILifecycle)(nil).Add), component)
+}
+
+// Fail mocks base method.
+func (m *MockILifecycle) Fail(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Fail", err)
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockILifecycleMockRecorder) Fail(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockILifecycle)(nil).Fail), err)
+}
+
+// Run mocks base method.
+func (m *MockILifecycle) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockILifecycleMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockILifecycle)(nil).Run), ctx)
+}
@@ -0,0 +1,146 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LifecycleTestSuite struct {
+	suite.Suite
+	lifecycle       *Lifecycle
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	calls           []string
+}
+
+// Run suite.
+func TestLifecycle(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *LifecycleTestSuite) SetupTest() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockLogger.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.mockEnvironment.EXPECT().Get(env.AppShutdownTimeout).Return("")
+	s.lifecycle = New(s.mockEnvironment, s.mockLogger).(*Lifecycle)
+	s.calls = nil
+}
+
+// component
+// Returns a component recording its start and stop calls.
+func (s *LifecycleTestSuite) component(name string, startErr error) Component {
+	return Component{
+		Name: name,
+		Start: func() error {
+			s.calls = append(s.calls, "start "+name)
+			return startErr
+		},
+		Stop: func(ctx context.Context) error {
+			s.calls = append(s.calls, "stop "+name)
+			return nil
+		},
+	}
+}
+
+// cancelled
+// Returns a context which is already done, as if the process is signalled.
+func cancelled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func (s *LifecycleTestSuite) TestNew_InvalidShutdownTimeout_Panics() {
+	// Given
+	s.mockEnvironment.EXPECT().Get(env.AppShutdownTimeout).Return("soon")
+
+	// When
+	create := func() { New(s.mockEnvironment, s.mockLogger) }
+
+	// Then
+	s.Panics(create)
+}
+
+func (s *LifecycleTestSuite) TestRun_Signalled_StopsComponentsInReverseOrder() {
+	// Given
+	s.lifecycle.Add(s.component("pool", nil))
+	s.lifecycle.Add(s.component("receivers", nil))
+	s.lifecycle.Add(s.component("server", nil))
+
+	// When
+	err := s.lifecycle.Run(cancelled())
+
+	// Then
+	s.NoError(err)
+	s.Equal([]string{"start pool", "start receivers", "start server", "stop server", "stop receivers", "stop pool"}, s.calls)
+}
+
+func (s *LifecycleTestSuite) TestRun_StartFails_StopsOnlyStartedComponents() {
+	// Given
+	startErr := errors.New("address already in use")
+	s.lifecycle.Add(s.component("pool", nil))
+	s.lifecycle.Add(s.component("server", startErr))
+	s.lifecycle.Add(s.component("receivers", nil))
+
+	// When
+	err := s.lifecycle.Run(context.Background())
+
+	// Then
+	s.ErrorIs(err, startErr)
+	s.Equal([]string{"start pool", "start server", "stop pool"}, s.calls)
+}
+
+func (s *LifecycleTestSuite) TestRun_ComponentFails_ShutsDownWithFailure() {
+	// Given
+	failure := errors.New("server stopped")
+	s.lifecycle.Add(s.component("pool", nil))
+	s.lifecycle.Add(Component{
+		Name: "server",
+		Start: func() error {
+			go s.lifecycle.Fail(failure)
+			return nil
+		},
+	})
+
+	// When
+	err := s.lifecycle.Run(context.Background())
+
+	// Then
+	s.ErrorIs(err, failure)
+	s.Equal([]string{"start pool", "stop pool"}, s.calls)
+}
+
+func (s *LifecycleTestSuite) TestRun_StopExceedsDeadline_StopsRemainingComponents() {
+	// Given
+	s.lifecycle.timeout = time.Millisecond * 50
+	s.lifecycle.Add(s.component("pool", nil))
+	s.lifecycle.Add(Component{
+		Name: "receivers",
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	// When
+	err := s.lifecycle.Run(cancelled())
+
+	// Then
+	s.ErrorContains(err, "receivers could not be stopped: "+context.DeadlineExceeded.Error())
+	s.Equal([]string{"start pool", "stop pool"}, s.calls)
+}
@@ -0,0 +1,30 @@
+package permission
+
+// Roles
+const (
+	RoleAdmin           = "admin"
+	RoleSamplePublisher = "sample-publisher"
+)
+
+// Sample
+const (
+	SamplePublish = "sample:publish"
+)
+
+// Auth
+const (
+	AuthLockoutsManage   = "auth:lockouts:manage"
+	AuthTokensRevoke     = "auth:tokens:revoke"
+	AuthTokensIntrospect = "auth:tokens:introspect"
+	AuthAuditRead        = "auth:audit:read"
+)
+
+// Api keys
+const (
+	ApiKeysManage = "api-keys:manage"
+)
+
+// Users
+const (
+	UsersManage = "users:manage"
+)
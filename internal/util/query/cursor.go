@@ -0,0 +1,58 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var errInvalidCursor = errors.New("cursor is invalid")
+
+// cursor
+// Values of the sort fields of the last row of a page. Sort is kept to reject cursors used with another sort.
+type cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// encodeCursor
+// Returns an opaque cursor continuing after the row with given field values.
+func (q *Query) encodeCursor(values map[string]interface{}) string {
+	c := cursor{Sort: q.sortString()}
+	for _, sort := range q.Sorts {
+		c.Values = append(c.Values, formatValue(values[sort.Field]))
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor
+// Returns the sort field values of the cursor, converted to the types of the fields.
+func (q *Query) decodeCursor(value string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c cursor
+	err = json.Unmarshal(data, &c)
+	if err != nil || len(c.Values) != len(q.Sorts) {
+		return nil, errInvalidCursor
+	}
+	if c.Sort != q.sortString() {
+		return nil, errors.New("cursor cannot be used with another sort")
+	}
+
+	after := make([]interface{}, 0, len(c.Values))
+	for i, sort := range q.Sorts {
+		value, err := parseValue(q.schema.Fields[sort.Field].Type, c.Values[i])
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+
+		after = append(after, value)
+	}
+
+	return after, nil
+}
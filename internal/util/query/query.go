@@ -0,0 +1,271 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of list queries.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	MaxFilters      = 10
+)
+
+type FieldType int
+
+const (
+	String FieldType = iota
+	Int
+	Time
+	Bool
+)
+
+// Field
+// A field of a resource which list queries can filter or sort by. Column must be selected by its name,
+// and must be not null if the field is sortable.
+type Field struct {
+	Column     string
+	Type       FieldType
+	Filterable bool
+	Sortable   bool
+}
+
+// Schema
+// Whitelist of fields of a resource keyed by their names in query strings. Key is a unique sortable field
+// which is appended to every sort, so rows with equal values are paged in a stable order. If SoftDelete is set,
+// rows whose deleted_at column is set are excluded unless the query includes deleted rows.
+type Schema struct {
+	Fields     map[string]Field
+	Key        string
+	SoftDelete bool
+}
+
+// Model
+// Query string of list endpoints. PageIndex is zero based and cannot be combined with Cursor.
+// Filters are formatted as field:op:value and sort as comma separated fields, descending if prefixed with -.
+type Model struct {
+	PageSize  int      `form:"pageSize"`
+	PageIndex int      `form:"pageIndex"`
+	Cursor    string   `form:"cursor"`
+	Filter    []string `form:"filter"`
+	Sort      string   `form:"sort"`
+}
+
+type Operator string
+
+// Filter operators. In takes comma separated values, Prefix is a case-insensitive prefix match.
+const (
+	Eq     Operator = "eq"
+	Ne     Operator = "ne"
+	Gt     Operator = "gt"
+	Gte    Operator = "gte"
+	Lt     Operator = "lt"
+	Lte    Operator = "lte"
+	In     Operator = "in"
+	Prefix Operator = "prefix"
+)
+
+// operatorTypes
+// Field types each operator can be used with.
+var operatorTypes = map[Operator][]FieldType{
+	Eq:     {String, Int, Time, Bool},
+	Ne:     {String, Int, Time, Bool},
+	Gt:     {Int, Time},
+	Gte:    {Int, Time},
+	Lt:     {Int, Time},
+	Lte:    {Int, Time},
+	In:     {String, Int},
+	Prefix: {String},
+}
+
+type Filter struct {
+	Field    string
+	Operator Operator
+	Values   []interface{}
+}
+
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+// Query
+// A validated list query of a resource. Only columns of the schema are written into sql, values are parameters.
+// After holds the values of the sort fields to continue after in keyset mode, it is nil in offset mode.
+// IncludeDeleted selects soft deleted rows too, callers set it only for admins.
+type Query struct {
+	schema         Schema
+	PageSize       int
+	PageIndex      int
+	Filters        []Filter
+	Sorts          []Sort
+	After          []interface{}
+	IncludeDeleted bool
+}
+
+// Parse
+// Validates model against the schema and returns the query.
+func Parse(schema Schema, model Model) (*Query, error) {
+	query := Query{schema: schema, PageSize: model.PageSize, PageIndex: model.PageIndex}
+	if query.PageSize == 0 {
+		query.PageSize = DefaultPageSize
+	}
+	if query.PageSize < 1 || query.PageSize > MaxPageSize {
+		return nil, fmt.Errorf("pageSize must be between 1 and %d", MaxPageSize)
+	}
+	if query.PageIndex < 0 {
+		return nil, errors.New("pageIndex cannot be negative")
+	}
+	if len(model.Filter) > MaxFilters {
+		return nil, fmt.Errorf("at most %d filters are allowed", MaxFilters)
+	}
+
+	for _, expression := range model.Filter {
+		filter, err := schema.parseFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+
+		query.Filters = append(query.Filters, *filter)
+	}
+
+	sorts, err := schema.parseSort(model.Sort)
+	if err != nil {
+		return nil, err
+	}
+	query.Sorts = sorts
+
+	if model.Cursor != "" {
+		if query.PageIndex != 0 {
+			return nil, errors.New("pageIndex cannot be used with cursor")
+		}
+
+		query.After, err = query.decodeCursor(model.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &query, nil
+}
+
+// parseFilter
+// Parses a field:op:value expression. Value may contain colons.
+func (s Schema) parseFilter(expression string) (*Filter, error) {
+	parts := strings.SplitN(expression, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("filter %q must be formatted as field:op:value", expression)
+	}
+
+	field, ok := s.Fields[parts[0]]
+	if !ok || !field.Filterable {
+		return nil, fmt.Errorf("cannot filter by %q", parts[0])
+	}
+
+	operator := Operator(parts[1])
+	if !supports(operator, field.Type) {
+		return nil, fmt.Errorf("operator %q is not supported by %q", parts[1], parts[0])
+	}
+
+	values := []string{parts[2]}
+	if operator == In {
+		values = strings.Split(parts[2], ",")
+	}
+
+	filter := Filter{Field: parts[0], Operator: operator}
+	for _, value := range values {
+		parsed, err := parseValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %q", value, parts[0])
+		}
+
+		filter.Values = append(filter.Values, parsed)
+	}
+
+	return &filter, nil
+}
+
+// parseSort
+// Parses comma separated sort fields and appends the key field if it is not sorted by.
+func (s Schema) parseSort(value string) ([]Sort, error) {
+	var sorts []Sort
+	seen := map[string]bool{}
+	if value != "" {
+		for _, name := range strings.Split(value, ",") {
+			sort := Sort{Field: strings.TrimPrefix(name, "-"), Descending: strings.HasPrefix(name, "-")}
+			field, ok := s.Fields[sort.Field]
+			if !ok || !field.Sortable {
+				return nil, fmt.Errorf("cannot sort by %q", sort.Field)
+			}
+			if seen[sort.Field] {
+				return nil, fmt.Errorf("cannot sort by %q more than once", sort.Field)
+			}
+
+			seen[sort.Field] = true
+			sorts = append(sorts, sort)
+		}
+	}
+
+	if !seen[s.Key] {
+		sorts = append(sorts, Sort{Field: s.Key})
+	}
+
+	return sorts, nil
+}
+
+// supports
+// Returns true if operator can be used with fields of given type.
+func supports(operator Operator, fieldType FieldType) bool {
+	for _, supported := range operatorTypes[operator] {
+		if supported == fieldType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseValue
+// Converts a query string value to the type of the field, times are RFC 3339.
+func parseValue(fieldType FieldType, value string) (interface{}, error) {
+	switch fieldType {
+	case Int:
+		return strconv.ParseInt(value, 10, 64)
+	case Time:
+		return time.Parse(time.RFC3339Nano, value)
+	case Bool:
+		return strconv.ParseBool(value)
+	default:
+		return value, nil
+	}
+}
+
+// formatValue
+// Converts a field value to the format parseValue reads.
+func formatValue(value interface{}) string {
+	switch value := value.(type) {
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// sortString
+// Returns sorts in the format of Model.Sort.
+func (q *Query) sortString() string {
+	names := make([]string, 0, len(q.Sorts))
+	for _, sort := range q.Sorts {
+		if sort.Descending {
+			names = append(names, "-"+sort.Field)
+		} else {
+			names = append(names, sort.Field)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
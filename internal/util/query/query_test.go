@@ -0,0 +1,205 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryTestSuite struct {
+	suite.Suite
+	schema Schema
+}
+
+// Run suite.
+func TestQuery(t *testing.T) {
+	suite.Run(t, new(QueryTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *QueryTestSuite) SetupTest() {
+	s.schema = Schema{
+		Key: "id",
+		Fields: map[string]Field{
+			"id":          {Column: "id", Type: Int, Filterable: true, Sortable: true},
+			"name":        {Column: "name", Type: String, Filterable: true, Sortable: true},
+			"createdDate": {Column: "created_date", Type: Time, Filterable: true, Sortable: true},
+			"secret":      {Column: "secret", Type: String},
+		},
+	}
+}
+
+func (s *QueryTestSuite) TestParse_EmptyModel_UsesDefaultPageSizeAndKeySort() {
+	// When
+	query, err := Parse(s.schema, Model{})
+
+	// Then
+	s.NoError(err)
+	s.Equal(DefaultPageSize, query.PageSize)
+	s.Equal([]Sort{{Field: "id"}}, query.Sorts)
+	s.Nil(query.After)
+}
+
+func (s *QueryTestSuite) TestParse_InvalidModel_ReturnsError() {
+	models := map[string]Model{
+		"page size too large":     {PageSize: MaxPageSize + 1},
+		"negative page index":     {PageIndex: -1},
+		"malformed filter":        {Filter: []string{"name=test"}},
+		"unknown filter field":    {Filter: []string{"password:eq:test"}},
+		"not filterable field":    {Filter: []string{"secret:eq:test"}},
+		"unknown operator":        {Filter: []string{"name:like:test"}},
+		"unsupported operator":    {Filter: []string{"name:gt:test"}},
+		"invalid filter value":    {Filter: []string{"id:eq:one"}},
+		"not sortable field":      {Sort: "secret"},
+		"duplicate sort field":    {Sort: "name,-name"},
+		"cursor with page index":  {Cursor: "abc", PageIndex: 1},
+		"malformed cursor":        {Cursor: "not a cursor"},
+		"too many filters":        {Filter: make([]string, MaxFilters+1)},
+		"filter without operator": {Filter: []string{"name:test"}},
+	}
+
+	for name, model := range models {
+		// When
+		_, err := Parse(s.schema, model)
+
+		// Then
+		s.Error(err, name)
+	}
+}
+
+func (s *QueryTestSuite) TestSelect_OffsetMode_ReturnsParameterizedStatement() {
+	// Given
+	query, err := Parse(s.schema, Model{
+		PageSize:  10,
+		PageIndex: 2,
+		Filter:    []string{"name:prefix:50%_off", "id:in:1,2", "createdDate:gte:2022-06-01T00:00:00Z"},
+		Sort:      "-createdDate",
+	})
+	s.Require().NoError(err)
+
+	// When
+	statement, args := query.Select("id, name, created_date", "items", "deleted = $1", false)
+
+	// Then
+	s.Contains(statement, "where (deleted = $1)")
+	s.Contains(statement, `and lower(name) like lower($2) || '%'`)
+	s.Contains(statement, "and id in ($3, $4)")
+	s.Contains(statement, "and created_date >= $5")
+	s.Contains(statement, "order by created_date desc, id")
+	s.Contains(statement, "offset $6")
+	s.Contains(statement, "limit $7")
+	s.NotContains(statement, "50%")
+	s.Equal([]interface{}{false, `50\%\_off`, int64(1), int64(2), time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), 20, 11}, args)
+}
+
+func (s *QueryTestSuite) TestSelect_SoftDeleteSchema_ExcludesDeletedRowsByDefault() {
+	// Given
+	s.schema.SoftDelete = true
+	query, err := Parse(s.schema, Model{})
+	s.Require().NoError(err)
+
+	// When
+	statement, _ := query.Select("id, name", "items", "")
+
+	// Then
+	s.Contains(statement, "where deleted_at is null")
+
+	// When
+	query.IncludeDeleted = true
+	statement, _ = query.Select("id, name", "items", "")
+
+	// Then
+	s.NotContains(statement, "deleted_at")
+}
+
+func (s *QueryTestSuite) TestCount_CursorMode_CountsFilteredRowsWithoutPaging() {
+	// Given
+	query, err := Parse(s.schema, Model{PageSize: 10, Filter: []string{"name:eq:a"}})
+	s.Require().NoError(err)
+	query.After = []interface{}{int64(5)}
+
+	// When
+	statement, args := query.Count("items", "deleted = $1", false)
+
+	// Then
+	s.Contains(statement, "select count(*)")
+	s.Contains(statement, "where (deleted = $1)")
+	s.Contains(statement, "and name = $2")
+	s.NotContains(statement, "id >")
+	s.NotContains(statement, "limit")
+	s.Equal([]interface{}{false, "a"}, args)
+}
+
+func (s *QueryTestSuite) TestResult_MoreRowsThanPageSize_ReturnsNextPage() {
+	// Given
+	query, err := Parse(s.schema, Model{PageSize: 2, PageIndex: 1, Sort: "-name"})
+	s.Require().NoError(err)
+
+	// When
+	kept, page := query.Result(7, 3, func(i int) map[string]interface{} {
+		return map[string]interface{}{"id": int64(i + 1), "name": fmt.Sprintf("name %d", i)}
+	})
+
+	// Then
+	s.Equal(2, kept)
+	s.Equal(int64(7), page.TotalCount)
+	s.Equal(1, *page.PageIndex)
+	s.Equal(2, *page.NextPageIndex)
+	s.NotEmpty(page.NextCursor)
+}
+
+func (s *QueryTestSuite) TestResult_LastPage_HasNoNextPage() {
+	// Given
+	query, err := Parse(s.schema, Model{PageSize: 2})
+	s.Require().NoError(err)
+
+	// When
+	kept, page := query.Result(2, 2, nil)
+
+	// Then
+	s.Equal(2, kept)
+	s.Nil(page.NextPageIndex)
+	s.Empty(page.NextCursor)
+}
+
+func (s *QueryTestSuite) TestParse_NextCursor_ContinuesAfterLastRow() {
+	// Given
+	first, err := Parse(s.schema, Model{PageSize: 1, Sort: "-createdDate"})
+	s.Require().NoError(err)
+	createdDate := time.Date(2022, 6, 15, 10, 30, 0, 123000, time.UTC)
+	_, page := first.Result(5, 2, func(i int) map[string]interface{} {
+		return map[string]interface{}{"id": int64(42), "createdDate": createdDate}
+	})
+
+	// When
+	next, err := Parse(s.schema, Model{PageSize: 1, Sort: "-createdDate", Cursor: page.NextCursor})
+	s.Require().NoError(err)
+	statement, args := next.Select("id, created_date", "items", "")
+
+	// Then
+	s.Equal([]interface{}{createdDate, int64(42)}, next.After)
+	s.Contains(statement, "where ((created_date < $1) or (created_date = $1 and id > $2))")
+	s.Contains(statement, "limit $3")
+	s.NotContains(statement, "offset")
+	s.Equal([]interface{}{createdDate, int64(42), 2}, args)
+
+	_, nextPage := next.Result(5, 1, nil)
+	s.Nil(nextPage.PageIndex)
+}
+
+func (s *QueryTestSuite) TestParse_CursorOfAnotherSort_ReturnsError() {
+	// Given
+	first, err := Parse(s.schema, Model{PageSize: 1, Sort: "name"})
+	s.Require().NoError(err)
+	_, page := first.Result(5, 2, func(i int) map[string]interface{} {
+		return map[string]interface{}{"id": int64(1), "name": "first"}
+	})
+
+	// When
+	_, err = Parse(s.schema, Model{PageSize: 1, Sort: "-name", Cursor: page.NextCursor})
+
+	// Then
+	s.Error(err)
+}
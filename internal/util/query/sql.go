@@ -0,0 +1,182 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// likeReplacer
+// Escapes wildcards of like patterns.
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+var comparisons = map[Operator]string{Eq: "=", Ne: "<>", Gt: ">", Gte: ">=", Lt: "<", Lte: "<="}
+
+// Page
+// Metadata of a page returned by list endpoints. TotalCount is the count of rows matching the filters.
+// PageIndex and NextPageIndex are only set in offset mode, NextCursor is set whenever there is a next page.
+type Page struct {
+	TotalCount    int64
+	PageSize      int
+	PageIndex     *int   `json:",omitempty"`
+	NextPageIndex *int   `json:",omitempty"`
+	NextCursor    string `json:",omitempty"`
+}
+
+// parameters
+// Collects arguments of a statement and returns their placeholders.
+type parameters struct {
+	args []interface{}
+}
+
+func (p *parameters) add(value interface{}) string {
+	p.args = append(p.args, value)
+	return "$" + strconv.Itoa(len(p.args))
+}
+
+// Select
+// Returns a statement selecting columns from table with the filters, sorting and paging of the query, followed by
+// a total count column, and its arguments. where is an optional condition of the resource whose placeholders start
+// from $1 with args as arguments. Soft deleted rows are excluded unless the query includes them.
+// One more row than the page size is selected, see Result.
+func (q *Query) Select(columns string, table string, where string, args ...interface{}) (string, []interface{}) {
+	params := parameters{args: args}
+	filtered := q.filtered(&params, table, where)
+
+	orders := make([]string, 0, len(q.Sorts))
+	for _, sort := range q.Sorts {
+		order := q.schema.Fields[sort.Field].Column
+		if sort.Descending {
+			order += " desc"
+		}
+		orders = append(orders, order)
+	}
+	orderBy := "order by " + strings.Join(orders, ", ")
+
+	if q.After == nil {
+		offset := params.add(q.PageIndex * q.PageSize)
+		limit := params.add(q.PageSize + 1)
+		statement := `
+	select ` + columns + `, count(*) over ()
+	` + filtered + `
+	` + orderBy + `
+	offset ` + offset + `
+	limit ` + limit
+
+		return statement, params.args
+	}
+
+	// Rows are counted before continuing after the cursor, so the total count is the same for every page.
+	keyset := q.keyset(&params)
+	limit := params.add(q.PageSize + 1)
+	statement := `
+	select *
+	from (select ` + columns + `, count(*) over () as total_count
+	` + filtered + `) as filtered
+	where ` + keyset + `
+	` + orderBy + `
+	limit ` + limit
+
+	return statement, params.args
+}
+
+// Count
+// Returns a statement counting the rows of table matching the filters of the query, and its arguments. where and
+// args are the same as Select. Total count is read from the selected rows, so it is only run for empty pages.
+func (q *Query) Count(table string, where string, args ...interface{}) (string, []interface{}) {
+	params := parameters{args: args}
+	statement := `
+	select count(*)
+	` + q.filtered(&params, table, where)
+
+	return statement, params.args
+}
+
+// Result
+// Returns the number of rows to keep of the rows selected with Select, and the metadata of the page.
+// values returns the values of the sort fields of the row at given index, keyed by field name, for the next cursor.
+func (q *Query) Result(totalCount int64, rows int, values func(i int) map[string]interface{}) (int, Page) {
+	page := Page{TotalCount: totalCount, PageSize: q.PageSize}
+	if q.After == nil {
+		pageIndex := q.PageIndex
+		page.PageIndex = &pageIndex
+	}
+
+	if rows <= q.PageSize {
+		return rows, page
+	}
+
+	if q.After == nil {
+		nextPageIndex := q.PageIndex + 1
+		page.NextPageIndex = &nextPageIndex
+	}
+	page.NextCursor = q.encodeCursor(values(q.PageSize - 1))
+
+	return q.PageSize, page
+}
+
+// filtered
+// Returns the from and where clauses selecting the rows of table matching the filters of the query.
+func (q *Query) filtered(params *parameters, table string, where string) string {
+	var conditions []string
+	if where != "" {
+		conditions = append(conditions, "("+where+")")
+	}
+	if q.schema.SoftDelete && !q.IncludeDeleted {
+		conditions = append(conditions, "deleted_at is null")
+	}
+	for _, filter := range q.Filters {
+		conditions = append(conditions, q.condition(params, filter))
+	}
+
+	filtered := "from " + table
+	if len(conditions) > 0 {
+		filtered += "\n\twhere " + strings.Join(conditions, "\n\tand ")
+	}
+
+	return filtered
+}
+
+// condition
+// Returns the sql condition of a filter.
+func (q *Query) condition(params *parameters, filter Filter) string {
+	column := q.schema.Fields[filter.Field].Column
+	switch filter.Operator {
+	case In:
+		placeholders := make([]string, 0, len(filter.Values))
+		for _, value := range filter.Values {
+			placeholders = append(placeholders, params.add(value))
+		}
+		return column + " in (" + strings.Join(placeholders, ", ") + ")"
+	case Prefix:
+		return "lower(" + column + ") like lower(" + params.add(likeReplacer.Replace(filter.Values[0].(string))) + ") || '%'"
+	default:
+		return column + " " + comparisons[filter.Operator] + " " + params.add(filter.Values[0])
+	}
+}
+
+// keyset
+// Returns the condition selecting rows after the cursor in sort order, e.g. (a > $1) or (a = $1 and b < $2)
+// for sort a,-b.
+func (q *Query) keyset(params *parameters) string {
+	placeholders := make([]string, 0, len(q.After))
+	for _, value := range q.After {
+		placeholders = append(placeholders, params.add(value))
+	}
+
+	alternatives := make([]string, 0, len(q.Sorts))
+	for i, sort := range q.Sorts {
+		var terms []string
+		for j := 0; j < i; j++ {
+			terms = append(terms, q.schema.Fields[q.Sorts[j].Field].Column+" = "+placeholders[j])
+		}
+
+		comparison := " > "
+		if sort.Descending {
+			comparison = " < "
+		}
+		terms = append(terms, q.schema.Fields[sort.Field].Column+comparison+placeholders[i])
+		alternatives = append(alternatives, "("+strings.Join(terms, " and ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " or ") + ")"
+}
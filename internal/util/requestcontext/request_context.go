@@ -0,0 +1,56 @@
+package requestcontext
+
+import "context"
+
+type contextKey int
+
+const (
+	requestIdKey contextKey = iota
+	userKey
+)
+
+// User
+// Identity of the authenticated caller. ApiKeyId is zero unless the request is authenticated with an api key.
+type User struct {
+	Id       int64
+	UserName string
+	ApiKeyId int64
+}
+
+// WithRequestId
+// Returns a copy of ctx carrying the request id, which is the message id for pub-sub messages.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+// GetRequestId
+// Returns the request id carried by ctx, empty if there is none.
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+// WithUser
+// Returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userKey, user)
+}
+
+// GetUser
+// Returns the authenticated user carried by ctx, false for anonymous requests and background work.
+func GetUser(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey).(User)
+	return user, ok
+}
+
+// Detach
+// Returns a context carrying the request id and user of ctx, which is not cancelled with ctx. Used for work that
+// outlives the request.
+func Detach(ctx context.Context) context.Context {
+	detached := WithRequestId(context.Background(), GetRequestId(ctx))
+	if user, ok := GetUser(ctx); ok {
+		detached = WithUser(detached, user)
+	}
+
+	return detached
+}
@@ -0,0 +1,132 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults which are supported by common authenticator apps.
+const (
+	DefaultDigits       = 6
+	DefaultPeriod       = time.Second * 30
+	DefaultSkew         = 1
+	DefaultSecretLength = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type ITotp interface {
+	GenerateSecret() (string, error)
+	Uri(issuer string, accountName string, secret string) string
+	Validate(secret string, code string, at time.Time) (int64, bool, error)
+}
+
+// Totp
+// Time-based one-time passwords as described in RFC 6238, using HMAC-SHA1 which authenticator apps expect.
+type Totp struct {
+	digits       int
+	period       time.Duration
+	skew         int64
+	secretLength int
+}
+
+// New
+// Returns a new Totp with default parameters.
+func New() ITotp {
+	return &Totp{
+		digits:       DefaultDigits,
+		period:       DefaultPeriod,
+		skew:         DefaultSkew,
+		secretLength: DefaultSecretLength,
+	}
+}
+
+// GenerateSecret
+// Returns a random base32 encoded secret.
+func (t *Totp) GenerateSecret() (string, error) {
+	secret := make([]byte, t.secretLength)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// Uri
+// Returns an otpauth URI which authenticator apps import, mostly via QR code.
+func (t *Totp) Uri(issuer string, accountName string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(t.digits))
+	query.Set("period", strconv.Itoa(int(t.period.Seconds())))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
+
+// Validate
+// Checks code against the time step of given time and its neighbours within skew.
+// Returns the matched time step so callers can reject a code which is used before.
+func (t *Totp) Validate(secret string, code string, at time.Time) (int64, bool, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.ReplaceAll(secret, " ", "")))
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(code) != t.digits {
+		return 0, false, nil
+	}
+
+	step := at.Unix() / int64(t.period.Seconds())
+	for i := -t.skew; i <= t.skew; i++ {
+		if step+i < 0 {
+			continue
+		}
+
+		expected := t.generate(key, uint64(step+i))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step + i, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// generate
+// Generates the code of a counter as described in RFC 4226.
+func (t *Totp) generate(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < t.digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", t.digits, value%modulo)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/util/totp/totp.go
+
+// Package totp is a generated GoMock package.
+package totp
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockITotp is a mock of ITotp interface.
+type MockITotp struct {
+	ctrl     *gomock.Controller
+	recorder *MockITotpMockRecorder
+}
+
+// MockITotpMockRecorder is the mock recorder for MockITotp.
+type MockITotpMockRecorder struct {
+	mock *MockITotp
+}
+
+// NewMockITotp creates a new mock instance.
+func NewMockITotp(ctrl *gomock.Controller) *MockITotp {
+	mock := &MockITotp{ctrl: ctrl}
+	mock.recorder = &MockITotpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITotp) EXPECT() *MockITotpMockRecorder {
+	return m.recorder
+}
+
+// GenerateSecret mocks base method.
+func (m *MockITotp) GenerateSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockITotpMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockITotp)(nil).GenerateSecret))
+}
+
+// Uri mocks base method.
+func (m *MockITotp) Uri(issuer, accountName, secret string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uri", issuer, accountName, secret)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Uri indicates an expected call of Uri.
+func (mr *MockITotpMockRecorder) Uri(issuer, accountName, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uri", reflect.TypeOf((*MockITotp)(nil).Uri), issuer, accountName, secret)
+}
+
+// Validate mocks base method.
+func (m *MockITotp) Validate(secret, code string, at time.Time) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", secret, code, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockITotpMockRecorder) Validate(secret, code, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockITotp)(nil).Validate), secret, code, at)
+}
@@ -0,0 +1,106 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Secret of RFC 6238 test vectors, "12345678901234567890" in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type TotpTestSuite struct {
+	suite.Suite
+	totp *Totp
+}
+
+// Run suite.
+func TestTotp(t *testing.T) {
+	suite.Run(t, new(TotpTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *TotpTestSuite) SetupTest() {
+	s.totp = New().(*Totp)
+}
+
+func (s *TotpTestSuite) TestValidate_RfcTestVectors_AreValid() {
+	// Given
+	rfcTotp := &Totp{digits: 8, period: DefaultPeriod, skew: 0}
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unix, code := range vectors {
+		// When
+		step, isValid, err := rfcTotp.Validate(rfcSecret, code, time.Unix(unix, 0))
+
+		// Then
+		s.NoError(err)
+		s.True(isValid, "code at %d", unix)
+		s.Equal(unix/30, step)
+	}
+}
+
+func (s *TotpTestSuite) TestValidate_PreviousStepWithinSkew_ReturnsMatchedStep() {
+	// Given
+	at := time.Unix(1111111111, 0)
+	key, _ := encoding.DecodeString(rfcSecret)
+	previousCode := s.totp.generate(key, uint64(at.Unix()/30-1))
+
+	// When
+	step, isValid, err := s.totp.Validate(rfcSecret, previousCode, at)
+
+	// Then
+	s.NoError(err)
+	s.True(isValid)
+	s.Equal(at.Unix()/30-1, step)
+}
+
+func (s *TotpTestSuite) TestValidate_StepOutsideSkew_IsInvalid() {
+	// Given
+	at := time.Unix(1111111111, 0)
+	key, _ := encoding.DecodeString(rfcSecret)
+	oldCode := s.totp.generate(key, uint64(at.Unix()/30-2))
+
+	// When
+	_, isValid, err := s.totp.Validate(rfcSecret, oldCode, at)
+
+	// Then
+	s.NoError(err)
+	s.False(isValid)
+}
+
+func (s *TotpTestSuite) TestValidate_MalformedSecret_ReturnsError() {
+	// When
+	_, isValid, err := s.totp.Validate("not-base32!", "123456", time.Now())
+
+	// Then
+	s.Error(err)
+	s.False(isValid)
+}
+
+func (s *TotpTestSuite) TestGenerateSecret_Decodes_ToSecretLength() {
+	// When
+	secret, err := s.totp.GenerateSecret()
+
+	// Then
+	s.NoError(err)
+	key, err := encoding.DecodeString(secret)
+	s.NoError(err)
+	s.Len(key, DefaultSecretLength)
+}
+
+func (s *TotpTestSuite) TestUri_ContainsSecretAndIssuer() {
+	// When
+	uri := s.totp.Uri("Go Clean Architecture", "john", rfcSecret)
+
+	// Then
+	s.Equal("otpauth://totp/Go%20Clean%20Architecture:john?algorithm=SHA1&digits=6&issuer=Go+Clean+Architecture&period=30&secret="+rfcSecret, uri)
+}
@@ -1,24 +1,55 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"go-clean-architecture/docs"
 	"go-clean-architecture/internal/api"
+	"go-clean-architecture/internal/api/v1/controller/apikey"
+	"go-clean-architecture/internal/api/v1/controller/audit"
 	"go-clean-architecture/internal/api/v1/controller/auth"
 	"go-clean-architecture/internal/api/v1/controller/health"
+	"go-clean-architecture/internal/api/v1/controller/lockout"
+	"go-clean-architecture/internal/api/v1/controller/mfa"
+	"go-clean-architecture/internal/api/v1/controller/revocation"
 	"go-clean-architecture/internal/api/v1/controller/sample"
+	"go-clean-architecture/internal/api/v1/controller/user"
+	"go-clean-architecture/internal/api/v1/controller/wellknown"
 	sampleController "go-clean-architecture/internal/api/v2/controller/sample"
+	"go-clean-architecture/internal/command"
+	"go-clean-architecture/internal/data/database"
+	sampleReceiver "go-clean-architecture/internal/data/pubsub/receiver/sample"
+	auditService "go-clean-architecture/internal/service/audit"
+	authService "go-clean-architecture/internal/service/auth"
+	outboxService "go-clean-architecture/internal/service/outbox"
 	"go-clean-architecture/internal/util/cacher"
 	"go-clean-architecture/internal/util/env"
+	"go-clean-architecture/internal/util/lifecycle"
 	"go-clean-architecture/internal/util/logger"
 	"go-clean-architecture/internal/util/validator"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
 )
 
+const usage = `usage: go-clean-architecture [command]
+  serve                 starts the http server, default if no command is given
+  worker [--count N]    starts N receivers for each subscription without the http server
+  all [--count N]       starts both the http server and the receivers
+  migrate <command>     runs db migrations: up, down [N], goto V, status or force V
+  create-user           creates an active user with password from CREATE_USER_PASSWORD, --programmatic for a programmatic user
+  config check          validates configuration and connections`
+
 // @title                       Go Clean Architecture
 // @version                     1.0
 // @description                 This is an template project.
@@ -36,24 +67,212 @@ import (
 // @securityDefinitions.apikey  Bearer
 // @in                          header
 // @name                        Authorization
+// @securityDefinitions.apikey  ApiKey
+// @in                          header
+// @name                        X-Api-Key
 func main() {
 	environment := env.New()
 	environment.Init()
 	loggr := logger.New(environment)
-	defer loggr.Sync()
 	cachr := cacher.New(environment)
 	validatr := validator.New()
 
-	// router := gin.Default()
-	router := gin.New()
-	router.Use(api.LoggingMiddleware(loggr))
-	addRoutes(router, environment, loggr, validatr, cachr)
-	addSwagger(router, environment)
+	// Flush buffered logs before a panic terminates the process.
+	defer func() {
+		if rec := recover(); rec != nil {
+			loggr.Sync()
+			panic(rec)
+		}
+	}()
+
+	err := run(os.Args[1:], environment, loggr, validatr, cachr)
+	loggr.Sync()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run
+// Runs the subcommand given in args, serve if there is none.
+func run(args []string, environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, cachr cacher.ICacher) error {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+
+	switch args[0] {
+	case "serve":
+		return start(args, environment, loggr, validatr, cachr, true, false)
+	case "worker":
+		return start(args, environment, loggr, validatr, cachr, false, true)
+	case "all":
+		return start(args, environment, loggr, validatr, cachr, true, true)
+	case "migrate":
+		return command.NewMigrateCommand(environment, loggr, nil).Run(args[1:], os.Stdout)
+	case "create-user":
+		return command.NewCreateUserCommand(environment, loggr, validatr, cachr, nil).Run(args[1:], os.Stdout)
+	case "config":
+		return command.NewConfigCommand(environment, loggr, validatr, cachr, nil).Run(args[1:], os.Stdout)
+	default:
+		return errors.New(usage)
+	}
+}
+
+// start
+// Starts the http server, the receivers or both, and blocks until the process is signalled or a component fails.
+// Resources are closed gracefully before returning.
+func start(
+	args []string,
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	withServer bool,
+	withReceivers bool,
+) error {
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	count := flags.Int("count", 1, "number of receivers listening to each subscription")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	if !withReceivers && isFlagSet(flags, "count") {
+		return errors.New("--count is only supported by worker and all")
+	}
+	if *count < 1 {
+		return errors.New("--count must be at least 1")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return newLifecycle(environment, loggr, validatr, cachr, withServer, withReceivers, *count).Run(ctx)
+}
+
+// newLifecycle
+// Returns a lifecycle owning the db pool, redis client, audit writer, outbox relay, receivers and http server.
+// Components are added in dependency order, so the server stops taking requests first and the pool is closed last.
+func newLifecycle(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	cachr cacher.ICacher,
+	withServer bool,
+	withReceivers bool,
+	count int,
+) lifecycle.ILifecycle {
+	lifecycl := lifecycle.New(environment, loggr)
+
+	pool := database.Open(environment)
+	lifecycl.Add(lifecycle.Component{
+		Name: "db pool",
+		Stop: func(ctx context.Context) error {
+			return pool.Close()
+		},
+	})
 
-	addReceivers(environment, loggr, validatr, cachr)
+	lifecycl.Add(lifecycle.Component{
+		Name: "redis client",
+		Stop: func(ctx context.Context) error {
+			return cachr.Close()
+		},
+	})
 
-	// listen and serve on 0.0.0.0:8080 (for windows "localhost:8080")
-	router.Run()
+	if environment.Get(env.PostgresqlAutoMigrate) == "true" {
+		lifecycl.Add(lifecycle.Component{
+			Name: "db migrations",
+			Start: func() error {
+				return command.NewMigrateCommand(environment, loggr, nil).Run([]string{"up"}, os.Stdout)
+			},
+		})
+	}
+
+	// Services record audit events through a single writer, so events of the process are written in the same batches.
+	audits := auditService.NewAuditService(environment, loggr, validatr, cachr, nil)
+	lifecycl.Add(lifecycle.Component{
+		Name: "audit writer",
+		Stop: audits.Close,
+	})
+
+	// Every process runs a relay, the relay lock lets only one of them publish at a time.
+	relay := outboxService.NewOutboxRelay(environment, loggr, validatr, cachr, nil, nil)
+	lifecycl.Add(lifecycle.Component{
+		Name: "outbox relay",
+		Start: func() error {
+			relay.Start()
+			return nil
+		},
+		Stop: relay.Shutdown,
+	})
+
+	if withReceivers {
+		receiver := sampleReceiver.NewSampleReceiver(environment, loggr, validatr, cachr, nil)
+		lifecycl.Add(lifecycle.Component{
+			Name: "sample receivers",
+			Start: func() error {
+				receiver.InitReceivers(count)
+				return nil
+			},
+			Stop: receiver.Shutdown,
+		})
+	}
+
+	if withServer {
+		// router := gin.Default()
+		router := gin.New()
+		router.Use(api.RequestContextMiddleware(environment), api.LoggingMiddleware(loggr))
+		addRoutes(router, environment, loggr, validatr, cachr, audits)
+		addSwagger(router, environment)
+
+		server := &http.Server{Addr: getAddress(environment), Handler: router}
+		lifecycl.Add(lifecycle.Component{
+			Name: "http server",
+			Start: func() error {
+				listener, err := net.Listen("tcp", server.Addr)
+				if err != nil {
+					return err
+				}
+
+				loggr.Info("Listening and serving HTTP.", zap.String("address", server.Addr))
+				go func() {
+					err := server.Serve(listener)
+					if err != nil && !errors.Is(err, http.ErrServerClosed) {
+						lifecycl.Fail(err)
+					}
+				}()
+
+				return nil
+			},
+			// Shutdown stops accepting connections and waits for requests in progress.
+			Stop: server.Shutdown,
+		})
+	}
+
+	return lifecycl
+}
+
+// getAddress
+// Returns the address to listen on, 0.0.0.0:8080 unless PORT is set.
+func getAddress(environment env.IEnvironment) string {
+	if port := environment.Get(env.AppPort); port != "" {
+		return ":" + port
+	}
+
+	return ":8080"
+}
+
+// isFlagSet
+// Returns true if the flag with given name is passed in command line.
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	isSet := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			isSet = true
+		}
+	})
+
+	return isSet
 }
 
 func addRoutes(
@@ -62,13 +281,23 @@ func addRoutes(
 	loggr logger.ILogger,
 	validatr validator.IValidator,
 	cachr cacher.ICacher,
+	audits auditService.IAuditService,
 ) {
+	wellknown.NewWellKnownController(environment, loggr, validatr, cachr, nil).RegisterRoutes(&router.RouterGroup)
+
 	api := router.Group("api")
 	health.NewHealthController(environment, loggr, validatr, cachr, nil).RegisterRoutes(api)
 
 	v1 := api.Group("v1")
 	v2 := api.Group("v2")
-	auth.NewAuthController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
+	authenticator := authService.NewAuthService(environment, loggr, validatr, cachr, nil, nil, nil, nil, nil, nil, nil, nil, audits, nil)
+	auth.NewAuthController(environment, loggr, validatr, cachr, authenticator).RegisterRoutes(v1)
+	lockout.NewLockoutController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
+	audit.NewAuditController(environment, loggr, validatr, cachr, audits).RegisterRoutes(v1)
+	mfa.NewMfaController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
+	revocation.NewRevocationController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
+	apikey.NewApiKeyController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
+	user.NewUserController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
 	sample.NewSampleController(environment, loggr, validatr, cachr, nil).RegisterRoutes(v1)
 	sampleController.NewSampleController(environment, loggr, validatr, cachr).RegisterRoutes(v2)
 }
@@ -78,12 +307,3 @@ func addSwagger(router *gin.Engine, environment env.IEnvironment) {
 	docs.SwaggerInfo.Host = environment.Get(env.AppHost)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
-
-func addReceivers(
-	environment env.IEnvironment,
-	loggr logger.ILogger,
-	validatr validator.IValidator,
-	cachr cacher.ICacher,
-) {
-	//go sampleReceiver.NewSampleReceiver(environment, loggr, validatr, cachr, nil).InitReceivers(1)
-}
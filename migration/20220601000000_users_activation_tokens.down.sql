@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS users_activation_tokens;
+DROP INDEX IF EXISTS users_email_uindex;
+DROP INDEX IF EXISTS users_username_uindex;
@@ -0,0 +1,19 @@
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_uindex
+    ON users (username);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_uindex
+    ON users (lower(email));
+
+CREATE TABLE IF NOT EXISTS users_activation_tokens
+(
+    id          bigserial
+        CONSTRAINT users_activation_tokens_pk
+            PRIMARY KEY,
+    user_id     bigint      NOT NULL,
+    token_hash  varchar(64) NOT NULL,
+    expiry_date timestamp   NOT NULL,
+    used_date   timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_activation_tokens_token_hash_uindex
+    ON users_activation_tokens (token_hash);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS users_refresh_tokens_user_id_index;
+DROP INDEX IF EXISTS users_refresh_tokens_family_id_index;
+DROP INDEX IF EXISTS users_refresh_tokens_token_hash_uindex;
+
+-- Tokens cannot be recovered from their hashes, so every session is dropped.
+DELETE
+FROM users_refresh_tokens;
+
+ALTER TABLE users_refresh_tokens
+    ADD COLUMN IF NOT EXISTS refresh_token varchar(36) NOT NULL,
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS family_id,
+    DROP COLUMN IF EXISTS token_hash;
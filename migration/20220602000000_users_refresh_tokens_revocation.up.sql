@@ -0,0 +1,24 @@
+ALTER TABLE users_refresh_tokens
+    ADD COLUMN IF NOT EXISTS token_hash varchar(64),
+    ADD COLUMN IF NOT EXISTS family_id  varchar(36),
+    ADD COLUMN IF NOT EXISTS revoked_at timestamp;
+
+-- Existing tokens were never rotated, so each of them starts its own family.
+UPDATE users_refresh_tokens
+SET token_hash = encode(sha256(convert_to(refresh_token, 'UTF8')), 'hex'),
+    family_id  = gen_random_uuid()::varchar(36)
+WHERE token_hash IS NULL;
+
+ALTER TABLE users_refresh_tokens
+    ALTER COLUMN token_hash SET NOT NULL,
+    ALTER COLUMN family_id SET NOT NULL,
+    DROP COLUMN IF EXISTS refresh_token;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_refresh_tokens_token_hash_uindex
+    ON users_refresh_tokens (token_hash);
+
+CREATE INDEX IF NOT EXISTS users_refresh_tokens_family_id_index
+    ON users_refresh_tokens (family_id);
+
+CREATE INDEX IF NOT EXISTS users_refresh_tokens_user_id_index
+    ON users_refresh_tokens (user_id);
@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS roles
+(
+    id   bigserial
+        CONSTRAINT roles_pk
+            PRIMARY KEY,
+    name varchar(50) NOT NULL
+        CONSTRAINT roles_name_uindex
+            UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+    id   bigserial
+        CONSTRAINT permissions_pk
+            PRIMARY KEY,
+    name varchar(100) NOT NULL
+        CONSTRAINT permissions_name_uindex
+            UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS roles_permissions
+(
+    role_id       bigint NOT NULL
+        CONSTRAINT roles_permissions_roles_id_fk
+            REFERENCES roles
+            ON DELETE CASCADE,
+    permission_id bigint NOT NULL
+        CONSTRAINT roles_permissions_permissions_id_fk
+            REFERENCES permissions
+            ON DELETE CASCADE,
+    CONSTRAINT roles_permissions_pk
+        PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS users_roles
+(
+    user_id bigint NOT NULL
+        CONSTRAINT users_roles_users_id_fk
+            REFERENCES users
+            ON DELETE CASCADE,
+    role_id bigint NOT NULL
+        CONSTRAINT users_roles_roles_id_fk
+            REFERENCES roles
+            ON DELETE CASCADE,
+    CONSTRAINT users_roles_pk
+        PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO roles (name)
+VALUES ('admin'),
+       ('sample-publisher')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name)
+VALUES ('sample:publish')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+   OR (r.name = 'sample-publisher' AND p.name = 'sample:publish')
+ON CONFLICT DO NOTHING;
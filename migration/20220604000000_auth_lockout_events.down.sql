@@ -0,0 +1,5 @@
+DELETE
+FROM permissions
+WHERE name = 'auth:lockouts:manage';
+
+DROP TABLE IF EXISTS auth_lockout_events;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS auth_lockout_events
+(
+    id              bigserial
+        CONSTRAINT auth_lockout_events_pk
+            PRIMARY KEY,
+    scope           varchar(10) NOT NULL,
+    username        varchar(30),
+    client_ip       varchar(45),
+    failed_attempts integer     NOT NULL,
+    locked_until    timestamp   NOT NULL,
+    created_date    timestamp   NOT NULL DEFAULT current_timestamp,
+    cleared_date    timestamp,
+    cleared_by      bigint
+);
+
+CREATE INDEX IF NOT EXISTS auth_lockout_events_username_index
+    ON auth_lockout_events (username);
+
+CREATE INDEX IF NOT EXISTS auth_lockout_events_client_ip_index
+    ON auth_lockout_events (client_ip);
+
+INSERT INTO permissions (name)
+VALUES ('auth:lockouts:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+  AND p.name = 'auth:lockouts:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS users_mfa_recovery_codes;
+DROP TABLE IF EXISTS users_mfa;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS users_mfa
+(
+    user_id        bigint
+        CONSTRAINT users_mfa_pk
+            PRIMARY KEY,
+    secret         varchar(64)           NOT NULL,
+    is_enabled     boolean DEFAULT FALSE NOT NULL,
+    last_used_step bigint,
+    created_date   timestamp             NOT NULL DEFAULT current_timestamp,
+    enabled_date   timestamp
+);
+
+CREATE TABLE IF NOT EXISTS users_mfa_recovery_codes
+(
+    id        bigserial
+        CONSTRAINT users_mfa_recovery_codes_pk
+            PRIMARY KEY,
+    user_id   bigint      NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    used_date timestamp
+);
+
+CREATE INDEX IF NOT EXISTS users_mfa_recovery_codes_user_id_index
+    ON users_mfa_recovery_codes (user_id);
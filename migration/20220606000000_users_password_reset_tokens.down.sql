@@ -0,0 +1 @@
+DROP TABLE IF EXISTS users_password_reset_tokens;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS users_password_reset_tokens
+(
+    id           bigserial
+        CONSTRAINT users_password_reset_tokens_pk
+            PRIMARY KEY,
+    user_id      bigint      NOT NULL,
+    token_hash   varchar(64) NOT NULL,
+    expiry_date  timestamp   NOT NULL,
+    created_date timestamp   NOT NULL DEFAULT current_timestamp,
+    used_date    timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_password_reset_tokens_token_hash_uindex
+    ON users_password_reset_tokens (token_hash);
+
+CREATE INDEX IF NOT EXISTS users_password_reset_tokens_user_id_index
+    ON users_password_reset_tokens (user_id);
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id             bigserial
+        CONSTRAINT api_keys_pk
+            PRIMARY KEY,
+    user_id        bigint       NOT NULL,
+    name           varchar(100) NOT NULL,
+    prefix         varchar(12)  NOT NULL,
+    key_hash       varchar(64)  NOT NULL,
+    scopes         text[]       NOT NULL DEFAULT '{}',
+    expiry_date    timestamp,
+    last_used_date timestamp,
+    created_date   timestamp    NOT NULL DEFAULT current_timestamp,
+    created_by     bigint       NOT NULL,
+    revoked_date   timestamp,
+    revoked_by     bigint
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_prefix_uindex
+    ON api_keys (prefix);
+
+CREATE INDEX IF NOT EXISTS api_keys_user_id_index
+    ON api_keys (user_id);
+
+INSERT INTO permissions (name)
+VALUES ('api-keys:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+  AND p.name = 'api-keys:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS users_identities
+(
+    id              bigserial
+        CONSTRAINT users_identities_pk
+            PRIMARY KEY,
+    user_id         bigint       NOT NULL,
+    issuer          varchar(255) NOT NULL,
+    subject         varchar(255) NOT NULL,
+    email           varchar(100),
+    created_date    timestamp    NOT NULL DEFAULT current_timestamp,
+    last_login_date timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_identities_issuer_subject_uindex
+    ON users_identities (issuer, subject);
+
+CREATE INDEX IF NOT EXISTS users_identities_user_id_index
+    ON users_identities (user_id);
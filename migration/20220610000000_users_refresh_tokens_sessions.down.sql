@@ -0,0 +1,4 @@
+ALTER TABLE users_refresh_tokens
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS client_ip,
+    DROP COLUMN IF EXISTS created_date;
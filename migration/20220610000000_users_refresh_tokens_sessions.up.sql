@@ -0,0 +1,4 @@
+ALTER TABLE users_refresh_tokens
+    ADD COLUMN IF NOT EXISTS user_agent   varchar(500),
+    ADD COLUMN IF NOT EXISTS client_ip    varchar(45),
+    ADD COLUMN IF NOT EXISTS created_date timestamp NOT NULL DEFAULT current_timestamp;
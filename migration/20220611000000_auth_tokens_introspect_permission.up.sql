@@ -0,0 +1,11 @@
+INSERT INTO permissions (name)
+VALUES ('auth:tokens:introspect')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+  AND p.name = 'auth:tokens:introspect'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE
+FROM permissions
+WHERE name = 'users:manage';
+
+DROP TABLE IF EXISTS users_audit_events;
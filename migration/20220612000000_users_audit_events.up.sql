@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS users_audit_events
+(
+    id            bigserial
+        CONSTRAINT users_audit_events_pk
+            PRIMARY KEY,
+    user_id       bigint      NOT NULL,
+    actor_user_id bigint      NOT NULL,
+    action        varchar(30) NOT NULL,
+    before        jsonb,
+    after         jsonb,
+    created_date  timestamp   NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS users_audit_events_user_id_index
+    ON users_audit_events (user_id);
+
+INSERT INTO permissions (name)
+VALUES ('users:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+  AND p.name = 'users:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE
+FROM permissions
+WHERE name = 'auth:audit:read';
+
+DROP TABLE IF EXISTS auth_audit_events;
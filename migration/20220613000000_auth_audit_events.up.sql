@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS auth_audit_events
+(
+    id           bigserial
+        CONSTRAINT auth_audit_events_pk
+            PRIMARY KEY,
+    event_type   varchar(40) NOT NULL,
+    user_id      bigint,
+    username     varchar(30),
+    client_ip    varchar(45),
+    user_agent   varchar(500),
+    details      jsonb,
+    created_date timestamp   NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS auth_audit_events_user_id_index
+    ON auth_audit_events (user_id);
+
+CREATE INDEX IF NOT EXISTS auth_audit_events_event_type_created_date_index
+    ON auth_audit_events (event_type, created_date);
+
+CREATE INDEX IF NOT EXISTS auth_audit_events_created_date_index
+    ON auth_audit_events (created_date);
+
+INSERT INTO permissions (name)
+VALUES ('auth:audit:read')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO roles_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles AS r
+         CROSS JOIN permissions AS p
+WHERE r.name = 'admin'
+  AND p.name = 'auth:audit:read'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+UPDATE users_audit_events
+SET actor_user_id = user_id
+WHERE actor_user_id IS NULL;
+
+ALTER TABLE users_audit_events
+    ALTER COLUMN actor_user_id SET NOT NULL;
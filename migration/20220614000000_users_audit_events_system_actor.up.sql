@@ -0,0 +1,2 @@
+ALTER TABLE users_audit_events
+    ALTER COLUMN actor_user_id DROP NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS samples
+(
+    id            bigserial
+        CONSTRAINT samples_pk
+            PRIMARY KEY,
+    sample_name   varchar(100) NOT NULL,
+    sample_type   varchar(50)  NOT NULL,
+    sample_code   integer,
+    sample_status integer      NOT NULL DEFAULT 0,
+    created_date  timestamp    NOT NULL DEFAULT current_timestamp,
+    modified_date timestamp,
+    modified_by   varchar(100)
+);
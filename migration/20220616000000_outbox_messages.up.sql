@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS outbox_messages
+(
+    id                bigserial
+        CONSTRAINT outbox_messages_pk
+            PRIMARY KEY,
+    topic             varchar(100) NOT NULL,
+    ordering_key      varchar(100) NOT NULL DEFAULT '',
+    payload           bytea        NOT NULL,
+    attributes        jsonb        NOT NULL DEFAULT '{}',
+    attempts          integer      NOT NULL DEFAULT 0,
+    last_error        varchar(500),
+    next_attempt_date timestamp    NOT NULL DEFAULT current_timestamp,
+    created_date      timestamp    NOT NULL DEFAULT current_timestamp,
+    sent_date         timestamp,
+    message_id        varchar(100)
+);
+
+CREATE INDEX IF NOT EXISTS outbox_messages_pending_index
+    ON outbox_messages (id)
+    WHERE sent_date IS NULL;
+
+CREATE INDEX IF NOT EXISTS outbox_messages_ordering_key_index
+    ON outbox_messages (ordering_key, id)
+    WHERE sent_date IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE samples
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,17 @@
+ALTER TABLE samples
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by;
+
+ALTER TABLE samples
+    RENAME COLUMN updated_at TO modified_date;
+
+ALTER TABLE samples
+    RENAME COLUMN created_at TO created_date;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,17 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS created_at timestamp NOT NULL DEFAULT current_timestamp,
+    ADD COLUMN IF NOT EXISTS created_by bigint,
+    ADD COLUMN IF NOT EXISTS updated_at timestamp,
+    ADD COLUMN IF NOT EXISTS updated_by bigint,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamp;
+
+ALTER TABLE samples
+    RENAME COLUMN created_date TO created_at;
+
+ALTER TABLE samples
+    RENAME COLUMN modified_date TO updated_at;
+
+ALTER TABLE samples
+    ADD COLUMN IF NOT EXISTS created_by bigint,
+    ADD COLUMN IF NOT EXISTS updated_by bigint,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamp;
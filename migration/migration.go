@@ -0,0 +1,9 @@
+package migration
+
+import "embed"
+
+// Files
+// Sql files of migrations, embedded into the binary so it can migrate db without the files next to it.
+//
+//go:embed *.sql
+var Files embed.FS